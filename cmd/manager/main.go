@@ -19,10 +19,12 @@ import (
 
 	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
 	workv1 "github.com/open-cluster-management/api/work/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
 	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/klog"
 
+	configv1alpha1 "github.com/open-cluster-management/managedcluster-import-controller/pkg/apis/config/v1alpha1"
 	"github.com/open-cluster-management/managedcluster-import-controller/pkg/controller"
 	ocinfrav1 "github.com/openshift/api/config/v1"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
@@ -141,11 +143,21 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := configv1alpha1.Install(mgr.GetScheme()); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
 	if err := rbacv1.AddToScheme(mgr.GetScheme()); err != nil {
 		log.Error(err, "")
 		os.Exit(1)
 	}
 
+	if err := certificatesv1.AddToScheme(mgr.GetScheme()); err != nil {
+		log.Error(err, "")
+		os.Exit(1)
+	}
+
 	if err := certificatesv1beta1.AddToScheme(mgr.GetScheme()); err != nil {
 		log.Error(err, "")
 		os.Exit(1)
@@ -3,7 +3,9 @@ module github.com/open-cluster-management/managedcluster-import-controller
 go 1.16
 
 require (
+	github.com/aws/aws-sdk-go v1.35.24
 	github.com/ghodss/yaml v1.0.1-0.20190212211648-25d852aebe32
+	github.com/google/go-cmp v0.5.2
 	github.com/onsi/ginkgo v1.14.1
 	github.com/onsi/gomega v1.10.2
 	github.com/open-cluster-management/api v0.0.0-20201210143210-581cab55c797
@@ -12,7 +14,11 @@ require (
 	github.com/openshift/api v3.9.1-0.20191112184635-86def77f6f90+incompatible
 	github.com/openshift/hive/apis v0.0.0-20210506000654-5c038fb05190
 	github.com/operator-framework/operator-sdk v0.18.1
+	github.com/prometheus/client_golang v1.7.1
 	github.com/spf13/pflag v1.0.5
+	golang.org/x/net v0.0.0-20201110031124-69a78807bb2b
+	golang.org/x/oauth2 v0.0.0-20200107190931-bf48bf16ab8d
+	golang.org/x/time v0.0.0-20200630173020-3af7569d3a1e
 	k8s.io/api v0.20.5
 	k8s.io/apimachinery v0.20.5
 	k8s.io/client-go v12.0.0+incompatible
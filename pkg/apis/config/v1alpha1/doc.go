@@ -0,0 +1,7 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package v1alpha1 contains API Schema definitions for the config v1alpha1 API group
+// +k8s:deepcopy-gen=package,register
+// +kubebuilder:validation:Optional
+// +groupName=config.open-cluster-management.io
+package v1alpha1
@@ -0,0 +1,116 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:scope=Namespaced
+
+// KlusterletConfig contains the configuration used by the managedcluster-import-controller
+// to render the klusterlet manifests of the managed clusters that reference it.
+// A ManagedCluster references a KlusterletConfig with the annotation
+// agent.open-cluster-management.io/klusterlet-config
+type KlusterletConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec defines the klusterlet configuration
+	Spec KlusterletConfigSpec `json:"spec,omitempty"`
+}
+
+// KlusterletConfigSpec defines the desired configuration of the klusterlet
+type KlusterletConfigSpec struct {
+	// RegistrationOperatorImagePullSpec overrides the image of the klusterlet operator.
+	// +optional
+	RegistrationOperatorImagePullSpec string `json:"registrationOperatorImagePullSpec,omitempty"`
+
+	// RegistrationImagePullSpec overrides the image of the registration agent.
+	// +optional
+	RegistrationImagePullSpec string `json:"registrationImagePullSpec,omitempty"`
+
+	// WorkImagePullSpec overrides the image of the work agent.
+	// +optional
+	WorkImagePullSpec string `json:"workImagePullSpec,omitempty"`
+
+	// Registries includes the mirror and source registries. The source registry will be replaced by the Mirror.
+	// +optional
+	Registries []Registries `json:"registries,omitempty"`
+
+	// KlusterletNamespace is the namespace in which the klusterlet is deployed on the managed cluster.
+	// The namespace must have the prefix "open-cluster-management-".
+	// +optional
+	KlusterletNamespace string `json:"klusterletNamespace,omitempty"`
+
+	// NodePlacement enables explicit control over the scheduling of the klusterlet operator pods.
+	// +optional
+	NodePlacement *NodePlacement `json:"nodePlacement,omitempty"`
+
+	// PullSecret is the image pull secret copied to the managed cluster.
+	// If the namespace is not set, the namespace of the KlusterletConfig is used. The secret must be in the
+	// namespace of the KlusterletConfig or of the managedcluster-import-controller.
+	// +optional
+	PullSecret corev1.ObjectReference `json:"pullSecret,omitempty"`
+
+	// ProxyConfig defines the proxy settings used by the klusterlet to reach the hub.
+	// +optional
+	ProxyConfig *ProxyConfig `json:"proxyConfig,omitempty"`
+}
+
+// ProxyConfig describes the proxy settings of the klusterlet
+type ProxyConfig struct {
+	// HTTPProxy is the URL of the proxy for HTTP requests.
+	// +optional
+	HTTPProxy string `json:"httpProxy,omitempty"`
+
+	// HTTPSProxy is the URL of the proxy for HTTPS requests.
+	// +optional
+	HTTPSProxy string `json:"httpsProxy,omitempty"`
+
+	// NoProxy is a comma-separated list of hostnames, domains and/or CIDRs for which the proxy should not be used.
+	// +optional
+	NoProxy string `json:"noProxy,omitempty"`
+
+	// CABundle is a PEM encoded CA bundle used to verify the certificate of the proxy.
+	// It is appended to the certificate authority data of the bootstrap kubeconfig, it is not used if the
+	// hub kube apiserver has no CA and the system roots are trusted.
+	// +optional
+	CABundle []byte `json:"caBundle,omitempty"`
+}
+
+// Registries describes a registry mirror
+type Registries struct {
+	// Mirror is the mirrored registry of the Source. Will be ignored if Mirror is empty.
+	// +required
+	Mirror string `json:"mirror"`
+
+	// Source is the source registry. All image registries will be replaced by Mirror if Source is empty.
+	// +optional
+	Source string `json:"source,omitempty"`
+}
+
+// NodePlacement describes node scheduling configuration for the pods.
+type NodePlacement struct {
+	// NodeSelector defines which Nodes the Pods are scheduled on.
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations is attached by pods to tolerate any taint that matches
+	// the triple <key,value,effect> using the matching operator <operator>.
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// KlusterletConfigList contains a list of KlusterletConfig.
+type KlusterletConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []KlusterletConfig `json:"items"`
+}
@@ -90,7 +90,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _hubManagedclusterManifestsManagedclusterClusterroleYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x90\x31\x4f\xe3\x50\x0c\xc7\xf7\xf7\x29\xac\x74\xbd\xe6\x74\xdb\xe9\x6d\xa7\x0e\x37\x01\x12\x03\x0b\xea\xe0\x24\x26\x31\x4d\x9e\x1f\xb6\xd3\xaa\x54\xfd\xee\xa8\x4d\x40\x40\xe9\x64\x4b\xfe\xc9\xfe\xfd\xbd\x80\x95\xe4\xbd\x72\xdb\x39\xac\x24\xb9\x72\x35\xba\xa8\x81\x0b\x78\x47\x70\x97\x29\xc1\xaa\x1f\xcd\x49\xe1\x06\x13\xb6\x34\x50\x72\xc8\x2a\xcf\x54\x7b\x08\x98\xf9\x81\xd4\x58\x52\x04\xad\xb0\x2e\x71\xf4\x4e\x94\x5f\xd1\x59\x52\xb9\xf9\x6b\x25\xcb\xef\xed\x9f\xb0\xe1\xd4\xc4\xf7\x55\xf7\xd2\x53\x18\xc8\xb1\x41\xc7\x18\x00\x12\x0e\x14\xc1\xf6\xe6\x34\x44\xc9\x94\x96\xf5\x44\x2e\x87\x8f\xa3\x71\x6a\x9b\x79\x12\x2b\x11\x37\x57\xcc\xf1\x70\x80\x72\x92\x6b\xe6\x03\xb7\x38\x10\x1c\x8f\x41\xc7\x9e\x2c\x86\x05\xfc\xeb\x7b\xd9\xc1\xbc\x01\xb0\x3d\x85\x70\x01\x15\x47\x27\x60\x37\xa8\x49\x9d\x9f\xb8\x46\xa7\xb0\x04\xcc\xfc\x5f\x65\xcc\x16\xe1\xb1\xf8\x34\xb2\x39\x52\xb1\x0e\x00\x4a\x26\xa3\xd6\x74\x01\x71\x9b\x38\xb5\x4a\x2f\x23\x99\xdb\x99\xdd\x92\x56\x13\xa7\x84\x4e\xc5\x2f\x28\x5a\xf2\x53\xe9\xd9\xce\x75\x87\x5e\x77\xc5\xfa\xba\x6c\x4b\x7e\x61\x36\xc5\x2d\xaf\xbc\xec\x47\xd1\xaf\x6f\xfc\x66\x37\x3b\xcd\x92\xeb\xf0\x16\x00\x00\xff\xff\xf7\xf9\xea\x7a\x21\x02\x00\x00")
+var _hubManagedclusterManifestsManagedclusterClusterroleYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x74\x90\xb1\x6e\xdb\x40\x0c\x86\xf7\x7b\x0a\x42\x5e\x6b\x15\xdd\x8a\xdb\x0a\x0f\x9d\xda\x02\x1d\xba\x14\x1e\xa8\x13\x2b\xb1\x96\x8e\x17\x92\xb2\xe1\x18\x7e\xf7\xc0\x96\x12\x24\x71\x3c\x91\x00\x3f\x90\xdf\xcf\x15\x6c\xa4\x1c\x95\xbb\xde\x61\x23\xd9\x95\x9b\xc9\x45\x0d\x5c\xc0\x7b\x82\x5f\x85\x32\x6c\x86\xc9\x9c\x14\x7e\x60\xc6\x8e\x46\xca\x0e\x45\xe5\x3f\x25\x0f\x01\x0b\xff\x21\x35\x96\x1c\x41\x1b\x4c\x35\x4e\xde\x8b\xf2\x23\x3a\x4b\xae\x77\x5f\xad\x66\xf9\xbc\xff\x12\x76\x9c\xdb\xf8\xbc\xea\xb7\x0c\x14\x46\x72\x6c\xd1\x31\x06\x80\x8c\x23\x45\xb0\xa3\x39\x8d\x51\x0a\xe5\x75\x9a\xc9\xf5\xf8\x72\x34\xce\x6d\xbb\x4c\x62\x23\xe2\xe6\x8a\x25\x9e\x4e\x50\xcf\x72\xed\x72\xe0\x27\x8e\x04\xe7\x73\xd0\x69\x20\x8b\x61\x05\xdf\x86\x41\x0e\xb0\x6c\x00\xec\x2e\x21\x5c\x40\xc5\xd1\x09\xd8\x0d\x12\xa9\xf3\x3f\x4e\xe8\x14\xd6\x80\x85\xbf\xab\x4c\xc5\x22\xfc\xad\x5e\x8d\x6c\x89\x54\x6d\x03\x80\x92\xc9\xa4\x89\x6e\x20\xee\x32\xe7\x4e\xe9\x61\x22\x73\xbb\xb2\x7b\xd2\x66\xe6\x94\xd0\xa9\xfa\x04\x55\x47\x7e\x29\x03\xdb\xb5\x1e\xd0\x53\x5f\x6d\xef\xcb\x76\xe4\x37\x66\x73\xdc\xfa\xce\xcb\x3e\x14\x7d\xfb\xc6\x77\x76\x8b\x53\x52\x42\xa7\x6a\x1b\x9e\x06\x00\xf7\xf9\xea\x7a\x21\x02\x00\x00")
 
 func hubManagedclusterManifestsManagedclusterClusterroleYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -110,7 +110,7 @@ func hubManagedclusterManifestsManagedclusterClusterroleYaml() (*asset, error) {
 	return a, nil
 }
 
-var _hubManagedclusterManifestsManagedclusterClusterrolebindingYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x90\x31\x6b\x02\x41\x10\x85\xfb\xfd\x15\x83\xa9\xbd\x90\x2e\x6c\x17\x2d\x52\x25\x01\x03\xe9\xe7\xf6\x26\x3a\xd1\x9d\x59\x66\x67\x05\x23\xfe\xf7\xa0\x9e\x42\x10\xd2\xa5\x5b\x98\xf7\xde\xf7\xb1\x77\x30\xd7\xb2\x33\x5e\xae\x1c\xe6\x2a\x6e\xdc\x37\x57\xab\xe0\x0a\xbe\x22\x78\x2b\x24\x30\xdf\xb4\xea\x64\xf0\x82\x82\x4b\xca\x24\x0e\xc5\xf4\x8b\x92\x87\x80\x85\x3f\xc8\x2a\xab\x44\xb0\x1e\x53\x87\xcd\x57\x6a\xfc\x8d\xce\x2a\xdd\xfa\xb1\x76\xac\xf7\xdb\x87\xb0\x66\x19\xe2\x65\x6a\xa1\x1b\x9a\xb1\x0c\x2c\xcb\x90\xc9\x71\x40\xc7\x18\x00\x04\x33\x45\xa8\xbb\xea\x94\xa3\x16\x92\x69\x3a\x17\xa6\xf9\xca\x8e\xe7\xe7\x30\x5e\x62\xaf\xea\xd5\x0d\x4b\xdc\xef\xa1\x3b\x3b\x0e\x23\xe7\x15\x33\xc1\xe1\x10\x4c\x37\xb4\xa0\xcf\x23\x02\x0b\x3f\x9b\xb6\xf2\x87\x6e\x00\xb8\xb1\xfd\x47\xb9\xda\xfa\xe3\x5f\xd6\x18\xa6\x23\xf7\x9d\x6c\xcb\x89\x9e\x52\xd2\x26\x7e\x45\x4f\x8e\x1b\xb3\xcb\xe2\xef\xd0\x38\x36\x19\xc3\xb5\x60\xba\x34\x6e\xa9\xa7\xeb\x29\xfd\x13\x00\x00\xff\xff\xa1\xce\xea\x83\x00\x02\x00\x00")
+var _hubManagedclusterManifestsManagedclusterClusterrolebindingYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x90\x41\x4b\x03\x31\x10\x85\xef\xf9\x15\x43\x3d\x77\xc5\x9b\xe4\x66\x7b\xf0\xa4\x42\x05\xef\xb3\xd9\xb1\x1d\xdb\xcc\x84\xc9\xa4\x50\xcb\xfe\x77\x29\xdd\x16\xa4\xe0\xcd\x5b\x20\xef\xbd\xef\x4b\xee\x60\xa9\xe5\x60\xbc\xde\x38\x2c\x55\xdc\xb8\x6f\xae\x56\xc1\x15\x7c\x43\xf0\x56\x48\x60\xb9\x6b\xd5\xc9\xe0\x05\x05\xd7\x94\x49\x1c\x8a\xe9\x17\x25\x0f\x01\x0b\x7f\x90\x55\x56\x89\x60\x3d\xa6\x0e\x9b\x6f\xd4\xf8\x1b\x9d\x55\xba\xed\x63\xed\x58\xef\xf7\x0f\x61\xcb\x32\xc4\xcb\xd4\x4a\x77\xb4\x60\x19\x58\xd6\x21\x93\xe3\x80\x8e\x31\x00\x08\x66\x8a\x50\x0f\xd5\x29\x47\x2d\x24\xf3\x74\x2e\xcc\xf3\x95\x1d\xcf\xc7\x61\xba\x89\xbd\xaa\x57\x37\x2c\xf1\x78\x84\xee\xec\x38\x4c\x9c\x57\xcc\x04\xe3\x18\x4c\x77\xb4\xa2\xcf\x13\x02\x0b\x3f\x9b\xb6\xf2\x87\x6e\x00\xb8\xb1\xfd\x47\xb9\xda\xfa\xd3\x5f\xd6\x18\xe6\x13\xf7\x9d\x6c\xcf\x89\x9e\x52\xd2\x26\x7e\x45\xcf\x4e\x0f\x5c\x5c\x16\x7f\x87\xa6\xb1\xd9\x14\xae\x05\xd3\xa5\x71\x4b\xad\x05\x13\xc1\x38\xce\xc2\xcf\x00\xa1\xce\xea\x83\x00\x02\x00\x00")
 
 func hubManagedclusterManifestsManagedclusterClusterrolebindingYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -130,7 +130,7 @@ func hubManagedclusterManifestsManagedclusterClusterrolebindingYaml() (*asset, e
 	return a, nil
 }
 
-var _hubManagedclusterManifestsManagedclusterServiceAccountYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x54\xce\xb1\x6a\xc4\x30\x10\x84\xe1\x5e\x4f\x31\x38\x7d\x20\xad\xba\xc4\x75\x9c\x22\x90\x7e\x23\x2f\xb6\x92\xd3\xae\x58\xad\x0d\x87\xf1\xbb\x1f\xdc\xd9\xc5\xd5\xf3\x0d\xfc\x2f\xe8\xb5\x5e\x2d\x4f\xb3\xa3\x57\x71\xcb\xbf\x8b\xab\x35\xb8\xc2\x67\xc6\x57\x65\x41\x7f\x59\x9a\xb3\xe1\x93\x84\x26\x2e\x2c\x8e\x6a\xfa\xc7\xc9\x43\xa0\x9a\x7f\xd8\x5a\x56\x89\x58\xdf\xc2\x7f\x96\x31\xe2\x9b\x6d\xcd\x89\xdf\x53\xd2\x45\x3c\x14\x76\x1a\xc9\x29\x06\x40\xa8\x70\x44\xb7\x6d\x78\xfd\x50\xf5\xe6\x46\xf5\x99\x0f\x54\x18\xfb\xde\x1d\xb8\x55\x4a\xe7\xe3\x11\x30\x1e\x3d\xc3\xb9\xde\xf5\x2d\x00\x00\xff\xff\x9b\xd4\xc7\xa7\xca\x00\x00\x00")
+var _hubManagedclusterManifestsManagedclusterServiceAccountYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x54\xce\xb1\x4e\xc5\x30\x0c\x85\xe1\x3d\x4f\x71\x54\x76\x24\xd6\x6c\xd0\x99\x32\x20\xb1\x9b\xd4\x6a\x03\xc4\x8e\x1c\xb7\x12\xaa\xf2\xee\x77\xb8\xed\x70\xe7\xf3\x1f\xe9\x7b\xc2\xa8\xf5\xdf\xf2\xb2\x3a\x46\x15\xb7\xfc\xbd\xb9\x5a\x83\x2b\x7c\x65\x7c\x54\x16\x8c\x7f\x5b\x73\x36\xbc\x93\xd0\xc2\x85\xc5\x51\x4d\x7f\x38\x79\x08\x54\xf3\x17\x5b\xcb\x2a\x11\xfb\x4b\xf8\xcd\x32\x47\x7c\xb2\xed\x39\xf1\x6b\x4a\xba\x89\x87\xc2\x4e\x33\x39\xc5\x00\x08\x15\x8e\x18\x8e\x03\xcf\x6f\xaa\xde\xdc\xa8\x3e\xe6\x13\x15\x46\xef\xc3\x19\xb7\x4a\xe9\x7a\xdc\x01\xf3\xe9\x99\xae\x15\xbd\x0f\xe1\x36\x00\x9b\xd4\xc7\xa7\xca\x00\x00\x00")
 
 func hubManagedclusterManifestsManagedclusterServiceAccountYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -150,7 +150,7 @@ func hubManagedclusterManifestsManagedclusterServiceAccountYaml() (*asset, error
 	return a, nil
 }
 
-var _klusterletBootstrap_secretYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x44\x8d\xb1\x4e\xc3\x40\x10\x44\xfb\xfb\x8a\x91\xa9\x83\x44\x7b\x25\x2e\x23\x48\x81\x44\xbf\x76\x16\xfb\x48\xbc\xbb\xec\xed\x21\x45\x51\xfe\x1d\x01\x36\xd4\x33\xef\xbd\x3b\xf4\x6a\x17\x2f\xd3\x1c\xe8\x55\xc2\xcb\xd0\x42\xbd\x22\x14\x31\x33\x0e\xc6\x82\xfe\xdc\x6a\xb0\xe3\x89\x84\x26\x5e\x58\x02\xe6\xfa\xce\x63\xa4\x44\x56\x5e\xd9\x6b\x51\xc9\xf8\x7c\x48\xa7\x22\xc7\x8c\x17\x1e\x9d\x23\x2d\x1c\x74\xa4\xa0\x9c\x00\xa1\x85\x33\xba\x41\x35\x6a\x38\xd9\x6e\x6e\xc3\xee\xd4\x06\x1e\x55\xde\xca\xd4\xad\x97\x6a\x34\x7e\xff\xae\x57\xdc\xef\x7f\xb3\x67\x8e\xe7\x6d\xc1\xed\xd6\xa5\xb8\x18\x67\x1c\x8c\x3e\x1a\xa7\xcd\xff\xaf\x5a\xe9\xc7\xad\xb4\xff\x5b\x7e\xe8\xaf\x00\x00\x00\xff\xff\xfb\xf6\x72\x27\xf2\x00\x00\x00")
+var _klusterletBootstrap_secretYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x44\x8d\xb1\x4e\xc3\x40\x10\x44\xfb\xfb\x8a\x91\xa9\x83\x44\x7b\x25\x2e\x23\x48\x81\x44\xbf\x76\x16\xfb\x48\xbc\xbb\xec\xed\x21\x45\x91\xff\x1d\x21\x62\x52\xcf\x9b\xf7\x1e\xd0\xab\x5d\xbc\x4c\x73\xa0\x57\x09\x2f\x43\x0b\xf5\x8a\x50\xc4\xcc\x38\x18\x0b\xfa\x73\xab\xc1\x8e\x17\x12\x9a\x78\x61\x09\x98\xeb\x27\x8f\x91\x12\x59\x79\x67\xaf\x45\x25\xe3\xfb\x29\x9d\x8a\x1c\x33\xde\x78\x74\x8e\xb4\x70\xd0\x91\x82\x72\x02\x84\x16\xce\xe8\x06\xd5\xa8\xe1\x64\xbb\xb9\x0d\xbb\x53\x1b\x78\x54\xf9\x28\x53\x77\x43\xaa\xd1\xf8\xcb\x5d\xaf\x78\xdc\xff\x65\xcf\x1c\xaf\xdb\x82\x75\xed\x52\x5c\x8c\x33\x0e\x46\x5f\x8d\xd3\xe6\xbf\xab\x6e\xef\xe7\xad\xb4\xff\x5f\xb0\xae\x5d\xfa\x19\x00\xfb\xf6\x72\x27\xf2\x00\x00\x00")
 
 func klusterletBootstrap_secretYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -170,7 +170,7 @@ func klusterletBootstrap_secretYaml() (*asset, error) {
 	return a, nil
 }
 
-var _klusterletCluster_roleYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x55\x3d\x8f\xdb\x30\x0c\xdd\xfd\x2b\x04\xdf\x7a\x49\xd0\xad\xf0\x56\x64\xe8\x54\x14\xe8\xd0\xa5\xc8\xc0\x48\x8c\xa3\x46\x16\x05\x92\x4a\x7a\xfd\xf5\x85\x9c\x8f\x36\xb1\xdd\xe6\xd0\x0c\x37\x59\x5f\x7c\x7c\xef\x89\xa2\x9f\xcc\x92\xd2\x0b\xfb\x76\xab\x66\x49\x51\xd9\xaf\xb3\x12\x8b\x51\x32\xba\x45\xf3\x39\x61\x34\xcb\x90\x45\x91\xcd\x27\x88\xd0\x62\x87\x51\x4d\x62\xfa\x8e\x56\xab\x0a\x92\xff\x8a\x2c\x9e\x62\x63\x78\x0d\x76\x0e\x59\xb7\xc4\xfe\x27\xa8\xa7\x38\xdf\xbd\x97\xb9\xa7\xc5\xfe\x5d\xb5\xf3\xd1\x35\x67\xa8\x2f\x14\xb0\xea\x50\xc1\x81\x42\x53\x19\x13\xa1\xc3\xc6\xec\x8e\xbb\x01\xb5\xe2\x1c\x50\x9a\xea\xc9\x7c\x08\x81\x0e\x3d\x17\xc6\xd6\x8b\x72\x0f\x3c\xa3\x84\x0c\x4a\x5c\x88\x5a\x46\x50\x34\x07\xe2\x5d\x20\x70\xd5\xcc\x40\xf2\x1f\x99\x72\x92\xc6\x7c\xab\xeb\x55\x65\x0c\xa3\x50\x66\x8b\xfd\x8a\xa0\x65\x54\xa9\x9f\x4d\x6d\x29\x6e\x7c\xdb\x41\xea\x67\x82\xbc\xf7\x16\xc1\x5a\xca\x51\xa5\x8f\xdc\x23\xaf\xfb\xa8\x63\x9a\x72\xac\x45\x2d\x9f\xe0\xa5\xff\xe6\xe4\x4e\x1b\x07\x50\xbb\x2d\x83\x74\x1e\x38\x0c\xa8\x58\xaf\x6e\x49\x8d\xd9\x34\x42\x34\xaf\x8b\xcd\x60\x2d\x8a\x30\xee\x3d\x1e\xc6\x49\x0d\xf0\x87\x58\xc5\x62\x49\x60\xf1\x5e\x59\x27\x31\x93\x12\x46\x52\x90\xbb\x45\x1f\xc5\x1c\x42\x3d\x9b\x1a\xf7\x18\x55\x26\xad\x38\x6e\x4f\x51\xbf\xf8\x7d\xba\x8b\xa1\xdf\x29\xc9\x10\xd4\x61\x0a\xf4\xd2\xfd\x0d\xf9\x01\x77\x3d\xf9\x2e\x86\x84\xec\xf1\x05\x30\x05\x5c\xfb\xe8\x7c\x6c\xfb\xba\xbc\x9a\xbf\x35\xa2\x17\x86\x0f\xa4\x56\xea\x41\x2c\x84\xd3\xb9\xa2\xbd\x5e\xbd\xaa\x1b\x58\x76\x32\x2c\x02\x8f\x3f\x14\x63\x69\x57\xd3\x95\x66\xb3\x28\x75\xe7\x25\x87\x1b\x1f\x7d\xc9\xf2\x50\xe7\xef\x92\xd2\xf5\x0d\xf7\x8f\xb6\x58\xe4\xc8\xfc\x56\xd6\x39\x64\x4e\x09\xe3\xec\x74\x33\xb3\xee\xd2\xad\x47\x55\xfe\x06\xbd\xe7\xc5\x5e\xc9\xfa\x67\x1d\xfd\x2f\xa1\x85\x28\x68\xbe\xe1\x75\x9b\xff\x4e\x0f\x8b\x8e\xc5\x31\x76\xd1\x07\x1a\x48\x29\x78\x74\x1d\x44\xbf\x41\xd1\xf2\xdb\x18\x7a\x5a\x56\x5f\x45\x7f\x0c\xf5\x5a\xc0\xb0\x3e\xce\x42\x7e\x05\x00\x00\xff\xff\xcd\x10\xb6\x44\x86\x07\x00\x00")
+var _klusterletCluster_roleYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x95\xbb\x8e\xdb\x3c\x10\x85\x7b\x3d\x05\xa1\x6d\xd7\x36\xfe\xee\x87\xba\xc0\x45\xaa\x20\x40\x8a\x34\x81\x8b\x31\x39\x96\x19\x53\x1c\x62\x66\x68\x67\xf3\xf4\x01\xe5\xcb\x66\x75\x49\xbc\x80\x8b\x54\xb2\x48\xcd\xe1\xf9\x0e\xc9\xf1\x93\x59\x53\x7a\x61\xdf\xee\xd5\xac\x29\x2a\xfb\x6d\x56\x62\x31\x4a\x46\xf7\x68\x3e\x27\x8c\x66\x1d\xb2\x28\xb2\xf9\x04\x11\x5a\xec\x30\xaa\x49\x4c\xdf\xd1\x6a\x55\x41\xf2\x5f\x91\xc5\x53\x6c\x0c\x6f\xc1\x2e\x21\xeb\x9e\xd8\xff\x04\xf5\x14\x97\x87\xff\x65\xe9\x69\x75\xfc\xaf\x3a\xf8\xe8\x9a\xab\xd4\x17\x0a\x58\x75\xa8\xe0\x40\xa1\xa9\x8c\x89\xd0\x61\x63\x0e\xe7\xd9\x80\x5a\x71\x0e\x28\x4d\xf5\x64\x3e\x84\x40\xa7\xde\x0b\x63\xeb\x45\xb9\x17\x5e\x50\x42\x06\x25\x2e\x46\x2d\x23\x28\x9a\x13\xf1\x21\x10\xb8\x6a\x61\x20\xf9\x8f\x4c\x39\x49\x63\xbe\xd5\xf5\xa6\x32\x86\x51\x28\xb3\xc5\x7e\x44\xd0\x32\xaa\xd4\xcf\xa6\xb6\x14\x77\xbe\xed\x20\xf5\x6f\x82\x7c\xf4\x16\xc1\x5a\xca\x51\xa5\xaf\x3c\x22\x6f\xfb\xaa\xf3\x32\xe5\xb3\x16\xb5\x3c\x82\x97\xfe\x99\x93\xbb\x4c\x9c\x40\xed\xbe\x0c\xa5\xeb\x0f\x87\x01\x15\xeb\xcd\xd0\xd4\x54\x4c\x13\x46\xf3\xb6\xc4\x0c\xd6\xa2\x08\xe3\xd1\xe3\x69\xda\xd4\xe6\xef\xd0\x25\x62\x49\x60\xf1\x5e\xac\x0b\xcc\x2c\xc2\xc4\x12\xe4\x86\xea\x93\x9a\x63\xa9\x67\x53\xe3\x11\xa3\xca\x6c\x14\xe7\xe9\x39\xeb\xb7\xbc\x2f\x7b\x31\x5a\x01\x52\x92\xb1\xa8\xc3\x14\xe8\xa5\xfb\x93\xf2\x03\xf6\x7a\xf6\x5e\x8c\x0d\xd9\xf3\x0d\x60\x0a\xb8\xf5\xd1\xf9\xd8\xf6\xe7\xf2\xcd\xfb\xbf\x66\xf4\xe6\xf0\x81\xd6\xca\x79\x10\x0b\xe1\xf2\x5d\x61\xaf\x37\xef\xea\x06\x96\x9d\x0c\xf9\x20\x79\xfc\xa1\x18\x4b\xbb\x9a\x3f\x69\x36\x8b\x52\x77\x1d\x72\xb8\xf3\xd1\x97\x2c\x1e\x9a\xfc\x5d\x28\x5d\xdf\x70\x7f\x6b\x8b\x05\x47\x96\x43\xac\x6b\xc9\x92\x12\xc6\xc5\x65\x67\x16\xdd\xad\x5b\x4f\x52\xbe\x8a\x0e\xb8\x06\x34\x37\x86\x57\xac\x31\xcd\x83\x0d\xad\x44\x41\xf3\xc0\xd7\x70\xfd\x3b\x33\x2c\xbb\xb2\x3a\xd7\xae\xfa\x42\x03\x29\x05\x8f\xae\x83\xe8\x77\x28\x5a\xfe\x36\xc6\x99\x96\xd1\x77\xd9\x9f\x52\x7d\x0b\x30\x3e\x1f\x09\xd4\xee\xeb\x4d\xf5\x6b\x00\xcd\x10\xb6\x44\x86\x07\x00\x00")
 
 func klusterletCluster_roleYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -190,7 +190,7 @@ func klusterletCluster_roleYaml() (*asset, error) {
 	return a, nil
 }
 
-var _klusterletCluster_role_bindingYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x8e\x31\x4f\xc3\x40\x0c\x85\xf7\xfb\x15\x56\x99\x09\x62\x43\xb7\x41\x06\x06\x04\x48\x45\x62\x77\x2e\x26\x31\x49\xec\x93\xcf\x57\x09\xaa\xfe\x77\x54\x95\xb2\x10\x75\xf5\xe7\xf7\xbe\x77\x05\xad\xe6\x2f\xe3\x61\x74\x68\x55\xdc\xb8\xab\xae\x56\xc0\x15\x7c\x24\x78\xcd\x24\xd0\xce\xb5\x38\x19\x3c\xa3\xe0\x40\x0b\x89\x43\x36\xfd\xa4\xe4\x21\x60\xe6\x77\xb2\xc2\x2a\x11\xac\xc3\xd4\x60\xf5\x51\x8d\xbf\xd1\x59\xa5\x99\xee\x4a\xc3\x7a\xb3\xbb\x0d\x13\x4b\x1f\xcf\x55\x5b\x9d\xe9\x81\xa5\x67\x19\xc2\x42\x8e\x3d\x3a\xc6\x00\x20\xb8\x50\x84\xe9\xf4\x34\x93\x07\xd3\x99\xb6\xf4\x71\x64\x98\xf9\xd1\xb4\xe6\x0b\x9e\x00\xf0\x4f\xb3\xd6\x5a\x6a\x77\x5c\x5f\x62\xb8\xfe\x0d\xbc\x91\xed\x38\xd1\x7d\x4a\x5a\xc5\xd7\x32\xa7\x53\xc9\x98\x28\xc2\x66\xbf\x87\xe6\xe9\x0f\xbe\x9c\x09\x1c\x0e\x9b\xf0\x13\x00\x00\xff\xff\xf2\xf1\x30\xe3\x54\x01\x00\x00")
+var _klusterletCluster_role_bindingYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x8e\x31\x4f\xc3\x40\x0c\x85\xf7\xfb\x15\x56\x99\x09\x62\x43\xb7\x41\x06\x06\x04\x48\x45\x62\x77\x2e\x26\x31\x49\xec\x93\xcf\x57\x09\xaa\xfe\x77\x54\x95\xb2\x10\x75\xf5\xe7\xf7\xbe\x77\x05\xad\xe6\x2f\xe3\x61\x74\x68\x55\xdc\xb8\xab\xae\x56\xc0\x15\x7c\x24\x78\xcd\x24\xd0\xce\xb5\x38\x19\x3c\xa3\xe0\x40\x0b\x89\x43\x36\xfd\xa4\xe4\x21\x60\xe6\x77\xb2\xc2\x2a\x11\xac\xc3\xd4\x60\xf5\x51\x8d\xbf\xd1\x59\xa5\x99\xee\x4a\xc3\x7a\xb3\xbb\x0d\x13\x4b\x1f\xcf\x55\x5b\x9d\xe9\x81\xa5\x67\x19\xc2\x42\x8e\x3d\x3a\xc6\x00\x20\xb8\x50\x84\xe9\xf4\x34\x93\x07\xd3\x99\xb6\xf4\x71\x64\x98\xf9\xd1\xb4\xe6\x0b\x9e\x00\xf0\x4f\xb3\xd6\x5a\x6a\x77\x5c\x5f\x62\xb8\xfe\x0d\xbc\x91\xed\x38\xd1\x7d\x4a\x5a\xc5\xd7\x32\xa7\x53\xc9\x98\x28\xc2\x66\xbf\x87\xe6\xe9\x0f\xbe\x9c\x09\x1c\x0e\x9b\xf0\x33\x00\xf2\xf1\x30\xe3\x54\x01\x00\x00")
 
 func klusterletCluster_role_bindingYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -210,7 +210,7 @@ func klusterletCluster_role_bindingYaml() (*asset, error) {
 	return a, nil
 }
 
-var _klusterletCrdsV10000_00_operatorOpenClusterManagementIo_klusterletsCrdYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5a\x6d\x6f\xdb\x46\xf2\x7f\xef\x4f\x31\xd0\xff\x0f\x24\xce\x49\x74\x9d\x1e\x7a\xad\x80\x20\xc8\xb9\xcd\x21\x48\xd3\x06\x71\x9a\x02\x67\xf9\xae\x43\xee\x50\xda\x9a\xdc\x65\x77\x97\xb2\xd5\xa2\xdf\xfd\x30\xbb\x7c\x16\x29\x39\x4d\x72\x2f\x0e\xe5\x2b\x89\x5c\xce\xce\xe3\x6f\x1e\x96\x58\xc8\x77\x64\xac\xd4\x6a\x09\x58\x48\xba\x73\xa4\xf8\x9f\x8d\x6e\xbe\xb4\x91\xd4\x67\xdb\xf3\x93\x1b\xa9\xc4\x12\x2e\x4a\xeb\x74\xfe\x86\xac\x2e\x4d\x42\x5f\x53\x2a\x95\x74\x52\xab\x93\x9c\x1c\x0a\x74\xb8\x3c\x01\x50\x98\xd3\x12\x6e\xb2\xd2\x3a\x32\x19\x39\x1b\xe9\x82\x0c\x3a\x6d\xf8\x87\x5a\x24\xe1\xc9\x22\x47\x85\x6b\xca\x49\xb9\x48\xea\x13\x5b\x50\xc2\x6f\xaf\x8d\x2e\x8b\x25\xdc\xe7\x95\xb0\x95\xe5\xb7\x00\x02\x83\x2f\x9b\x5d\xfd\xcd\x4c\x5a\xf7\x72\xf0\xe0\x5b\x69\xc3\xc3\x22\x2b\x0d\x66\x3d\x4e\xfd\x7d\x2b\xd5\xba\xcc\xd0\x74\x9f\x9c\x00\xd8\x44\x17\xb4\x84\x8b\x70\xef\x04\xa0\x30\x64\xc9\x6c\xe9\x07\x75\xa3\xf4\xad\x7a\x2e\x29\x13\x76\x09\x29\x66\x96\x4e\x00\xb6\x41\xa5\x9e\xbd\x45\xa5\x94\xed\x79\xd8\x21\xd9\x50\x8e\x81\x6f\x60\x51\xd5\xb3\xd7\x2f\xde\x7d\x7e\xd9\xbb\x0d\x20\xc8\x26\x46\x16\xce\x1b\xa6\x15\x00\x0c\xf9\xad\x95\xb3\x90\x68\xe5\x8c\xce\x32\x32\x16\xb4\x02\xb7\x21\x08\x3a\x12\x50\xe9\x2c\x82\x1f\x37\xa4\x1a\x9a\xc0\xaf\xa4\x72\x5d\x1a\x12\x73\xbf\xbe\x47\xf8\x97\x52\x1a\xb2\x80\x60\x29\x31\xe4\x3c\xdb\x02\x74\x0a\xb1\xd6\xce\x3a\x83\xc5\x62\x53\xc6\x8b\x9b\x32\xa6\x40\xa7\x43\x58\x86\xfd\x2d\xe6\x14\x0c\x53\x60\x42\xe0\x34\x60\x96\xe9\x5b\x78\xf6\xfa\x85\xdf\x80\xac\xb3\x7c\x97\xd7\x6e\xca\x18\x52\x6d\xfc\x6f\x43\x6b\xc9\x3b\x78\x7f\x6a\xa9\x16\x46\x3b\x9d\xe8\x2c\x6a\xee\xb9\x1d\xdb\x41\xc7\x3f\x53\xe2\x4e\x3a\xcb\x0a\x32\x4e\xd6\xee\x10\xae\x8e\x67\x77\xee\x0e\x34\xfb\x80\x95\x1f\x56\x81\x60\x97\x26\xeb\x19\xaa\x0c\x48\xa2\xb2\x17\xab\xc1\x6d\xa4\x6d\xf5\x3f\xe4\xd5\x5b\x33\x05\x54\x15\x77\x11\x5c\xb2\x83\x18\x0b\x76\xa3\xcb\x4c\xb0\xee\xb7\x64\x58\xd1\x89\x5e\x2b\xf9\x6b\x43\xbb\xd1\x48\x86\x8e\xac\x1b\xd0\x94\xca\x91\x51\x98\xc1\x16\xb3\x92\xe6\x80\x4a\x40\x8e\x3b\x30\xc4\xbb\x40\xa9\x3a\xf4\xfc\x12\x1b\xc1\x2b\x6d\x08\xa4\x4a\xf5\x12\x36\xce\x15\x76\x79\x76\xb6\x96\xae\x8e\xe8\x44\xe7\x79\xa9\xa4\xdb\x9d\x79\x0f\x92\x71\xe9\xb4\xb1\x67\x82\xb6\x94\x9d\x59\xb9\x5e\xa0\x49\x36\xd2\x51\xe2\x4a\x43\x67\x58\xc8\x85\x67\x5d\x39\x0f\x0b\xb9\xf8\x3f\x53\x61\x80\x7d\xd0\xe3\x35\xd8\xc6\x3a\x23\x55\xd7\x35\x7c\x70\x1e\xb0\x00\xc7\x28\x48\xef\x77\xfe\xd5\x20\x45\xab\x68\xbe\xc5\xda\x79\xf3\xcd\xe5\x5b\xa8\xb7\xf6\xc6\x18\x6a\xdf\xeb\xbd\x13\x21\xad\x09\x58\x61\x52\xa5\x64\x82\x11\x53\xa3\x73\x4f\x93\x94\x28\xb4\x54\xce\xff\x49\x32\x49\x6a\xa8\x7e\x5b\xc6\xb9\x74\xb6\xeb\xbd\x11\x5c\xa0\x52\xda\x41\x4c\x50\x16\x02\x1d\x89\x08\x5e\x28\xb8\xc0\x9c\xb2\x0b\xb4\xf4\xc9\x0d\xc0\x9a\xb6\x0b\x56\xec\xfd\x4c\xd0\x45\xe8\xe1\xe2\x41\x2c\x01\xd4\x60\x3c\x61\xaf\xcb\x82\x92\x2e\x0a\xb1\xe6\x04\x59\x69\x48\x80\xa0\x22\xd3\x3b\x86\xe8\x06\x69\x7c\x98\x70\x60\x0c\xd0\xb9\xbd\x70\xcd\x90\x7e\x1f\xce\xa6\x22\x9d\xaf\x0a\xf1\xbe\x63\xa8\x1d\x3c\x1a\x08\x70\xd1\xae\x64\xb7\x63\xfe\x19\xb1\x42\x84\xef\x41\x28\xc7\x66\x4c\x7b\x14\x01\x12\x43\x6c\x79\x46\xde\x4d\x19\x47\xf0\xb6\x0f\xa7\x5e\x2a\x58\x93\xe2\x54\xe6\x51\xd5\xa0\x12\x3a\x0f\x7b\xc9\x74\x84\xa2\x74\xcc\x0f\x3b\x96\x25\x37\x07\x6d\x40\x48\x9b\x68\xef\xc0\xcc\x19\x16\x2c\xbe\x91\xe8\xa8\xe1\x2e\x70\xae\xe0\xfb\x82\xd4\xe5\x46\xa6\x03\x45\x1e\xf0\x09\xbe\x38\xdb\x33\xb6\x84\x30\xf9\xe1\xcd\xb7\x7b\x6a\x1d\xe8\xee\x9b\xbd\x17\x86\xae\x80\x3e\xef\x7a\x24\x2c\xa4\x4f\x91\x66\x44\xd4\xd2\x64\xd6\x23\x59\x82\x10\x97\x4a\x64\x1e\x76\xd1\x2b\x00\x93\x84\xac\x95\x71\x46\x0d\x7f\xd9\x0e\x5e\xa4\x95\x7e\x2c\x39\xa0\xbc\x70\xbb\xf9\x08\xdd\xa1\xf1\x36\xc8\x0a\xed\xd2\xe9\x50\x2f\x4d\x16\x36\xe5\x4c\x94\x34\xa9\x7d\xcf\xd0\xa8\x60\x2b\xad\x9c\x54\x2d\x1a\x83\xbb\xbd\x67\xd2\x51\x3e\xa2\xce\x61\x34\xd5\x8a\xdc\xd3\x63\xad\x3d\xcf\x66\x4f\x55\x23\x34\xe1\xb0\xf6\x46\xde\x98\x0c\xb1\x70\x4d\x07\x5a\xad\x94\xbf\x7b\x5e\xc6\x9f\x0e\x23\xee\x59\x58\x5c\x87\x5b\x23\x09\x07\x57\xa2\x95\x62\xd8\xe6\x5a\xe1\x80\xc7\x84\x6b\x3c\x46\x23\xb8\xdc\x59\x47\x39\x24\x64\x9c\x05\x34\x04\xa5\x25\x01\x32\x6d\x63\x6a\x92\xa4\xa5\x11\xc3\x76\x75\x34\x1a\x39\xf5\x95\x6a\x93\xa3\x5b\x42\xbc\x73\xe3\x76\x29\x4d\x76\x2f\x1d\xb1\x0b\x54\xea\x61\x83\x77\xe3\xa7\xcd\x52\x7d\xf1\x27\x45\xaa\xd5\xf2\xfe\x62\x35\xa5\xdb\x11\x1c\xf8\xae\x29\xf1\x3a\x08\xda\xd4\x7c\x21\x0d\x04\x37\xf6\xe0\xee\xc1\xb1\x59\x32\x16\xb7\xa5\x75\xb0\xc1\x2d\xe3\x47\x61\x28\x95\x77\x2c\xeb\x6c\xa2\xfe\x5f\xcc\x42\x19\x74\xc4\xbe\x1e\x45\xfb\xcc\x1d\x22\xea\x59\x9d\x31\x39\xef\x3c\x4e\x8f\xc6\xae\x97\x6c\x24\x65\x1d\x51\x6c\xb7\xc0\x7d\x91\xe3\x9a\x5e\x97\x59\x76\xb9\x97\x69\xf7\x14\xfd\x66\xea\xbd\xa9\x14\x2c\x79\xd1\x18\x8a\x0d\xf3\x71\x97\xa3\x3f\x20\xd0\xad\x36\x37\xef\x23\xc8\x8f\xc3\xf5\x07\x05\xe8\xb3\x3b\x22\x8e\x4e\x3d\x07\xef\xc9\xb8\x75\xe8\x4a\x7b\xa8\xb8\xf1\x0b\x86\xac\x25\xa5\x31\x9c\xcd\xc3\xeb\xfd\x62\xe6\x63\x95\x2f\x5a\x09\xdf\x4d\x1f\xcb\xc0\x0f\x2e\x9a\x95\xbe\x01\xc4\xaa\xf3\x12\x32\x4d\xc9\x54\x95\x57\x58\x50\xf1\x4b\xc3\x3a\x19\x02\x6e\x85\x52\xb8\x95\x24\x82\x77\x98\x49\xd1\x79\xdf\x97\x99\x0c\xa7\x4b\x78\x56\x14\x99\x24\xb1\x84\x0b\x9d\x17\x5a\xb1\x6a\x46\xa8\xfa\x08\x8e\x89\x14\xd7\x2a\xbc\xbe\xee\x0b\xf7\x00\xfb\xd9\x16\x65\x86\x71\x46\x47\x28\x8e\xbf\xef\x21\x1e\x6b\x12\x1e\x0b\x0c\xa1\xd8\x31\xf8\x78\xcc\x8c\xe0\xb5\xd1\x6b\xc3\x99\x50\xad\xc7\xf0\xb7\xdd\xf4\xd0\x16\x52\x01\x82\x33\xa8\xac\x57\x08\x77\x22\xac\x53\x1a\x43\xd6\xaf\x69\x6d\x50\xf4\x55\x34\x45\x5b\x68\x5f\xe6\xe5\xe8\x92\x4d\xd7\xf9\x8f\xc6\x2d\x4b\xaa\x55\xb6\x63\x4f\xda\x4a\xc1\x2f\x86\x5d\xbd\xd8\x32\xa1\xe8\xc1\x27\x28\x52\x66\x8d\xcf\xd5\x2e\x67\x41\x90\x43\x99\x59\xef\x48\x5a\x11\x20\xb7\x0d\x4d\x86\xaa\x22\x66\x34\x01\x79\x0d\x36\xfd\xf4\xb3\xd7\x2f\xa0\x9e\x29\x45\xb0\x58\x2c\xe0\x2d\xdf\xb6\xce\x94\x89\xc7\x76\xee\x7f\x15\x4b\x98\xfa\x82\xd8\x8c\xd7\x2b\xc0\xa0\x0d\xc8\x45\x65\x10\x15\x30\x34\x75\xa9\xa4\x4c\x40\x81\x6e\x03\x51\x88\x87\xa8\x8d\xb5\x08\xe0\xb9\x36\x40\x77\x98\x17\x19\x8d\x15\x94\x41\x7d\xf0\x5c\xeb\x0a\x1a\x02\x63\xbf\xf9\x47\x67\x67\xf0\xa6\x8f\x14\x3a\x66\x3b\x60\x08\x4f\xce\xe1\xa3\x24\x53\xad\x1f\xd8\x1e\xaa\x50\x54\x13\x7c\xa9\xf4\xad\x1a\x63\xd5\xf3\xc1\xb1\x38\x4a\x72\x35\x6b\x22\x6a\x35\x9b\xc3\x6a\xd6\xf1\xff\x55\x95\x2e\x57\xb3\xda\x47\x57\xb3\x7a\xbb\xbf\x14\xec\x83\xaf\xc8\xac\xe9\x25\xed\x9e\xf0\x26\xe3\xf4\x7b\xeb\x2f\x39\x6f\xd0\x7a\xf7\x24\xe7\x17\x1b\x5a\x5c\xf9\xbf\xdd\x15\xf4\x24\xc7\xa2\x77\xf3\x15\x16\xc7\xa9\x77\x80\xed\xea\x9a\x3b\xd6\xed\x79\xd4\x3a\xde\x4f\x3f\x5b\xad\x96\xab\x59\xab\x91\xb9\xce\xd9\x7d\x0b\xb7\x5b\xcd\x46\xa9\xf6\x58\x5d\xae\x66\x9e\xd9\xd5\x0c\x7a\x22\x2f\x57\x33\x66\x8b\x6f\x1b\xed\x74\x5c\xa6\xcb\xd5\x8c\xab\x39\x3b\x3f\x9f\x1b\x2a\xe6\x5c\x3c\x3c\x69\x77\x5d\xcd\x7e\x1a\x17\x41\xd5\x12\x6b\xb7\x21\x13\xfc\xce\xc2\xef\x63\xac\x1d\xa9\xbd\xab\x49\x9c\x18\xb3\xf4\x02\x32\xb4\xee\x6d\x03\x49\x6f\x65\x3e\xa6\xd2\x05\xe4\x64\xed\x78\x25\xb0\x60\xac\xb4\xa3\x59\x75\x51\xa5\x8c\xd1\x47\x13\xd6\x3b\xd6\x29\xec\xf3\x7b\xaf\x7a\x78\xff\xb5\xba\xd4\xe4\x27\xe0\xf8\x86\x47\x9a\xda\x32\x93\xd5\x70\x8b\xdf\x0c\x22\x46\xe7\x1e\xaf\xaa\x5c\xce\x5d\x87\xf2\x16\x8b\x2a\xe0\x09\xc3\xba\x98\xe0\xb6\x3f\x3a\x1d\x10\xe5\x3a\x5d\x09\x32\xd9\x8e\xb3\x42\x9b\x33\x93\x0d\xaa\x35\x89\x08\xb8\x63\xad\x9b\x32\x06\x7b\x3f\x27\xf6\xf5\xe8\x34\xd5\xd2\xd6\xc3\x2e\x2f\x1f\x73\xe0\xff\x31\x48\x06\x20\xab\xc8\xd7\x9d\x5e\xe1\x38\xe2\x3f\x42\xff\x22\xd0\xd1\xc2\x8d\x3b\x13\xd4\xce\x74\x2f\xc3\x55\x6b\xc3\x44\x6f\x53\xe6\xa8\x7c\x6e\xf6\x89\xba\x79\xa6\x84\x4c\xd0\x4d\xb3\x05\x4d\x7e\xc1\x58\x97\x01\xc9\x5b\x3b\x56\xa6\xca\x71\xc7\x76\x42\x15\xe6\x01\x95\xa0\x1f\xa0\x8c\x1c\xef\xbe\x25\xb5\x76\x9b\x25\x7c\xfe\xf8\x6f\x5f\x7c\x39\xba\x2c\x40\x3c\x89\x7f\x84\xd1\xce\xde\x78\x79\x42\x2d\xfb\xaf\x0d\xab\xcc\xa8\x9e\xd2\x45\xeb\x66\xcd\x01\xff\xab\xf2\x5b\xeb\x79\xb7\x18\x86\x23\x31\x72\xfb\x52\x16\xac\x27\xce\x6e\x52\x59\x87\x2a\xa1\x39\xf7\x4b\xef\xb5\x89\x6c\x92\x54\xb6\x83\xf3\xc7\x73\x88\x2b\x53\xec\xa7\xa7\xab\xbb\xeb\x68\x5f\xc4\x43\x94\xbf\x9a\x0f\xf8\x97\x16\xd8\xd4\x3a\xf5\xce\x08\xb7\xd2\x6d\xc0\x50\x28\x2b\xaa\x01\xf9\xa1\xb2\x02\xfa\xa5\x05\x35\x72\x1f\x76\x08\x2e\x2f\xd6\x93\x13\x87\x3a\x3c\xa4\x72\x5f\xfc\x75\xca\x6b\xa4\x92\x79\x99\x2f\xe1\xb3\xd1\x05\x01\x6c\xef\xe5\x23\x61\x69\x5b\x63\x71\x2f\xac\xd7\x06\xf3\x1c\x9d\x4c\x40\x0a\x52\x4e\xa6\x92\xcc\x7d\x02\x28\x1c\xab\x78\x82\xf5\x29\x4b\xa3\xeb\x07\xb6\x42\xd1\x4e\x48\xbd\x36\x5a\x94\x09\x99\x31\xf0\x0f\x97\x4e\xfd\x6c\x58\xa6\x32\xd9\x6b\x12\x38\x16\xc3\xf9\x09\xd0\x1d\x9b\xac\x39\x8d\xe0\xd2\x63\x92\x64\x4e\xc8\x55\xb5\x6d\xfb\x11\x0f\x73\xa1\x5e\xb9\xdd\x90\x4f\xa5\xfe\x3c\xa6\xa2\x65\xbc\x14\x56\x0a\x1a\x2f\x97\xc3\x85\xb0\x2e\xd1\xa0\x72\x44\x82\xc1\x33\x0c\x20\xc2\xb9\x42\x0b\xf0\xd8\x4e\xec\x8f\x60\x07\x04\xc0\x09\x10\xcc\xa2\x56\xd3\x7f\x8f\x3b\x1f\x07\x70\xce\x3f\x7b\x7c\xc0\xc1\x9a\x55\x13\x4b\x0a\x74\x8e\x8c\x5a\xc2\xbf\xae\x9e\x2d\xfe\x89\x8b\x5f\xaf\x1f\x56\x3f\x3e\x5b\x7c\xf5\xef\xf9\xf2\xfa\x51\xe7\xef\xf5\xe9\xd3\xff\x9f\xac\xcc\x87\xed\x71\x7b\xf5\x5c\xb5\x6d\x85\x7b\x8e\x35\xf7\xb9\x55\xa7\xf0\xd6\x94\x34\x87\xe7\x98\x59\x9a\x43\x75\x48\xfa\x01\x8a\x22\x55\xe6\x53\x7c\x2d\x60\xc6\xbb\x8d\xd7\x80\xfe\xb1\x67\x63\xfa\x79\xc5\xde\x64\xf5\x7f\x2f\x85\xf8\xf2\x5c\xa7\x5d\x3c\xeb\x9c\x08\x81\xc7\x61\x2e\xfc\xa3\xaa\xd9\x88\x12\x9d\x9f\xb5\x27\x46\x93\x8e\xc7\x1d\xd1\x2b\x54\x3b\x68\xc1\x36\xb4\x02\xc3\x88\xb0\x8e\x9b\x09\x4c\x8c\xb6\xb6\x39\x26\x9b\x0e\xe6\x4c\xde\x50\xdb\x85\x07\x68\x8f\x29\x41\xdf\x46\x99\x58\x3a\x83\x66\xd7\x19\x4b\xf8\x31\x78\xec\x07\xab\x69\x99\x4d\x92\x7d\x68\x89\x20\x52\x5a\xd0\x7e\x8e\x38\x0d\x88\x8f\xb1\xcc\xa4\xdb\x85\x41\x21\xb7\xb7\x99\xf4\x9d\xde\x74\xb2\xc8\x0b\x6d\x1c\xd6\x73\x44\x43\x6b\xba\x03\x59\xb5\xcf\x64\x39\x71\x3c\x14\xca\x9e\x9f\x3f\xfe\xfc\xb2\x8c\x85\xce\x51\xaa\xe7\xb9\x3b\x3b\x7d\xfa\xf0\x97\x12\x33\x46\x4c\xf1\x1d\xe6\xf4\x3c\x77\xa7\x1f\xa7\x38\x38\xff\xe2\x68\x1c\x3e\xbc\x0a\xd1\x76\xfd\xf0\x6a\x51\xfd\x7a\x54\xdf\x3a\x7d\xfa\x70\x15\x1d\x7c\x7e\xfa\x88\xb9\xef\xc4\xf0\xf5\xd5\xa2\x0d\xe0\xe8\xfa\xd1\xe9\xd3\xce\xb3\xd3\x61\x38\xb7\xb9\xfd\xd8\x38\xa9\xcd\xd5\x9d\xb9\xb9\xb7\x8c\x23\x93\x33\x9c\xfb\x22\x14\x95\x1f\x15\x80\x22\x12\x63\x86\xf2\x87\x64\xfe\x64\x5b\x25\x32\x23\xc1\xfe\xbe\x41\xdb\x96\xab\x0a\x10\x6e\x71\x17\xea\x16\x4f\x05\x90\xd3\x53\xe2\x1d\xfa\x13\xcc\x2c\x5a\xc1\xaa\xc6\xfd\x86\xa8\xb0\x9c\xf1\x92\x9b\x1a\xb6\x5a\x35\x71\xfe\x19\x35\x29\xc2\x5a\x6e\x49\xb5\xe7\xce\x56\x07\x19\x04\x25\xd2\x06\xb5\xf9\x0a\x35\xd5\x26\xf1\x65\x97\xf0\xc7\x7d\x21\x5a\xc6\x8b\x69\x14\x14\x3c\x59\x34\xdf\xd0\x34\xbe\xcc\x6c\xed\xb1\x1e\x12\xab\xd7\xe2\x5a\xba\x4d\x19\x7b\xf0\xd0\x05\x29\xbb\x91\xa9\x3b\xc3\x42\x9e\x6d\xc7\x52\xc3\x07\x1e\xf2\x84\xcf\x71\xee\x83\x7e\x7e\x65\xdd\xa0\x85\x3f\x95\x8e\x3b\x07\xf6\xe8\x60\xa7\xcb\x07\x66\x5c\x2d\x10\x5a\xb5\xe4\x66\x3a\x04\x8f\x46\x29\x17\x35\xef\x59\x97\xf7\x5f\xe9\xf5\x98\x1d\xf7\x18\x08\x73\xb8\x2c\x6f\xbf\xcb\xa9\xc6\xa0\x53\xd0\xf6\x71\x2a\x50\x35\x7a\xe4\x3d\x22\xaa\x9a\x38\xf1\x1e\x33\xd1\x07\x9b\xe2\xc0\x49\xd2\x04\x6b\xcd\x79\xd2\xed\x86\x0c\xfd\x61\xce\x60\x3a\x95\x1c\x65\xba\xde\xef\x9e\xd5\x7a\xc5\x5c\xa5\xd2\x96\xd9\xaa\x14\xd8\x93\x60\x92\xe3\x8f\xa4\xf3\xed\xd8\xe7\x4e\x13\xdc\x57\x6b\x6b\xe6\xeb\xbf\xff\xdd\xa8\xbd\x4f\x2f\xdd\xe3\xfa\xfb\xfd\x2e\x7a\x22\x62\x43\xe6\xf1\xac\x6f\xc7\x58\x17\x84\x99\xf3\x4d\xe6\x44\xf2\x99\x0a\xcb\x43\x01\x69\x28\x43\x47\xa2\x9e\x67\x1f\x4b\xbe\x6f\x06\xcb\x7b\x19\x38\x64\xaa\xae\x31\xec\x94\x13\xf1\x6b\xd5\xd6\xa1\x53\xee\x9f\xeb\x7c\x82\xec\x3a\xe0\xfc\x15\x39\x1c\x8e\x33\xfa\x2e\x34\x11\x93\xf5\xc1\x48\xbc\xf3\x1f\xce\x55\xdf\x7d\xfe\x99\xc9\xfe\x97\x41\xdd\x6d\xa4\x5a\x0f\x39\xfa\x13\xb4\x3f\x1c\xb4\x47\x15\x7b\x5f\xb6\x02\xae\x2e\xc1\x99\x32\x78\xb5\x75\xda\xe0\x9a\xba\x77\xca\xd8\x0c\x91\xad\x1a\x19\xc0\x6f\xbf\x9f\xb4\xd3\x83\x30\x99\x0e\x4d\x57\xef\x0b\xed\x59\x68\xc2\xeb\x8f\xaf\xfd\xdf\xce\xc9\x37\x5c\x5d\x9f\x84\x8d\x49\xbc\xab\xbf\xa0\x86\xab\xeb\xff\x04\x00\x00\xff\xff\x67\x5d\x76\x7d\xa4\x2e\x00\x00")
+var _klusterletCrdsV10000_00_operatorOpenClusterManagementIo_klusterletsCrdYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xec\x5a\x6f\x6f\xdc\x36\xd2\x7f\xef\x4f\x31\xd8\xe7\x01\x6c\xe7\xd9\x95\xeb\xf4\x41\xaf\x5d\x20\x08\x72\x6e\x73\x08\xd2\xb4\x41\xec\xb6\xc0\x79\x7d\x57\x4a\x1c\xad\x58\x4b\xa4\x4a\x52\x6b\x6f\x8b\x7e\xf7\xc3\x90\xd4\xdf\x95\x76\x37\x49\xf3\xe6\xee\xb2\x79\x61\x89\xe4\x70\x66\x38\xf3\x9b\x3f\x14\x2b\xc5\x8f\xa8\x8d\x50\x72\x09\xac\x14\xf8\x68\x51\xd2\x93\x89\xee\xbf\x34\x91\x50\x17\x9b\xcb\x93\x7b\x21\xf9\x12\xae\x2a\x63\x55\xf1\x0e\x8d\xaa\x74\x82\x5f\x63\x2a\xa4\xb0\x42\xc9\x93\x02\x2d\xe3\xcc\xb2\xe5\x09\x80\x64\x05\x2e\xe1\x3e\xaf\x8c\x45\x9d\xa3\x35\x91\x2a\x51\x33\xab\x34\xfd\x21\x17\x89\x1f\x59\x14\x4c\xb2\x35\x16\x28\x6d\x24\xd4\x89\x29\x31\xa1\xd5\x6b\xad\xaa\x72\x09\xc7\x2c\xf1\x5b\x19\x5a\x05\xe0\x19\x7c\xdd\xec\xea\x5e\xe6\xc2\xd8\xd7\x83\x81\x6f\x85\xf1\x83\x65\x5e\x69\x96\xf7\x38\x75\xef\x8d\x90\xeb\x2a\x67\xba\x3b\x72\x02\x60\x12\x55\xe2\x12\xae\x3c\xa1\x13\x80\x52\xa3\x41\xbd\xc1\x1f\xe4\xbd\x54\x0f\xf2\xa5\xc0\x9c\x9b\x25\xa4\x2c\x37\x78\x02\xb0\xf1\x2a\x75\xec\x2d\x82\x52\x36\x97\x7e\x87\x24\xc3\xc2\xe9\x8a\x9e\x48\x29\x2f\xde\xbe\xfa\xf1\xf3\xeb\xde\x6b\x00\x8e\x26\xd1\xa2\x24\x05\x77\x05\x00\x8d\x6e\x6b\x69\x0d\x24\x4a\x5a\xad\xf2\x1c\xb5\x01\x25\xc1\x66\x08\x5e\x47\x1c\x82\xce\x22\xf8\x29\x43\xd9\xd0\x04\x5a\x92\x8a\x75\xa5\x91\xcf\xdd\xfc\x1e\xe1\x5f\x2b\xa1\xd1\x00\x03\x83\x89\x46\xeb\xd8\xe6\xa0\x52\x88\x95\xb2\xc6\x6a\x56\x2e\xb2\x2a\x5e\xdc\x57\x31\x7a\x3a\x1d\xc2\xc2\xef\x6f\x58\x81\x6e\x9d\x29\x59\x82\x60\x15\xb0\x3c\x57\x0f\xf0\xe2\xed\x2b\xb7\x01\x1a\x6b\xe8\x2d\xcd\xcd\xaa\x18\x52\xa5\xdd\x3a\x8d\x6b\x41\x3b\x90\xb8\x1d\xaa\xa5\x56\x56\x25\x2a\x8f\x9a\x77\x76\x4b\xe7\xa0\xe2\x5f\x30\xb1\xcd\xcb\x52\x93\xc5\x58\x51\x9b\x83\xff\x75\x2c\xbb\xf3\x76\xa0\xd9\x53\x52\xbe\x9f\x05\x9c\x4c\x1a\x8d\x63\x28\x1c\x20\xf2\x70\x5e\xa4\x06\x9b\x09\xd3\xea\x7f\xc8\x2b\xfd\x54\x0a\x4c\x06\xee\x22\xb8\x26\x03\xd1\x06\x4c\xa6\xaa\x9c\x93\xee\x37\xa8\x2d\x68\x4c\xd4\x5a\x8a\xdf\x1a\xda\x8d\x46\x72\x66\x31\xd8\x67\xfb\x13\xd2\xa2\x96\x2c\x87\x0d\xcb\x2b\x9c\x03\x93\x1c\x0a\xb6\x05\x8d\xa4\x03\xa8\x64\x87\x9e\x9b\x62\x22\x78\xa3\x34\x82\x90\xa9\x5a\x42\x66\x6d\x69\x96\x17\x17\x6b\x61\x6b\x8f\x4e\x54\x51\x54\x52\xd8\xed\x85\xb3\x20\x11\x57\x56\x69\x73\xc1\x71\x83\xf9\x85\x11\xeb\x05\xd3\x49\x26\x2c\x26\xb6\xd2\x78\xc1\x4a\xb1\x70\xac\x4b\x12\xd8\x44\x05\xff\x1f\x1d\x30\xc0\x9c\xf6\x78\xf5\x67\x63\xac\x16\xb2\x6b\x1a\xce\x39\xf7\x9c\x00\xf9\x28\x08\x67\x77\x6e\xa9\x17\xb4\x55\x34\xbd\xa2\x23\x79\xf7\xcd\xf5\x0d\xd4\x5b\xbb\xc3\xe8\x11\x85\xa0\xf7\x76\xa1\x69\x8f\x80\x14\x26\x64\x8a\x64\x6d\xc2\x40\xaa\x55\xe1\x34\x8e\x92\x97\x4a\x48\xeb\x1e\x92\x5c\xa0\x1c\xaa\xdf\x54\x71\x21\xac\xe9\x5a\x6f\x04\x57\x4c\x4a\x65\x21\x46\xa8\x4a\xce\x2c\xf2\x08\x5e\x49\xb8\x62\x05\xe6\x57\xcc\xe0\x27\x3f\x00\xd2\xb4\x59\x90\x62\x8f\x3b\x82\x2e\x42\x03\xec\xf5\x25\x80\x1a\x8c\x27\xce\xeb\xba\xc4\xa4\xa3\x63\xa7\x39\x8e\x46\x68\xe4\xc0\xb1\xcc\xd5\x96\x20\xba\x41\x1a\xe7\x26\xe4\x3d\x2d\xd4\xf4\x68\x03\xb0\x35\x45\x81\x63\x38\x9b\xf2\x74\xfa\x05\xc4\xfb\x8e\xa0\x76\x30\x34\x10\x20\x80\x38\xcd\x24\xb3\x23\xfe\x09\xb1\x88\xc7\x11\x08\x25\xdf\x8c\x71\x87\x22\x40\xa2\x91\x4e\x9e\x90\x37\xab\xe2\x08\x6e\xfa\x70\xea\xa4\x82\x35\x4a\x0a\x65\x0e\x55\x35\x93\x5c\x15\x0e\x1d\x41\xa4\x23\x14\x85\x25\x7e\xc8\xb0\x0c\xda\x39\x28\x0d\x5c\x98\x44\x39\x0c\x21\xce\x58\x49\xe2\x6b\xc1\x2c\x36\xdc\x39\x6a\x4a\xc2\xf7\x25\xca\xeb\x4c\xa4\x03\x45\xee\xb1\x09\xfa\x4f\xd1\x9e\xb0\xc5\xbb\xc9\x0f\xef\xbe\x35\x07\x74\xf7\xcd\xce\x82\xa1\x29\x30\x17\x77\x49\x99\xac\x14\x2e\x44\xea\x1d\x92\x00\x95\xce\x8d\x43\xb2\x84\x41\x5c\x49\x9e\x3b\xd8\x65\x4e\x01\x2c\x49\xd0\x18\x11\xe7\xd8\xf0\x97\x6f\xe1\x55\x1a\xf4\x63\xd0\x02\x16\xa5\xdd\xce\x47\xe8\x0e\x0f\x2f\x63\xa4\xd0\x2e\x9d\x0e\xf5\x4a\xe7\x7e\x53\x8a\x44\x61\xc5\x08\xcd\x84\x49\xd8\x08\x23\x26\x55\xcb\xb4\x66\xdb\x9d\x31\x61\xb1\x18\x51\xe7\x40\xa1\x8d\x22\x77\xf4\x58\x6b\x8f\x74\xd5\x57\xd5\x08\x4d\xd8\xaf\xbd\x91\x15\x93\x2e\x76\xc8\xd1\x6a\xa5\xfc\xd5\x1d\xdb\xf8\xe8\x40\xc8\xab\x17\x7e\x72\xed\x6e\x8d\x24\xe4\x5c\x89\x92\x92\x60\xdb\xaa\x56\xe6\x09\xa2\x30\xe1\xa3\x11\x5c\x6f\x8d\xc5\x02\x12\xd4\xd6\x00\xd3\x08\x95\x41\x0e\xa2\xb6\x19\xa9\xc6\x64\x0c\x70\x87\x23\x07\x7b\xd0\x73\xea\x5f\xaa\x74\xc1\xec\x12\xe2\xad\x1d\x3f\x97\x4a\xe7\x47\xe9\x88\x4c\x20\xa8\x87\x0e\xbc\xeb\x3f\x6d\x94\xea\x8b\x3f\x41\xb6\xc1\xc2\x0f\x10\xab\x49\xdd\x96\x27\x7b\xb9\xfd\xae\x49\xf1\x02\xcf\xbd\x9c\xcf\x87\x01\xf7\xde\xc1\xa0\x07\xc7\x66\xca\x0e\x69\x80\xa2\x32\x16\x32\xb6\x21\xfc\x28\x35\xa6\xe2\x91\x8e\x7a\x36\x91\xff\x2f\x66\x3e\x0d\x3a\x70\xbe\x0e\x45\xfb\xcc\xed\x23\xea\x58\x9d\x91\xb9\x38\xe3\xb1\x6a\x84\x64\x90\x6c\x24\x64\x1d\x50\x6c\x37\xc1\x7d\x55\xb0\x35\xbe\xad\xf2\xfc\x7a\x27\xd2\xee\x28\xfa\xdd\xd4\xba\xa9\x10\x2c\x68\xd2\x0e\x4d\xd8\x8d\xc7\x5d\x8e\x3e\x40\xa0\x07\xa5\xef\xdf\x47\x90\x9f\x86\xf3\xf7\x0a\xd0\x67\x77\x87\xb2\x03\x02\xe2\xe0\x3d\x19\x37\x96\xd9\x6a\x80\x68\x3d\x2e\xaf\xdd\x84\x21\x6b\x49\xa5\x35\x45\x73\xbf\xbc\x9f\xcc\x8c\x71\x30\x89\xad\xd3\xa8\x9a\x28\xc9\x5d\x35\x6d\x0e\xe8\xf1\xf4\xaa\x99\x49\x4a\xb2\x2c\x54\x5e\x5c\xa4\x29\xea\x90\x79\xf9\x09\x81\x5f\x1c\xe6\xc9\xf4\xf3\x85\x97\x30\x1d\x49\x22\xf8\x91\xe5\x82\x77\xd6\x93\x1c\x0e\x4e\x97\xf0\xa2\x2c\x73\x81\xd4\x05\x50\x45\xa9\x24\xca\x50\x2f\xf7\x7f\xce\x83\x63\x44\x09\xcc\xcf\x07\x21\xbb\x88\xd5\x02\xf6\x8b\x0d\x13\x39\x8b\x73\x3c\x40\x71\x7c\x3d\xf1\x04\xac\x26\xe1\xb0\x40\x23\xe3\x5b\x8a\x27\x0e\x33\x23\x78\xab\xd5\x5a\x53\x24\x94\xeb\x5d\x8d\x42\x67\xd3\x7d\x5b\x08\x09\x0c\xac\x66\xd2\x38\x85\x50\x25\x42\x3a\xc5\x5d\x83\x03\xf8\x1a\xd7\x9a\xf1\xbe\x8a\xa6\x68\x73\x45\x90\x05\x05\xb3\x49\xd6\x35\xfe\x83\x7e\x4b\x92\x2a\x99\x6f\x29\x11\xde\x08\x4e\x5e\xe3\x77\x75\x62\x8b\x04\xa3\xd3\x09\x5f\xf8\x98\x24\x65\xd6\xd8\x5c\x6d\x72\x06\x38\x5a\x26\x72\xe3\x2a\x78\x25\x11\x18\x95\x0d\x4d\x84\x0a\x1e\x33\x42\x18\x9c\x55\x86\x6c\x5b\x18\xd7\x19\xa8\x7b\x4a\x11\x2c\x16\x0b\xb8\xa1\x32\xdb\x58\x5d\x25\x0e\xdb\xa9\xfe\x95\x1c\xb9\xdb\x89\x0b\x3d\x9e\xaf\x00\x45\x7c\x60\x94\x54\x7a\x51\x81\xf9\xa2\x2e\xa5\xce\x0c\x94\xcc\x66\x10\xd1\xce\x95\x89\x1a\x03\x37\x11\xc0\x4b\xa5\x01\x1f\x59\x51\xe6\x38\x96\x50\x7a\xf5\xc1\x4b\xa5\x02\x34\x78\xc6\x7e\xa7\x11\xb8\xb8\x80\x77\x7d\xa4\x50\x31\x9d\x83\xc3\x58\x43\x22\xb2\x51\x92\xa9\x52\xa7\xa6\x87\x2a\x18\xd5\x04\x5f\x53\x3b\x69\x8c\x55\xc7\x07\xf9\xe2\x28\xc9\xd5\xac\xf1\xa8\xd5\x6c\x0e\xab\x59\xc7\xfe\x57\x21\x5c\xae\x66\xb5\x8d\xae\x66\xf5\x76\xff\x57\x92\x0d\xbe\x41\xbd\xc6\xd7\xb8\x7d\x46\x9b\x8c\xd3\xef\xcd\xbf\xa6\x88\x84\xeb\xed\xb3\x82\x16\x36\xb4\x28\xf3\xbf\xd9\x96\xf8\xac\x60\x65\xef\xe5\x1b\x56\x1e\xa6\xde\x01\xb6\xdb\x3b\xaa\x58\x37\x97\x51\x6b\x78\x3f\xff\x62\x94\x5c\xae\x66\xad\x46\xe6\xaa\x20\xf3\x2d\xed\x76\x35\x1b\xa5\xda\x63\x75\xb9\x9a\x39\x66\x57\x33\xe8\x89\xbc\x5c\xcd\x88\x2d\x7a\x4d\xcd\xa6\xb8\x4a\x97\xab\x19\x65\x73\x66\x7e\x39\xd7\x58\xce\x29\x79\x78\xd6\xee\xba\x9a\xfd\x3c\x2e\x82\xac\x25\x56\x36\x43\xed\xed\xce\xc0\x1f\x63\xac\x4d\xc6\x87\x3a\x57\x70\x9d\xb8\x41\xd3\xc4\xff\x5f\x40\xce\x8c\xbd\x69\x20\xe9\x46\x14\x63\x2a\x5d\x40\x81\xc6\x8c\x67\x02\x0b\xd0\xc8\xcc\x68\x54\x5d\x84\x90\x31\x3a\x34\x71\x7a\xd3\x31\xcd\xff\x76\xf9\x1d\x9f\x37\xc0\x9c\xdd\x65\x75\xaa\x49\x23\x60\x45\x81\xce\xbf\x9b\x93\x99\x20\x0a\x1d\xfc\x26\x10\xa1\xce\x0f\xe1\x55\x88\xe5\x54\x75\x48\x77\x62\x51\x00\x1e\xdf\xac\x8b\x11\x1e\xfa\xad\xd3\xfe\x3f\xda\xba\x92\x1c\x75\xbe\xa5\xa8\xd0\x70\x01\x49\xc6\xe4\x9a\x1a\x42\xf0\x2a\x6d\x8a\x32\x02\x7b\xd7\x27\x76\x5d\xd7\x69\xaa\x95\xa9\x9b\x5d\x4e\x3e\xe2\xc0\x3d\x11\x48\x3a\x83\xaa\xc9\xd7\x95\x5e\x69\x29\x00\x8e\x85\xa3\x3d\x59\xd0\x58\xfd\x42\x5d\xac\x85\x1d\x37\x26\xa8\x8d\xe9\xa8\x83\x0b\x73\x1d\x87\x90\x55\x05\x93\x64\x6f\x9c\xf8\xac\xe9\x80\x90\x5c\x24\xcc\x4e\xb3\x05\x4d\x7c\x61\xb1\xaa\x3c\x92\xb7\xe7\x18\x8e\x8a\x9a\x7a\x31\x12\xe2\x3b\x14\x08\x82\x7e\x84\x32\x0a\xf6\xf8\x2d\xca\xb5\xcd\x96\xf0\xf9\xd3\xbf\x7c\xf1\xe5\xe8\x34\x0f\xf1\xc8\xff\xe6\x5b\x3b\x3b\xed\xe5\x09\xb5\xec\x2e\x1b\x66\x99\x51\xdd\xa5\x8b\x42\xd7\x68\xaf\x51\x67\xcc\xf6\xed\x1f\x1e\x98\x6f\x8e\xc4\x8c\xca\x97\xaa\x24\x3d\x51\x74\x13\xd2\x58\x26\x13\x9c\x53\x3d\xfc\x5e\x9b\x88\x26\x48\xe5\x5b\xb8\x7c\x3a\x87\x38\x1c\xc5\x6e\x78\xba\x7d\xbc\x8b\x76\x45\xdc\x47\xf9\xab\xf9\x80\x7f\x61\x80\x8e\x5a\xa5\xce\x18\xe1\x41\xd8\x8c\xda\xbd\x65\x68\x0e\x1c\x4a\x2b\x06\xa9\x05\x36\x72\xef\x37\x08\x4a\x2f\xd6\xa8\x0f\xb8\x87\x90\xf6\x8b\xff\x9f\x98\x53\x08\x29\x8a\xaa\x58\xc2\x67\xa3\x13\x3c\xd8\x1e\x65\x23\x7e\x6a\x9b\x63\x31\x8a\x49\x6b\xcd\x8a\x82\x59\x91\x80\xe0\x28\xad\x48\x05\xea\x63\x1c\x88\xf4\x15\x08\xd6\xb7\x2c\x8d\xae\x4f\x4d\x40\xd1\x8e\x4b\xbd\xd5\x8a\x57\x09\xea\xb1\x2c\xbc\xa9\xb9\x28\xc9\x13\xa9\x48\x3a\xc7\x46\x6a\xf4\x0d\x76\x7f\x7f\x02\xf8\x48\x47\xd6\xdc\x46\x50\x76\x3e\x49\xb2\x40\x26\x85\x5c\x9b\xb6\x1e\x71\x30\xe7\xf3\x95\x87\x0c\x09\x98\xdd\x61\xd6\xb4\xb4\x93\xc2\x08\x8e\xe3\xe9\x72\x68\x29\xc3\xba\x62\x9a\x49\x8b\xc8\x29\xc3\x24\xc0\x08\x34\x3a\x00\xcf\xda\x8e\xfd\x01\xec\x00\x0f\x38\x8e\x37\x27\x6a\xe8\xfe\x3b\xdc\xf9\x73\x00\xe7\xf2\xb3\xa7\x7b\x0c\xac\x99\x35\x31\xa5\x64\x96\x7a\x7d\x4b\xf8\xc7\xed\x8b\xc5\xdf\xd9\xe2\xb7\xbb\xb3\xf0\xc7\x67\x8b\xaf\xfe\x39\x5f\xde\x3d\xe9\x3c\xde\x9d\x3f\xff\xdf\x51\x3a\x63\xe5\xf1\x84\xa9\xb6\xa5\x70\xcf\xb0\xe6\x2e\xb6\xaa\x14\x6e\x34\xdd\x55\xbd\xa4\xeb\xd0\x39\x84\x4b\xd2\x8f\x50\x14\xca\xaa\x98\xe2\x6b\x01\x33\xda\x6d\x3c\x07\x74\xc3\x8e\x8d\xe9\xf1\xc0\xde\xe8\xb8\xe3\xed\x18\x85\xd0\x44\x52\x47\xeb\x18\xa2\x73\x23\x44\xcd\x7c\x21\x21\x55\x2a\x0a\xc5\x46\x94\xa8\xe2\xa2\x19\x9f\x52\x0d\xb8\x8a\xe8\x0d\x93\x5b\x68\xc1\x36\x72\x7b\x0d\x3d\xc2\x58\x2a\xfe\x59\xa2\x95\x31\xcd\x35\xd9\xb4\x33\xe7\xe2\x1e\xdb\x2a\xdc\x43\x7b\x8c\x09\x73\x65\x94\x8e\x85\xd5\x4c\x6f\x5b\x69\x8c\x6b\x83\xd3\x85\x97\xc1\xb4\xca\x27\xc9\x9e\x19\x44\x88\xa4\xe2\xb8\x1b\x23\xce\x3d\xe2\xb3\x58\xe4\xc2\xba\x5a\x9d\xbb\x8b\xe4\x5c\xb8\x4a\x6f\x92\xa6\x28\x4a\xa5\x2d\xab\xfb\x88\x1a\xd7\xf8\x48\x8d\x3f\x57\x3e\xa3\xa1\x60\x72\xc6\xa5\xb9\xbc\x7c\xfa\xf9\x75\x15\x73\x55\x30\x21\x5f\x16\xf6\xe2\xfc\xf9\xd9\xaf\x15\xcb\x09\x31\x39\x35\x2c\x5f\x16\xf6\xfc\xc3\x4d\xb0\x9b\x1c\x5c\x7e\x71\xd0\x0f\xcf\x6e\xbd\xb7\xdd\x9d\xdd\x2e\xc2\x5f\x4f\xea\x57\xe7\xcf\xcf\x56\xd1\xde\xf1\xf3\x27\xc4\x7d\xc7\x87\xef\x6e\x17\xad\x03\x47\x77\x4f\xce\x9f\x77\xc6\xce\x87\xee\xdc\xc6\xf6\x11\x6f\xee\x99\x6d\x1b\xab\x3b\x7d\x73\x77\x32\x16\x75\x21\x64\x48\x42\x99\x04\xaa\xb5\x40\x22\xf2\x31\x9b\x72\x97\x64\xee\x66\x5b\x26\x22\xa7\x5b\x31\x7f\xf7\xd2\xa4\xab\xd4\x48\x79\x60\x5b\x9f\x11\x3b\x2a\x74\x23\x86\x2c\xa1\xad\xa3\x4f\xd0\xb3\x68\x05\x0b\x85\xfb\x3d\x62\x69\xa8\x18\x48\xee\x6b\xd8\x6a\xd5\x04\xa9\x1a\x4f\x01\x18\xac\xc5\x06\x65\xe3\x50\x60\x28\x13\x61\x16\x38\x26\xc2\x90\x55\x87\x0c\x35\x55\x3a\x71\x69\x17\x25\x2f\xb5\xb7\x8c\x92\x2c\x18\x47\x6f\xc9\xbc\xf9\x86\xa6\xb1\x65\x62\x6b\x87\x75\x37\x8f\x92\x7e\x09\x6b\x61\xb3\x2a\x76\xe0\x41\x7d\x6c\x43\x57\x7e\x74\x45\x7f\x11\xbe\x2e\x79\xaf\x42\xf3\x50\xe9\xe6\x3f\xc7\x19\x1d\x1a\x68\xdb\xcd\xac\x0b\x34\xff\x10\x74\xdc\x28\xce\x69\x6d\xab\xaa\x53\x8d\xfb\x4a\xb5\xe4\x7e\xda\x05\x0f\x7a\x29\x25\x35\xad\xf6\x8e\xe2\xbc\xbf\xa4\x57\x63\x76\xcc\x63\x20\xcc\xfe\xb4\xbc\xfd\x2e\x27\xb4\x41\xcd\x27\xcd\x40\xe5\xe8\x95\xf7\x88\xa8\x72\xe2\xc6\x7b\xec\x88\x3e\xfa\x28\x9a\x3b\x97\xa3\x59\x6b\xee\x93\x1e\x32\xd4\xf8\xc1\x9c\xc1\x74\x28\x39\xc8\x74\xbd\xdf\x51\x3c\x37\xcc\x05\x95\x36\xcf\x75\x2a\xb0\x23\xc1\x04\x55\xf8\xb3\x74\x1e\x3e\x5a\x3a\x8a\xfb\x30\xb7\xb6\x87\xfa\x71\x8f\x49\x7c\x02\xaf\xdd\xad\x18\x97\x27\x7b\xb9\xfe\x7e\x67\xc1\x94\xc7\xfa\xc8\xe3\x58\xdf\x8c\x01\x0e\x47\x96\x5b\x57\x64\x9e\xbc\x9f\x5b\xee\x73\x48\x8d\xf4\x09\x17\xaf\xfb\xd9\xe6\x80\x34\xef\x06\xd3\x7b\x11\xd8\x19\x43\xef\x30\xcc\x94\x11\xd1\xb2\xb0\x35\x65\x55\xc3\x7b\x9d\x4f\x10\x5d\x07\x9c\xbf\x41\xcb\x86\xed\x8c\xbe\x09\x4d\xf8\x64\x7d\x31\x12\x6f\xa9\x8d\x53\x7f\xf7\xf9\x1f\x1e\xc9\x28\x92\xfd\x1b\x83\xba\xcd\xa8\xc9\x39\xe0\xe8\xbf\xa0\xfd\xf1\xa0\x3d\xaa\xd8\x63\xd9\xf2\xb8\xba\x04\xab\x2b\x0f\x97\xc6\x2a\x4d\x3d\xdf\xce\x9b\x2a\xae\x35\xd3\xb8\x59\x68\x19\xc0\xef\x7f\x9c\xb4\xdd\x03\xdf\x99\xf6\x45\x57\x98\x49\xdf\x2a\x2e\x61\x36\xeb\x7d\x7c\xed\x1e\xdb\xfa\x70\x09\xb7\x77\xf4\xad\xb5\x55\x1a\x79\xf8\x28\xd7\x2c\xe1\xf6\xee\x5f\x03\x00\x67\x5d\x76\x7d\xa4\x2e\x00\x00")
 
 func klusterletCrdsV10000_00_operatorOpenClusterManagementIo_klusterletsCrdYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -230,7 +230,7 @@ func klusterletCrdsV10000_00_operatorOpenClusterManagementIo_klusterletsCrdYaml(
 	return a, nil
 }
 
-var _klusterletCrdsV1beta10000_00_operatorOpenClusterManagementIo_klusterletsCrdYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x58\xcd\x6e\xe4\xb8\x11\xbe\xf7\x53\x14\x9c\x83\x13\xc0\x2d\x63\x90\x4b\xd0\x37\xc7\xbb\x01\x8c\x9d\x9d\x0c\x6c\xef\xec\x61\xb1\x87\x92\x58\xdd\x62\x4c\x91\x0a\x59\xec\xd9\x4e\x90\x77\x0f\x8a\x94\xd4\xfa\x69\x79\x3c\x99\xac\x4e\xdd\x64\xb1\xf8\xd5\xc7\xfa\x23\xb1\xd5\x9f\xc8\x07\xed\xec\x0e\xb0\xd5\xf4\x1b\x93\x95\x7f\xa1\x78\xf9\x4b\x28\xb4\xbb\x3d\xbe\x2b\x89\xf1\xdd\xe6\x45\x5b\xb5\x83\xfb\x18\xd8\x35\x8f\x14\x5c\xf4\x15\x7d\x47\x7b\x6d\x35\x6b\x67\x37\x0d\x31\x2a\x64\xdc\x6d\x00\x2a\x4f\x28\x83\xcf\xba\xa1\xc0\xd8\xb4\x3b\xb0\xd1\x98\x0d\x80\xc5\x86\x76\xf0\x62\x62\x60\xf2\x86\x38\x14\xae\x25\x8f\xec\xbc\xfc\xb0\xdb\x2a\xcf\x6c\x1b\xb4\x78\xa0\x86\x2c\x17\xda\x6d\x42\x4b\x95\xe8\x3d\x78\x17\xdb\x1d\xbc\x65\x49\xde\x2a\xc8\x2a\x80\x0c\xfd\x87\x61\xd7\x34\x68\x74\xe0\x1f\x66\x13\xef\x75\xc8\x93\xad\x89\x1e\xcd\x04\x69\x1a\x0f\xda\x1e\xa2\x41\x3f\x9e\xd9\x00\x84\xca\xb5\xb4\x83\xfb\x3c\x26\x03\xb1\xf4\x1d\x47\x1d\x86\xc0\xc8\x31\xec\xe0\xdf\xff\xd9\x00\x1c\xd1\x68\x95\x28\xca\x93\x62\xc8\xdd\xc7\x87\x4f\x7f\x7e\xaa\x6a\x6a\x30\x0f\x02\x28\x0a\x95\xd7\x6d\x92\x1b\xa1\x04\x4f\xad\xa7\x40\x96\x03\x54\xce\xb2\x77\xc6\x90\x0f\xe0\x2c\x70\x4d\x90\x89\x50\xd0\x11\x53\xc0\xcf\x35\xd9\x4e\x23\xc8\x82\xbd\x3e\x44\x4f\xea\x26\x49\x4f\xd4\xfe\x33\x6a\x4f\x01\x10\x02\x55\x9e\x38\x71\xa8\xc0\xed\xa1\x74\x8e\x03\x7b\x6c\xb7\x75\x2c\xb7\x2f\xb1\xa4\xac\x67\x50\xab\xf3\xde\x01\x1b\xca\xcc\xb7\x58\x11\xb0\x03\x34\xc6\x7d\x86\xbb\x8f\x0f\x49\x3d\x05\x0e\x32\x2a\xb2\x75\x2c\x61\xef\x7c\xfa\xed\xe9\xa0\x45\x7f\x72\xa5\x5e\x67\xeb\x1d\xbb\xca\x99\xa2\x1b\xe1\x93\x90\xec\xca\x7f\x50\xc5\x9b\x41\xa4\x25\xcf\xba\x67\x59\xbe\x91\x47\x0f\x63\x33\x2e\xaf\x85\xec\x2c\x03\x4a\x7c\x98\x42\x82\x71\xcc\x63\xa4\x20\xa4\x83\x10\xd3\xb9\xd6\xe1\xcc\xf8\x14\x61\x3a\xbb\x3d\xa0\xed\x50\x15\xf0\x44\x5e\x94\x40\xa8\x5d\x34\x4a\xd8\x3e\x92\x17\x6a\x2b\x77\xb0\xfa\x5f\x83\xe6\x81\x05\x83\x4c\x81\x27\x1a\xb5\x65\xf2\x16\x8d\xb8\x49\xa4\x1b\x40\xab\xa0\xc1\x13\x78\x92\x3d\x20\xda\x91\xb6\x24\x12\x0a\xf8\xd1\x79\x02\x6d\xf7\x6e\x07\x35\x73\x1b\x76\xb7\xb7\x07\xcd\x7d\x0c\x57\xae\x69\xa2\xd5\x7c\xba\x4d\xfe\xa2\xcb\xc8\xce\x87\x5b\x45\x47\x32\xb7\x41\x1f\xb6\xe8\xab\x5a\x33\x55\x1c\x3d\xdd\x62\xab\xb7\x09\xb8\xe5\x94\x08\x1a\xf5\x87\xc1\x99\xaf\x47\x48\xf3\x79\x04\xf6\xda\x9e\x1d\x21\xc5\xda\x2a\xef\x12\x70\xa0\x93\x87\xa5\x65\x19\xff\x99\x5e\x19\x12\x56\x1e\xbf\x7f\x7a\x86\x7e\xd3\x74\x04\x53\xce\x13\xdb\xa3\x38\x38\x13\x2f\x44\x69\xbb\x27\x9f\x0f\x6e\xef\x5d\x93\x34\x92\x55\xad\xd3\x96\xd3\x9f\xca\x68\xb2\x53\xd2\x43\x2c\x1b\xcd\x61\xec\xa5\x05\xdc\xa3\xb5\x8e\xa1\x24\x88\xad\x42\x26\x55\xc0\x83\x85\x7b\x6c\xc8\xdc\x63\xa0\xdf\x9d\x76\x61\x38\x6c\x85\xd2\x2f\x13\x3f\x4e\xc0\x53\xc1\x49\xc4\x00\xf4\xd9\xf4\xe2\x09\x3d\xb5\x54\x8d\xf3\x8b\xb0\xa5\x28\x68\x4f\x0a\x14\xb5\xc6\x9d\x24\xc3\x0e\x59\x24\x85\x83\x84\xc0\x2c\xb9\x0e\xb1\x78\x90\x7c\xfc\x25\x44\x97\xe3\x58\xbe\x2e\x87\x7d\x90\xb2\x31\x99\x98\xc1\xbe\x3f\xcb\x89\x7b\x09\x6a\xc9\x42\x39\x7e\x17\x29\x51\x62\xaf\xa4\x5c\xa6\x48\xcd\xf4\x82\xe4\xd1\x3a\x96\x05\x3c\x4f\xd3\x63\xb2\x05\x0e\x64\xa5\xfa\xa4\x2c\xe9\xd1\x2a\xd7\xe4\x9d\xf4\x1e\x34\xcb\xde\xd6\xf1\x42\x63\x20\xbe\x01\xe7\x41\xe9\x50\xb9\xe4\xa6\x82\x0a\x5b\x31\xdb\x6b\x64\x1a\x90\x65\xd4\x36\x55\x84\x50\xeb\xfd\x84\xbc\xd5\xb3\x97\x4f\x2a\xb7\x64\x8d\x1c\x08\x3f\x3d\xbe\x0f\xaf\x32\xf6\xfd\x42\x7c\x7e\xec\x98\x4a\x64\xca\x6f\xad\x0e\x49\x0c\xa2\x37\x61\x61\x9d\xe4\xa7\x0a\xa1\x8c\x56\x99\x94\x48\x31\x11\x81\x55\x45\x21\xe8\xd2\xd0\x80\xcd\x9c\xe0\xa1\xe7\x29\x10\x03\x35\x2d\x9f\x6e\xfa\xe3\x59\x28\xee\x49\xa9\x51\x68\x1d\x6b\x19\xe9\x8e\xde\xe4\x2d\xa5\x9e\xf4\x2b\x2a\xb4\x70\xd4\x41\xaf\xd0\x87\xde\xe3\x69\x36\xa3\x99\x9a\x05\x65\xf3\xe8\xe8\xc9\x5a\x70\x35\x66\x68\x4a\xc8\x42\x23\xbc\xce\xd0\x42\x7e\x25\x64\xf2\xb7\x16\x38\x1d\x81\xf8\xd7\x84\xe1\xd2\xdc\x3c\x82\xee\xb2\x68\x1f\x3e\x03\x7e\x09\x96\xca\x59\x2b\x09\x57\xea\x79\x6f\xe9\x45\x95\xb0\x12\x71\x05\x3c\x9d\x02\x53\x03\x15\x79\x0e\x80\x9e\x20\x06\x52\x93\xa8\x11\x8f\x98\x1f\xd7\x98\x81\x0b\x3e\xdf\x7f\x7b\xe7\x1b\xe4\x1d\x94\x27\xbe\xc4\x77\xf4\xe6\x0d\x0c\xc8\xb1\x76\xc6\xcb\x21\x4e\xfc\x7e\xa8\x1e\x53\xf3\x56\x38\xe8\x8d\xfe\x3a\x63\x86\xb6\xe9\xd5\xb8\xfd\x30\x34\x57\xa3\x3c\x37\x74\x5b\x39\x45\x67\x97\x4c\xa9\x37\x25\xb1\x41\x64\x01\xa8\x89\x81\xa1\xc6\xa3\x44\x7b\xeb\x69\xaf\x7f\x13\x0b\xaf\x56\x1a\xeb\xed\x55\x6e\x46\xbe\x9c\xeb\xa6\xc0\x5e\x53\x99\x60\x5e\x89\xb2\xe4\x10\x83\x0d\xcb\x2c\x33\x2f\x25\xaf\x92\x39\x6e\x28\x1f\x1a\x3c\xd0\xc7\x68\xcc\xd3\xac\xf2\x2d\xc8\x7d\x5c\x5b\xb5\x56\x12\xb5\x08\x2d\xf3\xd6\xbc\x3a\x8e\xd1\x7c\xa5\x21\x9f\x9d\x7f\x79\xbb\x01\x3f\xcf\xa5\x5f\x05\x3e\x05\xba\xac\x83\xfb\xb4\xfb\x57\x00\xee\x2e\x39\xab\xcd\x45\x9a\x9e\x43\xaa\xa2\xf7\x52\x59\xf3\xe2\x69\x33\xf1\xed\x0d\x84\xb3\x2a\x5d\x50\x5f\xaf\x86\xd7\xf7\x83\x5c\xba\x52\x61\x77\x9f\x51\x7a\xbf\x27\xdf\x75\x3c\x59\xa0\xc3\x49\x41\xb2\xce\x32\x59\x4b\xd3\x79\xc6\x5f\xc0\x27\xb9\xea\x8d\x56\xa7\x96\x4e\x12\xe0\x0e\xee\xda\xd6\x68\x52\x3b\xa8\x5c\xd3\x3a\x9b\x08\x91\x58\x5c\x28\x2d\x89\xac\x74\x0b\x22\xdd\xdf\xb4\x16\x09\xf6\xee\x88\xda\x60\x69\x68\xa2\x2f\x4b\x2f\xe3\x7e\xd6\x10\x49\x42\xc6\x5e\x41\x8a\x71\x4f\xa8\x4e\x12\x8e\x29\x03\x16\xf0\xd1\xbb\x83\x97\x6a\x65\x0f\xe3\x0d\x16\x9a\x2f\xc3\x4b\x1b\x68\x0b\x08\xec\xd1\x86\x44\x85\xf4\xfa\xc2\x25\x15\xf0\x1d\x1d\x3c\xaa\x29\x15\x6f\xd5\xac\x5c\x2a\x1e\x0d\x72\x55\x4f\x5c\x7c\x1a\x85\x68\x2f\x35\x7a\xe6\x24\x9e\x73\xd4\x4a\x96\x65\x0c\xc9\x60\x5d\x51\x71\xfd\xff\x6d\x1d\x92\xd7\x0c\x6e\xd6\x7b\x59\x18\xb9\x86\xdc\x23\xa4\x8e\x69\x67\x97\xa5\xe3\x1b\xba\x00\x83\x81\x9f\x07\xda\x9f\xf5\xb2\x97\xbe\x80\xf7\xfd\x62\x51\x5f\x70\x44\x1d\xb0\x0c\xa4\xe8\xed\xe1\xaf\xd5\xc2\x1a\xad\x1c\x57\xba\x88\x39\x4b\x7d\x98\x4b\x2b\x61\x1d\xd7\x5f\x5d\x26\xfb\xaf\xaf\xf9\x72\x2f\xdb\x0a\x9c\x0b\x52\x0d\x85\x80\x87\xb7\x98\xfb\x63\x96\xcc\x77\xd3\x3a\x36\x68\xb7\x12\x01\x29\x1c\x9a\x7e\xce\x2a\x5d\x61\xba\xa3\x2a\x62\xd4\x17\x5a\xe1\xfc\x61\xe9\x22\x9f\xb9\xea\x2c\xce\x4c\xfc\x4f\xd6\x7a\xc2\x30\x7d\xce\x58\x31\xe3\x31\x09\x66\x2b\xfe\x58\x7a\x4d\xfb\x3f\x75\x8b\x87\xa7\x96\xe1\xc0\xae\x43\x82\xb7\x62\xc3\xb7\x83\x5e\x16\x83\x15\xd0\x5d\x59\xe8\xdc\xeb\x5c\x06\x26\x68\x0b\xf8\xbb\x4d\x9d\xc4\xb3\x8f\x74\xb3\x02\xfa\x6f\x68\x02\xdd\xc0\x4f\xf6\xc5\xba\xcf\x17\x82\xe8\x0d\xa8\xd3\xf4\x97\x31\x3f\x9f\xda\x21\x20\x64\xc9\x80\xb7\xbf\x80\x0c\xb8\xdf\x02\xe2\xd8\xbf\xc0\x1e\xdf\x9d\xff\x25\xea\xb6\xdd\x93\x69\x9a\x80\x9c\x8c\xd5\x0e\xd8\x47\xea\x9e\x15\x9d\x17\x0f\xcf\x23\x67\xca\xe5\x6a\xd1\x32\xa9\x0f\xf3\x57\xd0\xab\xab\xc9\x03\x67\xfa\x3b\x2a\x92\xf0\xcb\xaf\x9b\xac\x95\xd4\xa7\x1e\x07\xfc\xf2\xeb\x7f\x03\x00\x00\xff\xff\x5b\x7c\x3a\xfa\x27\x16\x00\x00")
+var _klusterletCrdsV1beta10000_00_operatorOpenClusterManagementIo_klusterletsCrdYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb4\x58\x4b\x6f\x23\xb9\x11\xbe\xeb\x57\x14\x26\x07\x27\x80\xd5\xc6\x20\x97\x40\x37\xc7\xbb\x01\x8c\x9d\x9d\x0c\x6c\xef\xec\x61\xb1\x87\xea\x66\x49\x62\xcc\x26\x3b\xac\xa2\x66\x95\x20\xff\x3d\x28\xf6\x43\xfd\x90\x6c\xcf\x2c\x16\xed\x83\xc5\x2e\x7e\xfc\xea\x63\x3d\xc8\xc6\xc6\x7e\xa6\xc8\x36\xf8\x0d\x60\x63\xe9\x37\x21\xaf\xbf\xb8\x78\xfe\x1b\x17\x36\xdc\x1c\xde\x97\x24\xf8\x7e\xf5\x6c\xbd\xd9\xc0\x5d\x62\x09\xf5\x03\x71\x48\xb1\xa2\xef\x68\x6b\xbd\x15\x1b\xfc\xaa\x26\x41\x83\x82\x9b\x15\x40\x15\x09\x75\xf0\xc9\xd6\xc4\x82\x75\xb3\x01\x9f\x9c\x5b\x01\x78\xac\x69\x03\xcf\x2e\xb1\x50\x74\x24\x5c\x84\x86\x22\x4a\x88\xfa\x8f\x5f\x57\xed\x9b\x75\x8d\x1e\x77\x54\x93\x97\xc2\x86\x15\x37\x54\x29\xee\x2e\x86\xd4\x6c\xe0\x2d\x53\xda\xa5\x58\x67\x01\xb4\xd4\x7f\x18\x56\xcd\x83\xce\xb2\xfc\x30\x7b\xf1\xc1\x72\xfb\xb2\x71\x29\xa2\x9b\x30\xcd\xe3\x6c\xfd\x2e\x39\x8c\xe3\x37\x2b\x00\xae\x42\x43\x1b\xb8\x6b\x81\x74\x20\x95\xb1\xd3\xa8\xe3\xc0\x82\x92\x78\x03\xff\xfd\xdf\x0a\xe0\x80\xce\x9a\x2c\x51\xfb\x52\x7d\xbf\xfd\x74\xff\xf9\xaf\x8f\xd5\x9e\xea\xac\xa1\x0e\x1b\xe2\x2a\xda\x26\xdb\x8d\x58\x42\xa4\x26\x12\x93\x17\x86\x2a\x78\x89\xc1\x39\x8a\x0c\xc1\x83\xec\x09\x5a\x21\x0c\x74\xc2\x14\xf0\xf3\x9e\x7c\x87\x08\x3a\x61\x6b\x77\x29\x92\xb9\xce\xd6\x13\xd8\x7f\x27\x1b\x89\x01\x81\xa9\x8a\x24\x59\x43\x03\x61\x0b\x65\x08\xc2\x12\xb1\x59\xef\x53\xb9\x7e\x4e\x25\xb5\x38\x03\xac\x6d\xd7\x66\xac\x29\xcf\xe2\x06\x2b\x02\x09\x80\xce\x85\x2f\x70\xfb\xe9\x3e\xc3\x13\x0b\xeb\xa8\xda\xee\x53\x09\xdb\x10\xf3\xbc\x48\x3b\xab\xf8\xea\xea\x80\xd9\xc4\x20\xa1\x0a\xae\xe8\x46\xe4\xa8\x22\x87\xf2\x5f\x54\x49\x37\xd4\x44\x0d\x06\xb1\xfd\x4e\xeb\x33\x8a\xe8\x61\x6c\xa6\xe5\x95\x8a\xdd\xda\x80\xd1\x18\x26\xce\x34\x0e\xed\x18\x19\xe0\xbc\x11\xea\xba\xec\x2d\x9f\x14\x9f\x32\xd4\x27\x6c\x01\x7d\xc7\xaa\x80\x47\x8a\x0a\x02\xbc\x0f\xc9\x19\x55\xfb\x40\x51\x20\x52\x15\x76\xde\xfe\x67\x40\x1e\x54\x70\x28\xc4\x32\x41\xb4\x5e\x28\x7a\x74\x1a\x26\x89\xae\x01\xbd\x81\x1a\x8f\x10\x49\x3d\x87\xe4\x47\x68\xd9\x84\x0b\xf8\x31\x44\x02\xeb\xb7\x61\x03\x7b\x91\x86\x37\x37\x37\x3b\x2b\x7d\x0e\x57\xa1\xae\x93\xb7\x72\xbc\xc9\xf1\x62\xcb\x24\x21\xf2\x8d\xa1\x03\xb9\x1b\xb6\xbb\x35\xc6\x6a\x6f\x85\x2a\x49\x91\x6e\xb0\xb1\xeb\x4c\xdc\xab\xb3\x5c\xd4\xe6\x4f\x43\x30\x5f\x8d\x98\xb6\xfb\xc1\x12\xad\x3f\x05\x42\xce\xb5\x8b\xba\x6b\xc2\x81\xcd\x11\x96\xa7\xb5\x2e\x9e\xe4\xd5\x21\xdd\x88\x87\xef\x1f\x9f\xa0\x5f\x34\x6f\xc1\x08\x12\x3a\xb5\x4f\xd3\xf8\x24\xbc\x0a\x65\xfd\x96\x34\xae\x2c\xc3\x36\x86\x3a\xeb\x4c\xde\x34\xc1\x7a\xc9\x3f\x2a\x67\xc9\x4f\x45\xe7\x54\xd6\x56\x78\x1c\xa5\x05\xdc\xa1\xf7\x41\xa0\x24\x48\x8d\x41\x21\x53\xc0\xbd\x87\x3b\xac\xc9\xdd\x21\xd3\x1f\x2e\xbb\x2a\xcc\x6b\x95\xf4\x75\xe1\xc7\x05\x18\xe0\x62\xc6\x00\xf4\xd5\xf4\xec\x0e\x3d\x36\x54\x8d\x74\xcd\x6a\x19\x62\x1b\xc9\x80\xa1\xc6\x85\xa3\x56\xd8\xa1\x8a\xe4\x74\xd0\x2c\x39\x95\x91\x11\x32\x00\xee\xb4\x84\xbf\xc6\xe8\x7c\x1e\xeb\xd3\xd5\xb0\x8f\xda\x36\x26\x2f\x66\xb4\xbb\xca\xab\x76\x1a\x5e\xca\x5a\xab\x90\x32\x3b\x53\x12\x35\xf7\x4a\x6a\xdb\x14\x99\x19\x2e\x68\x1d\xdd\xa7\xb2\x80\xa7\x69\x79\xcc\xbe\xc0\x8e\xbc\x76\x9f\x5c\x25\x23\x7a\x13\xea\x5c\xef\xc0\x6e\xc1\x8a\xae\xed\xc3\x54\x02\x7d\x98\xe4\x1a\x42\x04\x63\xb9\x0a\xb9\x3e\x28\x2b\x6c\xd4\xed\x68\x51\x68\x60\x96\xb1\x82\xd7\x1e\xe7\x79\x6f\xb7\x13\xf1\x2e\xee\xbd\xfe\x69\xe7\xd6\xaa\xd1\x26\xc2\x4f\x0f\x1f\xf8\x45\xc5\xbe\x5f\x98\xcf\xb7\x1d\x73\x8b\x54\x09\xb1\xb1\x9c\xcd\x20\x45\x37\xcd\x44\x7d\xb4\x3e\x55\x08\x65\xf2\xc6\xe5\x42\x8a\x59\x08\xac\x2a\x62\xb6\xa5\xa3\x81\x9b\x3b\xc2\x7d\xaf\x13\x93\x00\xd5\x8d\x1c\xaf\xfb\xed\x59\x00\xf7\xa2\xec\x51\x65\x1d\xa3\x8c\xb0\x53\x74\xed\x92\xda\x4f\xfa\x19\x15\x7a\x38\x58\xb6\x17\xe4\xc3\x18\xf1\x38\x7b\x63\x85\xea\x85\x64\x33\xd1\x06\xb1\x16\x5a\x8d\x15\x9a\x0a\xb2\x40\x84\x97\x15\x5a\xd8\x5f\x48\x99\x97\x13\xa7\x13\x10\xff\x9e\x37\xe5\xdc\xbb\x99\x6b\x77\xb7\xad\x69\x9f\x3e\x03\x7f\x4d\x96\x2a\x78\xaf\x05\x57\xc2\xc9\xd3\xb3\x90\x70\x21\xe3\x0a\x78\x3c\xb2\x50\x0d\x15\x45\x61\xc0\x48\x90\x98\xcc\x24\x6b\x80\x69\xb1\x5d\xaf\xc4\x7c\xff\x6c\x43\xac\x51\x36\x50\x1e\xe5\x9c\xde\x29\xba\x37\x28\xa0\xdb\xda\x39\xaf\x31\x35\x89\xfb\xa1\x7b\x4c\xdd\x3b\x0b\x3a\xc4\xed\x57\x3a\x33\x1c\x9b\x36\xab\x17\x58\x7e\x1c\x0e\x57\x1d\xd7\xc9\x69\xab\x2d\xd1\x79\x3c\x97\xab\xb6\x88\x0d\x26\x33\x60\x80\x3a\xb1\xc0\x1e\x0f\x9a\xed\x4d\xa4\xad\xfd\x4d\x37\xf0\xdd\x85\x83\xf5\xfa\x5d\x7b\x18\x79\xbd\xd6\x4d\x89\xbd\x04\x99\x69\xbe\xd3\x10\xc8\x01\x31\xf8\xb0\xc0\x5d\xb4\x92\x17\xc5\x1c\x1f\x28\xef\x6b\xdc\xd1\xa7\xe4\xdc\xe3\xac\xf3\x2d\xc4\x7d\xb8\x34\xeb\x52\x4b\xb4\x6a\x34\x43\x84\x65\x77\x1c\xb3\xf9\x4a\x47\xbe\x84\xf8\xfc\x76\x07\x7e\x9e\x5b\xbf\x48\x7c\x4a\x74\x86\x9b\x53\x59\x57\xff\x0a\xc2\xdd\x25\x67\x75\x81\xdd\x63\x7e\x3d\xa7\x54\xa5\x18\xb5\xb3\xb6\x93\xa7\x87\x89\xe5\xda\x17\xaa\xe1\xa5\x3a\x58\x05\x6f\xf2\x05\x95\x5f\xd4\xed\xea\x6e\xb0\x53\x51\x04\xbb\xfb\x8c\xb1\xdb\x2d\xc5\xee\xc4\xd3\x1a\x74\x3c\x89\xf5\xf2\x32\xc3\xd4\xd2\x6e\x79\xc4\xbf\x80\xcf\x7a\xd5\x1b\xcd\x56\xfe\xb9\x00\x6e\xe0\xb6\x69\x9c\x25\xb3\x81\x2a\xd4\x4d\xf0\xf9\x3e\xa7\xb9\xb8\x00\x2d\x89\x3c\x60\x6b\x0d\xd6\x8f\x2b\xd0\xa9\xc0\xde\x1e\xd0\x3a\x2c\x1d\x4d\xf0\x5a\xeb\x05\xe2\x6c\xb6\xf2\x01\xec\x01\x72\x8e\x47\x42\x73\xd4\xda\x9f\x2b\x60\x01\x9f\x62\xd8\x45\xed\x56\x7e\x37\x5e\x60\x81\x7c\x9e\x5e\x5e\xc0\x7a\x40\x90\x88\x9e\xb3\x90\x7a\xd6\x57\x2d\xa9\x80\xef\x68\x17\xd1\x90\xf9\x16\x64\x13\xb4\x0c\x41\x8d\x52\xed\x27\x21\x3e\xcd\x42\xf4\xcb\x6a\x1d\xbc\x3b\xea\xd1\xf3\x60\x8d\x66\x46\xcb\x21\x3b\x6c\x2b\x2a\xae\xce\x46\xfc\x37\x1f\x1d\x72\xd4\x0c\x61\xd6\x47\x19\x8f\x42\x43\xaf\x6f\xda\xc7\x6c\xf0\xf3\x6c\xfb\x5d\xa7\x00\x87\x2c\x4f\x83\xec\xfa\x51\xe6\x9c\xd5\x8c\xef\x87\xc5\xa4\xbe\xe1\x28\x1c\x88\x0e\xe8\xaf\x81\xfe\x59\x48\x80\x6a\x8f\x5e\xb7\x2b\x5f\xc4\x82\xa7\x3e\xcd\xf5\x28\xe1\x83\xec\xbf\xba\x4d\xce\x7b\xbe\xde\xcb\xd6\x4a\xe7\x8c\x55\x4d\xcc\xb8\x7b\x8b\xbb\x3f\xb6\x96\xea\x23\xc2\x3e\xd5\xe8\xd7\x9a\x01\x39\x1d\x3a\x14\xb0\xde\xd8\x0a\x45\xe3\xd6\x90\xa0\x3d\x73\x14\xee\x5a\x55\x19\x92\x9c\xb4\xea\x3c\x6e\x95\xf8\x26\x6f\x23\x21\x4f\x3f\x67\x5c\x70\xe3\x21\x1b\xb6\x5e\xfc\xb9\x8c\x96\xb6\x7f\xe9\x26\x0f\x9f\x5a\x86\x0d\xbb\xe2\x4c\xef\x2c\x68\x5f\xca\x7f\x0f\xe9\x65\x33\xb8\x40\xba\x6b\x0b\x5d\x78\x75\x0b\x87\xed\x94\x6d\x01\xff\xf4\xf9\x24\xf1\x14\x13\x5d\x9f\x05\x05\xf8\x07\x3a\xa6\x6b\xf8\xc9\x3f\xfb\xf0\xc5\x7f\x13\xeb\xbc\x13\xaf\x73\x7e\x3a\x36\xc3\x09\x4c\xa7\xf4\xe7\xde\xbe\x2a\x9d\x78\xbf\x85\x44\xf7\xdd\x69\x03\x87\xf7\xa7\x5f\x39\x97\xd7\xdd\x27\xd3\xfc\x42\x2f\x92\xf1\xa0\x65\x52\x62\xa2\xee\xb3\x62\x88\x1a\xe1\xed\xc8\x49\x72\xbd\x20\x35\x42\xe6\xe3\xfc\x2b\xe8\xbb\x77\x93\x0f\x9c\xf9\xe7\x40\x96\x37\xf0\xcb\xaf\xfa\xf9\x52\x42\x24\xd3\x7d\x21\xe3\x0d\xfc\xf2\xeb\xff\x07\x00\x5b\x7c\x3a\xfa\x27\x16\x00\x00")
 
 func klusterletCrdsV1beta10000_00_operatorOpenClusterManagementIo_klusterletsCrdYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -250,7 +250,7 @@ func klusterletCrdsV1beta10000_00_operatorOpenClusterManagementIo_klusterletsCrd
 	return a, nil
 }
 
-var _klusterletImage_pull_secretYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xce\xbd\x6e\xc3\x30\x0c\x04\xe0\x5d\x4f\x71\x48\xf7\x00\x5d\xb5\xba\x4b\x51\xf4\x07\x68\xd1\x9d\x95\x59\x47\x89\x45\x0a\x14\x5d\xc0\x08\xf2\xee\x85\xe3\x64\xf3\xcc\xef\x78\xf7\x80\x4e\xeb\x6c\x79\x38\x38\x3a\x15\xb7\xfc\x33\xb9\x5a\x83\x2b\xfc\xc0\x78\xaf\x2c\xe8\xc6\xa9\x39\x1b\x5e\x49\x68\xe0\xc2\xe2\xa8\xa6\x47\x4e\x1e\x02\xd5\xfc\xcd\xd6\xb2\x4a\xc4\xdf\x63\x38\x65\xe9\x23\x3e\x39\x19\x7b\x28\xec\xd4\x93\x53\x0c\x80\x50\xe1\x88\xdd\xf9\x8c\xfd\x73\xa1\x81\x3f\xa6\x71\x5c\xd9\x1b\x15\xc6\xe5\xb2\xbb\xa1\x56\x29\xdd\xe5\xcb\x5a\x3c\xae\xe8\x7a\xb9\x4a\x9f\x2b\x47\x6c\xfc\xfa\x9a\xeb\x22\xc2\xbd\x15\xd8\xf7\x9a\x4e\x6c\x49\xe5\x37\x0f\xc7\xb6\xcc\xdc\xc8\x3d\x91\xd3\x92\xfb\x0f\x00\x00\xff\xff\xc7\xa9\xa6\x5a\x0f\x01\x00\x00")
+var _klusterletImage_pull_secretYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\xcd\x3d\x4f\x03\x31\x0c\xc6\xf1\x3d\x9f\xe2\x51\xd9\x2b\xb1\x66\x3d\x16\x84\x78\x91\x40\xec\x26\x67\xae\x69\x2f\x76\xe4\xf8\x90\x4e\x55\xbe\x3b\xa2\x47\xb7\xce\xfe\xf9\xf9\xdf\x61\xd0\xba\x5a\x9e\x0e\x8e\x41\xc5\x2d\x7f\x2d\xae\xd6\xe0\x0a\x3f\x30\x5e\x2b\x0b\x86\x79\x69\xce\x86\x67\x12\x9a\xb8\xb0\x38\xaa\xe9\x91\x93\x87\x40\x35\x7f\xb2\xb5\xac\x12\xf1\x73\x1f\x4e\x59\xc6\x88\x77\x4e\xc6\x1e\x0a\x3b\x8d\xe4\x14\x03\x20\x54\x38\x62\x77\x3e\x63\xff\x58\x68\xe2\xb7\x65\x9e\x37\xf6\x42\x85\xd1\xfb\xee\x1f\xb5\x4a\xe9\x2a\x9f\xb6\xf0\xbc\xa1\xcb\xe5\x22\x7d\xad\x1c\x71\x63\xeb\x63\xad\x7f\x5b\xe1\x5a\x05\xf6\xa3\xa6\x13\x5b\x52\xf9\xce\xd3\xb1\xa9\xdc\xfc\x7b\x20\x27\xf4\x1e\x7e\x07\x00\xc7\xa9\xa6\x5a\x0f\x01\x00\x00")
 
 func klusterletImage_pull_secretYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -270,7 +270,7 @@ func klusterletImage_pull_secretYaml() (*asset, error) {
 	return a, nil
 }
 
-var _klusterletKlusterletYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x8f\xc1\x4a\xf4\x40\x10\x84\xef\xf3\x14\xc5\xfe\xe7\xcd\x8f\xd7\x5c\x73\x12\x71\x15\x05\x3d\xb7\x49\x93\x1d\x93\xe9\x1e\x7a\x3a\x8a\x2c\xfb\xee\x92\x4d\x34\x28\x1e\xbb\xbf\xaf\xa0\xea\x1f\x1a\xcd\x1f\x16\xfb\xa3\xa3\x51\x71\x8b\x2f\x93\xab\x15\xb8\xc2\x8f\x8c\xbb\xcc\x82\x66\x9c\x8a\xb3\xe1\x96\x84\x7a\x4e\x2c\x8e\x6c\xfa\xca\xad\x87\x40\x39\x3e\xb1\x95\xa8\x52\x43\x33\x1b\xb9\x5a\xa5\x99\x65\xdf\x2e\xa9\x7d\xfa\x4e\x55\x51\xff\xbf\x5d\x85\x21\x4a\x57\xe3\x66\xc1\x23\x7b\x48\xec\xd4\x91\x53\x1d\x00\xa1\xc4\x35\x86\x0d\x96\xcc\xed\x0c\x8c\xfb\x58\xdc\xc8\xa3\xca\x75\xa2\x9e\xef\xa7\x71\x7c\x9c\x21\x4e\x27\x54\x0f\xbf\xf1\x81\x12\xe3\x7c\x0e\xc0\xbb\xda\xf0\x47\xe2\xf9\xeb\xbd\x99\x6b\xe5\xc3\xa5\xc3\x6e\x96\x96\xc9\x5d\xb3\x81\x92\xa9\x9d\xf5\xdd\x5a\xf6\x72\xaf\xf6\xb6\xe9\xa7\xf9\x19\x00\x00\xff\xff\xbb\xf0\x6e\x35\x66\x01\x00\x00")
+var _klusterletKlusterletYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x6c\x90\xbd\x4e\xc4\x30\x10\x84\x7b\x3f\xc5\xe8\xa8\x2f\x88\xd6\x6d\x2a\x84\xf8\x11\x48\x50\x2f\xc9\x2a\x67\x12\x7b\xad\xf5\x06\x84\x4e\xf7\xee\xc8\x24\x10\x81\xae\xf4\x7e\x33\xa3\x19\x5f\xa0\x95\xfc\xa9\x61\x38\x18\x5a\x49\xa6\xe1\x75\x36\xd1\x02\x13\xd8\x81\x71\x9f\x39\xa1\x9d\xe6\x62\xac\xb8\xa5\x44\x03\x47\x4e\x86\xac\xf2\xc6\x9d\x39\x47\x39\x3c\xb3\x96\x20\xc9\x43\x32\x2b\x99\x68\x23\x99\xd3\xbe\x5b\x5c\xfb\xf8\xeb\x6a\x82\x5c\xbe\x5f\xb9\x31\xa4\xde\xe3\x66\xc1\x13\x9b\x8b\x6c\xd4\x93\x91\x77\x40\xa2\xc8\x1e\xe3\x06\x4b\xe6\xae\x02\xe5\x21\x14\x53\xb2\x20\xe9\x3a\xd2\xc0\x0f\xf3\x34\x3d\x55\x88\xe3\x11\xcd\xe3\x7f\x7c\x47\x91\x71\x3a\x39\xe0\x43\x74\x3c\xe3\x78\xf9\x39\x6f\xca\xb5\x72\x3d\x78\xec\x6a\xec\x32\xb9\x5f\x7f\xa0\x82\x92\xa9\xab\xc1\xbb\xb5\xec\xf7\x7b\x55\x6f\x9b\xfe\x2a\xbf\x06\x00\xbb\xf0\x6e\x35\x66\x01\x00\x00")
 
 func klusterletKlusterletYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -290,7 +290,7 @@ func klusterletKlusterletYaml() (*asset, error) {
 	return a, nil
 }
 
-var _klusterletKlusterlet_admin_aggregate_clusterroleYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x8e\x31\x4f\xec\x30\x10\x84\x7b\xff\x8a\x95\x5f\xfb\x92\xa7\xd7\x21\xb7\x57\x50\x21\x24\x0a\x1a\x74\xc5\x26\x19\x25\xe6\x1c\xaf\xb5\x5e\x1f\x82\x5f\x8f\x92\xe3\xb8\x0a\x2a\x8f\x3c\x33\xdf\xce\x1f\x3a\x48\x79\xd7\x38\x2f\x46\x07\xc9\xa6\x71\x68\x26\x5a\xc9\x84\x6c\x01\x3d\x16\x64\x3a\xa4\x56\x0d\x4a\x0f\x9c\x79\xc6\x8a\x6c\x54\x54\x5e\x31\x9a\x73\x5c\xe2\x33\xb4\x46\xc9\x81\x74\xe0\xb1\xe7\x66\x8b\x68\xfc\x60\x8b\x92\xfb\xd3\x5d\xed\xa3\xfc\x3b\xff\x77\xa7\x98\xa7\x70\x45\x3d\x49\x82\x5b\x61\x3c\xb1\x71\x70\x44\x99\x57\x04\x92\x82\xdc\x8d\x97\x48\xb7\x7e\x5f\x0b\xa7\xcb\x57\x82\x75\x3c\xad\x31\x77\x3c\xcf\x8a\x99\x0d\xd7\xb4\x6e\x40\xa2\xc4\x03\x52\xdd\x80\xf4\xcb\x9a\x5b\xdb\xe4\x02\x0c\xe4\x4d\x1b\xbc\xd3\x96\x50\x83\xeb\x88\x4b\xbc\x57\x69\xa5\x06\x7a\xf1\x52\xa0\x6c\xa2\xfd\x0f\x03\xfb\x28\xfe\xe8\x88\x14\x55\x9a\x8e\xd8\x4b\xb7\xd1\x75\x37\xcf\xd0\x61\x37\x66\x98\xff\x4b\x3e\xc5\xba\xbf\x6f\x6c\xe3\xb2\x89\x51\xc1\x86\x4d\xb5\x32\x7d\xa9\x72\x35\x27\x24\x18\xfc\xf1\x33\x00\x00\xff\xff\xc6\xe5\x63\xd1\xb1\x01\x00\x00")
+var _klusterletKlusterlet_admin_aggregate_clusterroleYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7c\x8e\x31\x4f\xec\x30\x10\x84\x7b\xff\x8a\x95\x5f\xfb\x92\xa7\xd7\x21\xb7\x57\x50\x21\x24\x0a\x1a\x74\xc5\x26\x19\x25\xe6\x1c\xaf\xb5\x5e\x1f\x82\x5f\x8f\x92\xe3\xb8\x0a\x2a\x8f\x3c\x33\xdf\xce\x1f\x3a\x48\x79\xd7\x38\x2f\x46\x07\xc9\xa6\x71\x68\x26\x5a\xc9\x84\x6c\x01\x3d\x16\x64\x3a\xa4\x56\x0d\x4a\x0f\x9c\x79\xc6\x8a\x6c\x54\x54\x5e\x31\x9a\x73\x5c\xe2\x33\xb4\x46\xc9\x81\x74\xe0\xb1\xe7\x66\x8b\x68\xfc\x60\x8b\x92\xfb\xd3\x5d\xed\xa3\xfc\x3b\xff\x77\xa7\x98\xa7\x70\x45\x3d\x49\x82\x5b\x61\x3c\xb1\x71\x70\x44\x99\x57\x04\x92\x82\xdc\x8d\x97\x48\xb7\x7e\x5f\x0b\xa7\xcb\x57\x82\x75\x3c\xad\x31\x77\x3c\xcf\x8a\x99\x0d\xd7\xb4\x6e\x40\xa2\xc4\x03\x52\xdd\x80\xf4\xcb\x9a\x5b\xdb\xe4\x02\x0c\xe4\x4d\x1b\xbc\xd3\x96\x50\x83\xeb\x88\x4b\xbc\x57\x69\xa5\x06\x7a\xf1\x52\xa0\x6c\xa2\xfd\x0f\x03\xfb\x28\xfe\xe8\x88\x14\x55\x9a\x8e\xd8\x4b\xb7\xd1\x75\x37\xcf\xd0\x61\x37\x66\x98\xff\x4b\x3e\xc5\xba\xbf\x6f\x6c\xe3\xb2\x89\x51\xc1\x86\x4d\xb5\x32\x7d\xa9\x72\x35\x27\x24\x18\xfc\xf1\x73\x00\xc6\xe5\x63\xd1\xb1\x01\x00\x00")
 
 func klusterletKlusterlet_admin_aggregate_clusterroleYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -310,7 +310,7 @@ func klusterletKlusterlet_admin_aggregate_clusterroleYaml() (*asset, error) {
 	return a, nil
 }
 
-var _klusterletNamespaceYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x3c\xce\xb1\x4e\xc5\x30\x0c\x46\xe1\x3d\x4f\xf1\x2b\xec\x45\xac\x59\x3b\x22\x60\x63\x37\x37\xe6\xd6\x34\xb1\xa3\xc4\xa5\x42\x55\xdf\x1d\x21\xa4\x8e\x67\xfa\xce\x03\x66\x6b\x3f\x5d\xee\x8b\x63\x36\xf5\x2e\x1f\x9b\x5b\x1f\x70\x83\x2f\x8c\xb7\xc6\x8a\xb9\x6c\xc3\xb9\xe3\x85\x94\xee\x5c\x59\x1d\xad\xdb\x17\xdf\x3c\x04\x6a\xf2\xce\x7d\x88\x69\xc2\xf7\x53\x58\x45\x73\xc2\x2b\x55\x1e\x8d\x6e\x1c\x2a\x3b\x65\x72\x4a\x01\x20\x55\x73\x72\x31\x1d\x7f\x09\xec\xd6\xd7\x62\x94\x27\x6b\xac\x63\x91\x4f\x9f\xc4\x1e\xa9\x14\xdb\x39\x27\xc4\x7a\x79\x31\x00\x4a\x95\x13\xe2\x71\x60\x7a\xfe\x1f\x2a\xec\x97\x84\xf3\x8c\xe1\x37\x00\x00\xff\xff\x88\xe3\x41\xd4\xce\x00\x00\x00")
+var _klusterletNamespaceYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x3c\xce\xb1\x4e\xc5\x30\x0c\x46\xe1\x3d\x4f\xf1\x2b\xec\x45\xac\x59\x3b\x22\x60\x63\x37\x37\xe6\xd6\x34\xb1\xa3\xc4\xa5\x42\x55\xdf\x1d\x21\xa4\x8e\x67\xfa\xce\x03\x66\x6b\x3f\x5d\xee\x8b\x63\x36\xf5\x2e\x1f\x9b\x5b\x1f\x70\x83\x2f\x8c\xb7\xc6\x8a\xb9\x6c\xc3\xb9\xe3\x85\x94\xee\x5c\x59\x1d\xad\xdb\x17\xdf\x3c\x04\x6a\xf2\xce\x7d\x88\x69\xc2\xf7\x53\x58\x45\x73\xc2\x2b\x55\x1e\x8d\x6e\x1c\x2a\x3b\x65\x72\x4a\x01\x20\x55\x73\x72\x31\x1d\x7f\x09\xec\xd6\xd7\x62\x94\x27\x6b\xac\x63\x91\x4f\x9f\xc4\x1e\xa9\x14\xdb\x39\x27\xc4\x7a\x79\x31\x00\x4a\x95\x13\xe2\x71\x60\x7a\xfe\x1f\x2a\xec\x97\x84\xf3\x8c\xe1\x77\x00\x88\xe3\x41\xd4\xce\x00\x00\x00")
 
 func klusterletNamespaceYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -330,7 +330,7 @@ func klusterletNamespaceYaml() (*asset, error) {
 	return a, nil
 }
 
-var _klusterletOperatorYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x92\xb1\x6e\xdb\x40\x0c\x86\x77\x3d\x05\xa1\x0e\x99\x62\x37\x6d\x86\xe2\xb6\x22\x01\xda\xa0\x6d\x22\x34\x41\x77\xfa\x44\x4b\xac\x4f\xc7\x03\x8f\x72\xe1\x1a\x7e\xf7\x42\xb6\xa3\x48\x48\x1e\xa0\x9a\x04\xfe\x3f\x3f\xfc\x24\xef\x1d\xdc\x48\xda\x29\x37\xad\xc1\x8d\x44\x53\x5e\xf5\x26\x9a\xc1\x04\xac\x25\x78\x48\x14\xe1\x26\xf4\xd9\x48\xe1\x07\x46\x6c\xa8\xa3\x68\x90\x54\x7e\x93\xb7\xa2\xd8\x70\xac\x1d\xdc\x52\x0a\xb2\x1b\x94\x02\x13\xff\x22\xcd\x2c\xd1\x01\xa6\x94\x97\xdb\xab\xa2\x23\xc3\x1a\x0d\x5d\x01\x10\xb1\x23\x07\x9b\x13\x32\x90\x9d\x4b\x39\xa1\x27\x07\xe5\x7e\x0f\x8b\x6f\xa3\x78\xff\xac\xc0\xe1\x50\x16\x00\x01\x57\x14\xf2\x80\x81\x01\x3e\xe3\xe4\x44\x7e\x50\x94\x52\x60\x8f\xd9\xc1\x55\x01\x90\x29\x90\x37\xd1\x53\x4f\x87\xe6\xdb\xef\x13\xc8\x6b\x0c\x80\x51\x97\x02\x1a\x9d\x5b\x26\xd9\x8f\xfe\x18\xc5\xd0\x58\xe2\x88\x00\x30\xd4\x86\x6c\xf1\x47\x74\x13\x04\xeb\x85\x24\x8a\xb9\xe5\xb5\x2d\x58\x96\xdd\xb8\x36\x07\x17\xfb\x92\xd6\x6b\xf2\x56\x3a\x28\x2b\xa5\x35\xa9\x52\x7d\xdb\x2b\xc7\xe6\xd1\xb7\x54\xf7\x81\x63\x53\x1e\x2e\xce\xe8\x30\xcb\xfa\x56\x5a\x80\xe7\xc1\x8f\xff\xa4\x5b\xf6\xf4\xd9\x7b\xe9\xe3\x71\x7d\xaf\xec\x00\x5e\xa2\x21\x47\xd2\x11\x7c\xf9\xd6\x59\x4e\x1f\x77\xd8\x90\x83\xe1\x2e\x3f\xa9\xe1\x6c\x7a\x1c\xfe\x21\x91\xa2\x89\xde\x0d\x32\x1c\x0e\x73\x7f\xd5\x87\x50\x49\x60\xbf\x73\x70\xb7\xbe\x17\xab\x94\xf2\xf0\x3a\xc6\x39\xb4\x99\x4c\x35\x04\x28\x97\x3a\xc1\x5f\xca\x99\x5f\xce\x4d\x2f\x01\x5f\x84\xc0\x5b\x8a\x94\x73\xa5\xb2\xa2\x29\xb4\x35\x4b\x5f\xc8\xa6\x25\x80\x84\xd6\x3a\x58\xb6\x84\xc1\xda\xbf\x33\x29\xfb\x96\x86\x2d\x7c\x7d\x7a\xaa\x1e\xe7\x4d\xa2\xe6\xe0\xd3\xf5\xf5\xc7\x49\x99\x23\x1b\x63\xb8\xa5\x80\xbb\x47\xf2\x12\xeb\xec\xe0\xc3\xc4\x90\x48\x59\xea\x51\xba\x7a\x3f\x6a\x4a\x58\xf3\x7f\x94\xf9\x5f\x00\x00\x00\xff\xff\x03\x2e\x8f\x6e\x07\x04\x00\x00")
+var _klusterletOperatorYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x54\x4d\x6f\xd3\x40\x10\xbd\xe7\x57\x8c\x42\xa5\x5e\x88\x43\xa1\x07\x64\x89\x03\x6a\x11\x54\x05\xc7\x6a\xaa\x0a\x4e\x68\xbb\x9e\xd8\x4b\x36\x3b\xab\xdd\x49\x20\x58\xf9\xef\x68\xe3\xef\x26\x2d\xe5\x86\xe2\x83\x33\xef\xcd\x9b\xb7\x6f\x6c\xbf\x80\x0b\xb2\x5b\xa7\xf2\x82\xe1\x82\x0c\x3b\x75\xbf\x66\x72\x1e\x98\x80\x0b\x84\x99\x45\x03\x17\x7a\xed\x19\x1d\x7c\x11\x46\xe4\xb8\x42\xc3\x60\x1d\xfd\x40\xc9\xa3\xd1\x52\x99\x2c\x86\x4b\xb4\x9a\xb6\x01\x19\x09\xab\xee\xd0\x79\x45\x26\x06\x61\xad\x9f\x6e\xce\x46\x2b\x64\x91\x09\x16\xf1\x08\xc0\x88\x15\xc6\xb0\xac\x24\x35\x72\x5d\xf2\x56\x48\x8c\x61\x5c\x96\x10\x5d\xb7\x60\xd2\x20\xb0\xdb\x8d\x47\x00\x5a\xdc\xa3\xf6\x41\x06\x82\xf8\x40\xc7\x5b\x94\x01\x71\x68\xb5\x92\xc2\xc7\x70\x36\x02\xf0\xa8\x51\x32\xb9\x80\x00\xac\x04\xcb\xe2\x73\x4f\xe4\x50\x06\x80\x71\x65\xb5\x60\xac\x5b\x7a\xde\xc3\x7f\x61\x0c\xb1\x60\x45\xa6\x95\x00\x60\xe1\x72\xe4\xe8\x27\xb9\xa5\x26\x91\x45\x64\xd1\xf8\x42\x2d\x38\x52\x34\x5d\xb5\xb1\xc5\x70\x5a\x8e\x71\xb1\x40\xc9\xe3\x18\xc6\xa9\xc3\x05\x3a\x87\xd9\xe5\xda\x29\x93\xcf\x65\x81\xd9\x5a\x2b\x93\x8f\x77\xa7\xb5\x74\xff\xc0\xc7\xdd\x02\x34\x07\x0f\x3f\x8f\x6e\xa3\x24\xbe\x97\x92\xd6\x86\x93\xc3\xac\x03\xa9\x2c\x27\xa0\x16\x10\x25\x94\xe1\xbc\xce\x07\x76\xbb\x1a\x35\xbd\x6a\x23\x1b\x3a\x9c\x30\x39\xc2\xc9\x12\xb7\x2f\xe1\x64\x23\xf4\x1a\x21\x7e\xf7\x98\x08\xec\x37\x19\xc8\x61\x73\xd5\x5e\xeb\xa6\x6a\x93\x8d\x2a\x9a\xac\xeb\x3a\x5a\x08\x4e\x6f\x49\xa3\xab\x42\xef\x40\xee\x8a\x47\x7c\x76\xe8\xde\xe6\x51\x85\x09\x2c\x71\x5b\x9b\xeb\xf8\xd1\x75\xe5\xba\x26\x01\x90\x0d\x73\xc8\x1d\x32\x67\x35\x32\xa0\xef\x8f\x79\xc8\xbd\x7b\x70\x7a\x80\xea\x49\x38\x64\x7e\xd8\xd7\x07\xd4\x3a\x88\x3e\xab\x3b\xd1\x1c\x25\x99\xac\x77\xae\x7e\x36\x35\x18\x43\x59\x3e\xbf\xfd\x79\x9b\x19\x14\x24\x19\x16\xca\xa0\x6b\x97\x31\x39\xf6\xae\x57\x3f\xb5\x12\x39\xee\x2d\x45\x37\x98\x2b\xcf\x95\xa9\x26\xce\xab\x00\x77\xd2\x35\x3f\x5d\x6b\x9d\x92\x56\x72\x1b\xc3\xd5\x22\x21\x4e\x1d\xfa\xf0\xc9\x69\x58\xc2\xe5\xed\xf0\x70\x4d\x60\x3c\x75\x3d\xf9\x49\xb3\xc8\x2e\xd7\x3d\xa9\x33\x78\x10\x38\x39\x88\x3e\xdd\xde\xa6\xa9\xa3\x5f\xdb\xea\x76\x5e\xdf\x27\x54\xdd\xf4\x6c\xa2\xd9\xc4\x0f\x15\x7a\xed\x3d\x66\x93\x4d\x00\xbf\xa7\x37\xb3\xaf\xdf\x5a\x68\xf0\x00\x0d\xba\x87\xee\x06\xe9\x0f\xc7\xcd\x9f\x9c\x37\xff\xeb\xc0\xf9\x3f\x4d\x4c\xe8\xd1\x71\xc9\xec\xc9\x59\x09\x3d\x7b\xd0\x83\x92\x56\x1b\x34\xe8\x7d\xea\xe8\xbe\xfe\x50\x57\x57\xc1\x6c\x3f\x22\xf7\x4b\x00\x56\x70\x11\xc3\xb4\x40\xa1\xb9\xf8\x3d\x80\xbc\x2c\xb0\x0d\x66\xd8\x44\x8e\x63\x78\x7b\x7e\xfe\xa6\x57\x56\x46\xb1\x12\xfa\x12\xb5\xd8\xb6\x6f\xd6\xeb\x1e\xc1\xa2\x53\x94\xb5\xd0\xd9\xab\x16\x73\x28\x32\xf5\x1f\x79\xfe\x33\x00\x1c\x37\xb9\x82\xfb\x07\x00\x00")
 
 func klusterletOperatorYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -350,7 +350,7 @@ func klusterletOperatorYaml() (*asset, error) {
 	return a, nil
 }
 
-var _klusterletService_accountYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\xcd\xcf\x4b\xc3\x40\x10\xc5\xf1\xfb\xfc\x15\x8f\x7a\x6e\xc1\xeb\xde\x24\x27\x11\x7f\x40\xd1\xfb\xb8\x79\xa6\x6b\x93\xd9\xb0\x3b\x29\x48\xd8\xff\x5d\xd4\x5a\xd0\xeb\xfb\x32\x9f\xb9\x42\x97\xe7\x8f\x92\x86\x83\xa3\xcb\xe6\x25\xbd\x2e\x9e\x4b\x85\x67\xf8\x81\x78\x9c\x69\xe8\xc6\xa5\x3a\x0b\xee\xd5\x74\xe0\x44\x73\xcc\x25\xbf\x33\xba\x88\xce\xe9\x85\xa5\xa6\x6c\x01\xa7\x6b\x39\x26\xeb\x03\xf6\x2c\xa7\x14\x79\x13\x63\x5e\xcc\x65\xa2\x6b\xaf\xae\x41\x00\xd3\x89\x01\xc7\x1f\x71\xa4\x9f\xa7\x3a\x6b\x64\xc0\x66\x5d\xb1\xbb\xbb\xc4\x87\xdf\x82\xd6\x36\xb2\xae\x5b\xa4\x37\xec\x9e\x2b\x6f\x27\x1d\xf8\xb4\x8c\xe3\x9e\xb1\xd0\xd1\x9a\xa4\xbf\x53\x0d\xb2\x3d\x3f\xfb\x46\xff\x5d\x7c\xc9\x17\x94\xd6\xb7\x26\x9f\x01\x00\x00\xff\xff\x41\xa1\xbe\x98\x0b\x01\x00\x00")
+var _klusterletService_accountYaml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x5c\xcd\xcf\x4b\xc3\x40\x10\xc5\xf1\xfb\xfc\x15\x8f\x7a\x6e\xc1\xeb\xde\x24\x27\x11\x7f\x40\xd1\xfb\xb8\x79\xa6\x6b\x93\xd9\xb0\x3b\x29\x48\xd8\xff\x5d\xd4\x5a\xd0\xeb\xfb\x32\x9f\xb9\x42\x97\xe7\x8f\x92\x86\x83\xa3\xcb\xe6\x25\xbd\x2e\x9e\x4b\x85\x67\xf8\x81\x78\x9c\x69\xe8\xc6\xa5\x3a\x0b\xee\xd5\x74\xe0\x44\x73\xcc\x25\xbf\x33\xba\x88\xce\xe9\x85\xa5\xa6\x6c\x01\xa7\x6b\x39\x26\xeb\x03\xf6\x2c\xa7\x14\x79\x13\x63\x5e\xcc\x65\xa2\x6b\xaf\xae\x41\x00\xd3\x89\x01\xc7\x1f\x71\xa4\x9f\xa7\x3a\x6b\x64\xc0\x66\x5d\xb1\xbb\xbb\xc4\x87\xdf\x82\xd6\x36\xb2\xae\x5b\xa4\x37\xec\x9e\x2b\x6f\x27\x1d\xf8\xb4\x8c\xe3\x9e\xb1\xd0\xd1\x9a\xa4\xbf\x53\x0d\xb2\x3d\x3f\xfb\x46\xff\x5d\x7c\xc9\x17\x94\xd6\xb7\x26\x9f\x03\x00\x41\xa1\xbe\x98\x0b\x01\x00\x00")
 
 func klusterletService_accountYamlBytes() ([]byte, error) {
 	return bindataRead(
@@ -442,11 +442,13 @@ var _bindata = map[string]func() (*asset, error){
 // directory embedded in the file by go-bindata.
 // For example if you run go-bindata on data/... and data contains the
 // following hierarchy:
-//     data/
-//       foo.txt
-//       img/
-//         a.png
-//         b.png
+//
+//	data/
+//	  foo.txt
+//	  img/
+//	    a.png
+//	    b.png
+//
 // then AssetDir("data") would return []string{"foo.txt", "img"}
 // AssetDir("data/img") would return []string{"a.png", "b.png"}
 // AssetDir("foo.txt") and AssetDir("notexist") would return an error
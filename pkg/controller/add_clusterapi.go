@@ -0,0 +1,21 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package controller
+
+import (
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	"github.com/open-cluster-management/managedcluster-import-controller/pkg/controller/managedcluster"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func init() {
+	// AddToManagerFuncs is a list of functions and manadatory GVs to create controllers and add them to a manager.
+	// The Cluster API controller is added only if the cluster.x-k8s.io/v1beta1 API is served.
+	AddToManagerFuncs = append(AddToManagerFuncs, addToManager{
+		function: managedcluster.AddClusterAPI,
+		MandatoryGroupVersions: []schema.GroupVersion{
+			clusterv1.SchemeGroupVersion,
+			managedcluster.ClusterAPIGroupVersion,
+		},
+	})
+}
@@ -5,13 +5,27 @@ package controller
 
 import (
 	"github.com/open-cluster-management/managedcluster-import-controller/pkg/controller/csr"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
 func init() {
 	// AddToManagerFuncs is a list of functions and manadatory GVs to create controllers and add them to a manager.
+	// The certificates.k8s.io/v1 CSR controller is used if the API is served,
+	// the certificates.k8s.io/v1beta1 CSR controller otherwise.
 	AddToManagerFuncs = append(AddToManagerFuncs, addToManager{
-		function:               csr.Add,
-		MandatoryGroupVersions: []schema.GroupVersion{},
+		function: csr.AddV1,
+		MandatoryGroupVersions: []schema.GroupVersion{
+			certificatesv1.SchemeGroupVersion,
+		},
+	}, addToManager{
+		function: csr.Add,
+		MandatoryGroupVersions: []schema.GroupVersion{
+			certificatesv1beta1.SchemeGroupVersion,
+		},
+		ExcludedGroupVersions: []schema.GroupVersion{
+			certificatesv1.SchemeGroupVersion,
+		},
 	})
 }
@@ -14,17 +14,22 @@ import (
 	"github.com/open-cluster-management/managedcluster-import-controller/pkg/controller/managedcluster"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	ocinfrav1 "github.com/openshift/api/config/v1"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 )
 
 func init() {
 	// AddToManagerFuncs is a list of functions and manadatory GVs to create controllers and add them to a manager.
 	AddToManagerFuncs = append(AddToManagerFuncs, addToManager{
-		function: managedcluster.Add,
+		functionWithMissingGVS: managedcluster.Add,
 		MandatoryGroupVersions: []schema.GroupVersion{
 			clusterv1.SchemeGroupVersion,
 			workv1.SchemeGroupVersion,
 			hivev1.SchemeGroupVersion,
 		},
+		// The OpenShift hub info provider is used if the config.openshift.io/v1 API is served
+		OptionalGroupVersions: []schema.GroupVersion{
+			ocinfrav1.GroupVersion,
+		},
 	})
 }
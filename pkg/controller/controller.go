@@ -25,6 +25,13 @@ var log = logf.Log.WithName("controller")
 type addToManager struct {
 	function               func(manager.Manager) error
 	MandatoryGroupVersions []schema.GroupVersion
+	//ExcludedGroupVersions are the GVs which must be missing to add the controller,
+	//they allow to fallback on a controller of a previous version of an API
+	ExcludedGroupVersions []schema.GroupVersion
+	//OptionalGroupVersions are the GVs used by the controller only if they are served,
+	//the missing GVs are passed to functionWithMissingGVS which is used instead of function
+	OptionalGroupVersions  []schema.GroupVersion
+	functionWithMissingGVS func(manager.Manager, []schema.GroupVersion) error
 }
 
 // AddToManagerFuncs is a list of functions to add all Controllers to the Manager and the mandatory GVs
@@ -33,8 +40,14 @@ var AddToManagerFuncs []addToManager
 // AddToManager adds all Controllers which have all their mandatory GVs installed to the Manager
 func AddToManager(m manager.Manager, missingGVS []schema.GroupVersion) error {
 	for _, a := range AddToManagerFuncs {
-		if mandatoryGVSatisfied(a, missingGVS) {
+		if mandatoryGVSatisfied(a, missingGVS) && excludedGVSatisfied(a, missingGVS) {
 			log.Info(fmt.Sprintf("Add to manager %s:", a.MandatoryGroupVersions))
+			if a.functionWithMissingGVS != nil {
+				if err := a.functionWithMissingGVS(m, missingGVS); err != nil {
+					return err
+				}
+				continue
+			}
 			if err := a.function(m); err != nil {
 				return err
 			}
@@ -61,6 +74,24 @@ func mandatoryGVSatisfied(a addToManager, missingGVS []schema.GroupVersion) bool
 	return true
 }
 
+//excludedGVSatisfied Check if the excluded GVs for a controller are all missing.
+func excludedGVSatisfied(a addToManager, missingGVS []schema.GroupVersion) bool {
+	for _, excludedGV := range a.ExcludedGroupVersions {
+		missing := false
+		for _, missingGV := range missingGVS {
+			if reflect.DeepEqual(excludedGV, missingGV) {
+				missing = true
+				break
+			}
+		}
+		if !missing {
+			return false
+		}
+	}
+
+	return true
+}
+
 //GetMissingGVS gets the missing GVs
 func GetMissingGVS(cfg *rest.Config) (missingGVS []schema.GroupVersion, err error) {
 	log.Info("Get missing GVS")
@@ -71,8 +102,15 @@ func GetMissingGVS(cfg *rest.Config) (missingGVS []schema.GroupVersion, err erro
 		return missingGVS, err
 	}
 
+	checked := map[schema.GroupVersion]bool{}
 	for _, atmf := range AddToManagerFuncs {
-		for _, gv := range atmf.MandatoryGroupVersions {
+		gvs := append(append([]schema.GroupVersion{}, atmf.MandatoryGroupVersions...), atmf.ExcludedGroupVersions...)
+		gvs = append(gvs, atmf.OptionalGroupVersions...)
+		for _, gv := range gvs {
+			if checked[gv] {
+				continue
+			}
+			checked[gv] = true
 			err := discovery.ServerSupportsVersion(c, gv)
 			if err != nil {
 				log.Info(fmt.Sprintf("%s-%s is missing", gv.Group, gv.Version))
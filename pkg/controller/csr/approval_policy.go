@@ -0,0 +1,309 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package csr
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// the environment variables overriding the default approval policy
+	csrAllowedKeyAlgorithmsEnvVarName = "CSR_ALLOWED_KEY_ALGORITHMS"
+	csrMinRSAKeySizeEnvVarName        = "CSR_MIN_RSA_KEY_SIZE"
+	csrMinECDSAKeySizeEnvVarName      = "CSR_MIN_ECDSA_KEY_SIZE"
+	csrAllowedSignerNamesEnvVarName   = "CSR_ALLOWED_SIGNER_NAMES"
+	csrAllowedUsagesEnvVarName        = "CSR_ALLOWED_USAGES"
+
+	// the subject of the client certificate of the registration agent of a managed cluster:
+	// CN=system:open-cluster-management:<cluster>:<agent>, O=system:open-cluster-management:<cluster>
+	clusterGroupPrefix   = "system:open-cluster-management:"
+	managedClustersGroup = "system:open-cluster-management:managed-clusters"
+
+	// csrAuditAnnotation records the last decision on a CSR of the managed cluster
+	csrAuditAnnotation = "import.open-cluster-management.io/csr-audit"
+)
+
+// approvalPolicy defines the CSRs of the managed clusters which are approved, the other CSRs are denied
+type approvalPolicy struct {
+	keyAlgorithms   sets.String
+	minRSAKeySize   int
+	minECDSAKeySize int
+	signerNames     sets.String
+	usages          sets.String
+}
+
+// policyViolation is the reason a CSR is denied
+type policyViolation struct {
+	reason  string
+	message string
+}
+
+func (v *policyViolation) Error() string {
+	return v.message
+}
+
+func newPolicyViolation(reason, format string, args ...interface{}) *policyViolation {
+	return &policyViolation{reason: reason, message: fmt.Sprintf(format, args...)}
+}
+
+// getApprovalPolicy returns the default approval policy overridden by the environment variables
+func getApprovalPolicy() (*approvalPolicy, error) {
+	policy := &approvalPolicy{
+		keyAlgorithms:   sets.NewString(x509.RSA.String(), x509.ECDSA.String()),
+		minRSAKeySize:   2048,
+		minECDSAKeySize: 256,
+		signerNames:     sets.NewString(certificatesv1beta1.KubeAPIServerClientSignerName),
+		usages: sets.NewString(
+			string(certificatesv1beta1.UsageDigitalSignature),
+			string(certificatesv1beta1.UsageKeyEncipherment),
+			string(certificatesv1beta1.UsageClientAuth),
+		),
+	}
+	if value := os.Getenv(csrAllowedKeyAlgorithmsEnvVarName); value != "" {
+		policy.keyAlgorithms = sets.NewString(splitList(value)...)
+	}
+	if value := os.Getenv(csrAllowedSignerNamesEnvVarName); value != "" {
+		policy.signerNames = sets.NewString(splitList(value)...)
+	}
+	if value := os.Getenv(csrAllowedUsagesEnvVarName); value != "" {
+		policy.usages = sets.NewString(splitList(value)...)
+	}
+	var err error
+	if value := os.Getenv(csrMinRSAKeySizeEnvVarName); value != "" {
+		if policy.minRSAKeySize, err = strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", csrMinRSAKeySizeEnvVarName, err)
+		}
+	}
+	if value := os.Getenv(csrMinECDSAKeySizeEnvVarName); value != "" {
+		if policy.minECDSAKeySize, err = strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", csrMinECDSAKeySizeEnvVarName, err)
+		}
+	}
+	return policy, nil
+}
+
+// splitList splits a comma separated list
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// validate checks the request, the signer and the usages of a CSR of the cluster clusterName,
+// a policyViolation is returned if the CSR must be denied
+func (p *approvalPolicy) validate(request []byte, signerName *string, usages []string, clusterName string) error {
+	block, _ := pem.Decode(request)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return newPolicyViolation("InvalidRequest", "The request is not a PEM encoded certificate request")
+	}
+	x509CSR, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return newPolicyViolation("InvalidRequest", "Failed to parse the certificate request: %v", err)
+	}
+	if err := x509CSR.CheckSignature(); err != nil {
+		return newPolicyViolation("InvalidRequest", "Invalid signature of the certificate request: %v", err)
+	}
+
+	clusterGroup := clusterGroupPrefix + clusterName
+	if !strings.HasPrefix(x509CSR.Subject.CommonName, clusterGroup+":") {
+		return newPolicyViolation("InvalidSubject", "The common name %q must start with %q",
+			x509CSR.Subject.CommonName, clusterGroup+":")
+	}
+	organizations := sets.NewString(x509CSR.Subject.Organization...)
+	if !organizations.Has(clusterGroup) {
+		return newPolicyViolation("InvalidSubject", "The organizations %v must contain %q",
+			x509CSR.Subject.Organization, clusterGroup)
+	}
+	if extra := organizations.Difference(sets.NewString(clusterGroup, managedClustersGroup)); extra.Len() > 0 {
+		return newPolicyViolation("InvalidSubject", "The organizations %v are not allowed", extra.List())
+	}
+
+	if err := p.validateKey(x509CSR); err != nil {
+		return err
+	}
+
+	// the signer name is not set by the clusters which don't support it
+	if signerName != nil && !p.signerNames.Has(*signerName) {
+		return newPolicyViolation("InvalidSignerName", "The signer %q is not allowed", *signerName)
+	}
+
+	if len(usages) == 0 {
+		return newPolicyViolation("InvalidUsages", "The usages are not set")
+	}
+	if extra := sets.NewString(usages...).Difference(p.usages); extra.Len() > 0 {
+		return newPolicyViolation("InvalidUsages", "The usages %v are not allowed", extra.List())
+	}
+	return nil
+}
+
+func (p *approvalPolicy) validateKey(x509CSR *x509.CertificateRequest) error {
+	algorithm := x509CSR.PublicKeyAlgorithm.String()
+	if !p.keyAlgorithms.Has(algorithm) {
+		return newPolicyViolation("InvalidKey", "The key algorithm %s is not allowed", algorithm)
+	}
+	switch key := x509CSR.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if size := key.N.BitLen(); size < p.minRSAKeySize {
+			return newPolicyViolation("InvalidKey", "The RSA key size %d is lower than %d", size, p.minRSAKeySize)
+		}
+	case *ecdsa.PublicKey:
+		if size := key.Curve.Params().BitSize; size < p.minECDSAKeySize {
+			return newPolicyViolation("InvalidKey", "The ECDSA key size %d is lower than %d", size, p.minECDSAKeySize)
+		}
+	}
+	return nil
+}
+
+// csrDecision is the decision of the controller on a CSR of a managed cluster
+type csrDecision struct {
+	approved bool
+	reason   string
+	message  string
+	time     metav1.Time
+}
+
+// decide returns the decision on the CSR of the managedCluster requested by username according to
+// the approval policy, the renewals requested by the registration agent are also checked against
+// the managedCluster
+func decide(
+	managedCluster *clusterv1.ManagedCluster,
+	username string,
+	groups []string,
+	request []byte,
+	signerName *string,
+	usages []string,
+) (*csrDecision, error) {
+	policy, err := getApprovalPolicy()
+	if err != nil {
+		return nil, err
+	}
+	renewal := isRenewalRequester(username, managedCluster.Name)
+	err = policy.validate(request, signerName, usages, managedCluster.Name)
+	if err == nil && renewal {
+		err = validateRenewal(managedCluster, username, groups, request)
+	}
+	if err != nil {
+		violation, ok := err.(*policyViolation)
+		if !ok {
+			return nil, err
+		}
+		return &csrDecision{reason: violation.reason, message: violation.message, time: metav1.Now()}, nil
+	}
+	if renewal {
+		return &csrDecision{
+			approved: true,
+			reason:   "AutoApprovedRenewalByCSRController",
+			message:  "The managedcluster-import-controller auto approval automatically approved this renewal CSR",
+			time:     metav1.Now(),
+		}, nil
+	}
+	return &csrDecision{
+		approved: true,
+		reason:   "AutoApprovedByCSRController",
+		message:  "The managedcluster-import-controller auto approval automatically approved this CSR",
+		time:     metav1.Now(),
+	}, nil
+}
+
+// recordDecision records the decision on the CSR csr of the managedCluster with events, metrics and
+// the audit annotation of the managedCluster
+func recordDecision(
+	c client.Client,
+	recorder record.EventRecorder,
+	csr runtime.Object,
+	csrName string,
+	managedCluster *clusterv1.ManagedCluster,
+	decision *csrDecision,
+) {
+	if decision.approved {
+		csrDecisions.WithLabelValues("approved").Inc()
+		recorder.Eventf(csr, corev1.EventTypeNormal, "CSRApproved",
+			"The CSR of the managed cluster %s is approved", managedCluster.Name)
+		recorder.Eventf(managedCluster, corev1.EventTypeNormal, "CSRApproved",
+			"The CSR %s of the managed cluster is approved", csrName)
+	} else {
+		csrDecisions.WithLabelValues("denied").Inc()
+		recorder.Eventf(csr, corev1.EventTypeWarning, "CSRDenied",
+			"The CSR of the managed cluster %s is denied: %s", managedCluster.Name, decision.message)
+		recorder.Eventf(managedCluster, corev1.EventTypeWarning, "CSRDenied",
+			"The CSR %s of the managed cluster is denied: %s", csrName, decision.message)
+	}
+
+	// the CSR is not reconciled again once approved or denied, the audit annotation is not retried
+	if err := setCSRAuditAnnotation(c, managedCluster, csrName, decision); err != nil {
+		log.Error(err, "Failed to record the CSR decision on the managed cluster", "cluster", managedCluster.Name)
+	}
+	if err := clearPendingCondition(c, managedCluster, csrName, decision); err != nil {
+		log.Error(err, "Failed to clear the CSR pending condition of the managed cluster", "cluster", managedCluster.Name)
+	}
+}
+
+// recordApprovalFailure records the failure to update the approval of the CSR csr of the managedCluster
+func recordApprovalFailure(recorder record.EventRecorder, csr runtime.Object, managedCluster *clusterv1.ManagedCluster, err error) {
+	csrDecisions.WithLabelValues("failed").Inc()
+	recorder.Eventf(csr, corev1.EventTypeWarning, "CSRApprovalFailed",
+		"Failed to approve the CSR of the managed cluster %s: %v", managedCluster.Name, err)
+}
+
+// csrAudit is the last decision on a CSR of a managed cluster recorded in the csrAuditAnnotation
+type csrAudit struct {
+	CSR      string `json:"csr"`
+	Decision string `json:"decision"`
+	Reason   string `json:"reason"`
+	Message  string `json:"message,omitempty"`
+	Time     string `json:"time"`
+}
+
+// setCSRAuditAnnotation records the decision on the CSR csrName in the csrAuditAnnotation of the managedCluster
+func setCSRAuditAnnotation(
+	c client.Client,
+	managedCluster *clusterv1.ManagedCluster,
+	csrName string,
+	decision *csrDecision,
+) error {
+	audit := csrAudit{
+		CSR:      csrName,
+		Decision: "Approved",
+		Reason:   decision.reason,
+		Message:  decision.message,
+		Time:     decision.time.UTC().Format(time.RFC3339),
+	}
+	if !decision.approved {
+		audit.Decision = "Denied"
+	}
+	data, err := json.Marshal(audit)
+	if err != nil {
+		return err
+	}
+	patch := client.MergeFrom(managedCluster.DeepCopy())
+	annotations := managedCluster.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[csrAuditAnnotation] = string(data)
+	managedCluster.SetAnnotations(annotations)
+	return c.Patch(context.TODO(), managedCluster, patch)
+}
@@ -0,0 +1,172 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package csr
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"os"
+	"testing"
+
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+)
+
+func newECDSAKey(t *testing.T) crypto.Signer {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return key
+}
+
+func newCSRRequest(t *testing.T, commonName string, organizations []string, key crypto.Signer) []byte {
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   commonName,
+			Organization: organizations,
+		},
+	}, key)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: der})
+}
+
+func Test_approvalPolicy_validate(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	p224Key, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	commonName := "system:open-cluster-management:cluster1:agent1"
+	organizations := []string{"system:open-cluster-management:cluster1", managedClustersGroup}
+	validUsages := []string{"digital signature", "key encipherment", "client auth"}
+	kubeAPIServerClient := certificatesv1beta1.KubeAPIServerClientSignerName
+	kubeletServing := certificatesv1beta1.KubeletServingSignerName
+
+	tests := []struct {
+		name       string
+		request    []byte
+		signerName *string
+		usages     []string
+		env        map[string]string
+		wantReason string
+	}{
+		{
+			name:       "valid",
+			request:    newCSRRequest(t, commonName, organizations, newECDSAKey(t)),
+			signerName: &kubeAPIServerClient,
+			usages:     validUsages,
+		},
+		{
+			name:    "valid without signer",
+			request: newCSRRequest(t, commonName, organizations, newECDSAKey(t)),
+			usages:  validUsages,
+		},
+		{
+			name:       "invalid request",
+			request:    []byte("invalid"),
+			usages:     validUsages,
+			wantReason: "InvalidRequest",
+		},
+		{
+			name:       "common name of another cluster",
+			request:    newCSRRequest(t, "system:open-cluster-management:cluster2:agent1", organizations, newECDSAKey(t)),
+			usages:     validUsages,
+			wantReason: "InvalidSubject",
+		},
+		{
+			name:       "missing cluster organization",
+			request:    newCSRRequest(t, commonName, []string{managedClustersGroup}, newECDSAKey(t)),
+			usages:     validUsages,
+			wantReason: "InvalidSubject",
+		},
+		{
+			name:       "extra organization",
+			request:    newCSRRequest(t, commonName, append(organizations, "system:masters"), newECDSAKey(t)),
+			usages:     validUsages,
+			wantReason: "InvalidSubject",
+		},
+		{
+			name:       "RSA key too small",
+			request:    newCSRRequest(t, commonName, organizations, rsaKey),
+			usages:     validUsages,
+			wantReason: "InvalidKey",
+		},
+		{
+			name:    "RSA key size allowed",
+			request: newCSRRequest(t, commonName, organizations, rsaKey),
+			usages:  validUsages,
+			env:     map[string]string{csrMinRSAKeySizeEnvVarName: "1024"},
+		},
+		{
+			name:       "ECDSA key too small",
+			request:    newCSRRequest(t, commonName, organizations, p224Key),
+			usages:     validUsages,
+			wantReason: "InvalidKey",
+		},
+		{
+			name:       "key algorithm not allowed",
+			request:    newCSRRequest(t, commonName, organizations, newECDSAKey(t)),
+			usages:     validUsages,
+			env:        map[string]string{csrAllowedKeyAlgorithmsEnvVarName: "RSA"},
+			wantReason: "InvalidKey",
+		},
+		{
+			name:       "signer not allowed",
+			request:    newCSRRequest(t, commonName, organizations, newECDSAKey(t)),
+			signerName: &kubeletServing,
+			usages:     validUsages,
+			wantReason: "InvalidSignerName",
+		},
+		{
+			name:       "usage not allowed",
+			request:    newCSRRequest(t, commonName, organizations, newECDSAKey(t)),
+			usages:     append(validUsages, "server auth"),
+			wantReason: "InvalidUsages",
+		},
+		{
+			name:       "no usages",
+			request:    newCSRRequest(t, commonName, organizations, newECDSAKey(t)),
+			wantReason: "InvalidUsages",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				os.Setenv(name, value)
+				defer os.Unsetenv(name)
+			}
+			policy, err := getApprovalPolicy()
+			if err != nil {
+				t.Errorf("getApprovalPolicy() unexpected error %v", err)
+				return
+			}
+			err = policy.validate(tt.request, tt.signerName, tt.usages, "cluster1")
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Errorf("validate() unexpected error %v", err)
+				}
+				return
+			}
+			violation, ok := err.(*policyViolation)
+			if !ok {
+				t.Errorf("validate() error = %v, want a policy violation", err)
+				return
+			}
+			if violation.reason != tt.wantReason {
+				t.Errorf("validate() reason = %s, want %s: %s", violation.reason, tt.wantReason, violation.message)
+			}
+		})
+	}
+}
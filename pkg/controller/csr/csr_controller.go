@@ -14,6 +14,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -33,8 +34,8 @@ var log = logf.Log.WithName("controller_csr")
 * business logic.  Delete these comments after modifying this file.*
  */
 
-func getClusterName(csr *certificatesv1beta1.CertificateSigningRequest) (clusterName string) {
-	for label, v := range csr.GetObjectMeta().GetLabels() {
+func getClusterName(csr metav1.Object) (clusterName string) {
+	for label, v := range csr.GetLabels() {
 		if label == clusterLabel {
 			clusterName = v
 		}
@@ -55,14 +56,19 @@ func getApprovalType(csr *certificatesv1beta1.CertificateSigningRequest) string
 }
 
 func validUsername(csr *certificatesv1beta1.CertificateSigningRequest, clusterName string) bool {
-	return csr.Spec.Username == fmt.Sprintf(userNameSignature, clusterName, clusterName)
+	return validUsernameSignature(csr.Spec.Username, clusterName)
+}
+
+// validUsernameSignature returns true if username is the bootstrap service account of the cluster clusterName
+func validUsernameSignature(username, clusterName string) bool {
+	return username == fmt.Sprintf(userNameSignature, clusterName, clusterName)
 }
 
 func csrPredicate(csr *certificatesv1beta1.CertificateSigningRequest) bool {
 	clusterName := getClusterName(csr)
 	return clusterName != "" &&
 		getApprovalType(csr) == "" &&
-		validUsername(csr, clusterName)
+		validRequester(csr.Spec.Username, clusterName)
 }
 
 // blank assignment to verify that ReconcileCSR implements reconcile.Reconciler
@@ -74,7 +80,9 @@ type ReconcileCSR struct {
 	// that reads objects from the cache and writes to the apiserver
 	client     client.Client
 	kubeClient kubernetes.Interface
+	recorder   record.EventRecorder
 	scheme     *runtime.Scheme
+	limiter    *approvalRateLimiter
 }
 
 // Reconcile reads that state of the csr for a ReconcileCSR object and makes changes based on the state read
@@ -83,54 +91,151 @@ type ReconcileCSR struct {
 // The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
 func (r *ReconcileCSR) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	return reconcileCSR(r.client, r.recorder, r.limiter, &csrV1beta1Client{client: r.client, kubeClient: r.kubeClient},
+		request)
+}
+
+// csrRequest is the part of a CSR of a managed cluster used by the approval flow, whatever the version
+// of its certificates API
+type csrRequest struct {
+	// object is the CSR of the version of the certificates API, the object of the events
+	object      runtime.Object
+	name        string
+	clusterName string
+	deleting    bool
+	// pending is true if the CSR of the managed cluster is neither approved nor denied
+	pending    bool
+	username   string
+	groups     []string
+	request    []byte
+	signerName *string
+	usages     []string
+}
+
+// csrClient gets and approves the CSRs of a version of the certificates API
+type csrClient interface {
+	// get returns the CSR name, nil if it is not found
+	get(name types.NamespacedName) (*csrRequest, error)
+	// updateApproval approves or denies the CSR with the decision
+	updateApproval(csr *csrRequest, decision *csrDecision) error
+}
+
+// reconcileCSR approves or denies the CSR of a managed cluster of the request according to the approval
+// policy, the CSRs are got and approved with csrs
+func reconcileCSR(
+	c client.Client,
+	recorder record.EventRecorder,
+	limiter *approvalRateLimiter,
+	csrs csrClient,
+	request reconcile.Request,
+) (reconcile.Result, error) {
 	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
 	reqLogger.Info("Reconciling CSR")
 
-	// Fetch the CertificateSigningRequest instance
-	instance := &certificatesv1beta1.CertificateSigningRequest{}
-
-	if err := r.client.Get(context.TODO(), request.NamespacedName, instance); err != nil {
-		if errors.IsNotFound(err) {
-			reqLogger.Info("CSR ", instance.Name, " not found")
-			// Request object not found, could have been deleted after reconcile request.
-			// Owned objects are automatically garbage collected. For additional cleanup logic use finalizers.
-			// Return and don't requeue
-			return reconcile.Result{}, nil
-		}
+	instance, err := csrs.get(request.NamespacedName)
+	if err != nil {
 		// Error reading the object - requeue the request.
 		return reconcile.Result{}, err
 	}
+	if instance == nil {
+		reqLogger.Info("CSR not found")
+		return reconcile.Result{}, nil
+	}
 
-	if instance.DeletionTimestamp != nil {
-		reqLogger.Info("CSR ", instance.Name, " has deletiontimestamp set")
+	if instance.deleting {
+		reqLogger.Info("CSR has deletiontimestamp set")
 		return reconcile.Result{}, nil
 	}
 
-	clusterName := getClusterName(instance)
+	// the CSRs enqueued when their cluster is accepted are not filtered by the predicates of the watch
+	if !instance.pending {
+		return reconcile.Result{}, nil
+	}
 
 	cluster := clusterv1.ManagedCluster{}
-	err := r.client.Get(context.TODO(), types.NamespacedName{Name: clusterName}, &cluster)
-	if err != nil {
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: instance.clusterName}, &cluster); err != nil {
 		reqLogger.Info("Warning", "error", err.Error())
 		return reconcile.Result{}, nil
 	}
 
-	reqLogger.Info("Approving CSR", "name", instance.Name)
-	if instance.Status.Conditions == nil {
-		instance.Status.Conditions = make([]certificatesv1beta1.CertificateSigningRequestCondition, 0)
+	// the CSR is reconciled again when the cluster is accepted, the renewals are not pending but denied
+	// by the renewal validation when the cluster is no longer accepted
+	if !isRenewalRequester(instance.username, instance.clusterName) {
+		accepted, err := checkHubAcceptsClient(c, recorder, instance.object, instance.name, &cluster)
+		if err != nil || !accepted {
+			return reconcile.Result{}, err
+		}
 	}
 
-	instance.Status.Conditions = append(instance.Status.Conditions, certificatesv1beta1.CertificateSigningRequestCondition{
-		Type:           certificatesv1beta1.CertificateApproved,
-		Reason:         "AutoApprovedByCSRController",
-		Message:        "The managedcluster-import-controller auto approval automatically approved this CSR",
-		LastUpdateTime: metav1.Now(),
-	})
+	decision, err := decide(&cluster, instance.username, instance.groups,
+		instance.request, instance.signerName, instance.usages)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
 
-	signingRequest := r.kubeClient.CertificatesV1beta1().CertificateSigningRequests()
-	if _, err := signingRequest.UpdateApproval(context.TODO(), instance, metav1.UpdateOptions{}); err != nil {
+	if decision.approved {
+		if allowed, delay := limiter.allow(instance.clusterName); !allowed {
+			recordThrottled(recorder, instance.object, instance.name, &cluster, delay)
+			return reconcile.Result{RequeueAfter: delay}, nil
+		}
+	}
+
+	reqLogger.Info("Updating the CSR approval", "name", instance.name, "approved", decision.approved, "reason", decision.reason)
+	if err := csrs.updateApproval(instance, decision); err != nil {
+		recordApprovalFailure(recorder, instance.object, &cluster, err)
 		return reconcile.Result{}, err
 	}
+	recordDecision(c, recorder, instance.object, instance.name, &cluster, decision)
 
 	return reconcile.Result{}, nil
 }
+
+// csrV1beta1Client gets and approves the certificates.k8s.io/v1beta1 CSRs
+type csrV1beta1Client struct {
+	client     client.Client
+	kubeClient kubernetes.Interface
+}
+
+func (c *csrV1beta1Client) get(name types.NamespacedName) (*csrRequest, error) {
+	csr := &certificatesv1beta1.CertificateSigningRequest{}
+	if err := c.client.Get(context.TODO(), name, csr); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	usages := []string{}
+	for _, usage := range csr.Spec.Usages {
+		usages = append(usages, string(usage))
+	}
+	return &csrRequest{
+		object:      csr,
+		name:        csr.Name,
+		clusterName: getClusterName(csr),
+		deleting:    csr.DeletionTimestamp != nil,
+		pending:     csrPredicate(csr),
+		username:    csr.Spec.Username,
+		groups:      csr.Spec.Groups,
+		request:     csr.Spec.Request,
+		signerName:  csr.Spec.SignerName,
+		usages:      usages,
+	}, nil
+}
+
+func (c *csrV1beta1Client) updateApproval(csr *csrRequest, decision *csrDecision) error {
+	instance := csr.object.(*certificatesv1beta1.CertificateSigningRequest)
+	condition := certificatesv1beta1.CertificateSigningRequestCondition{
+		Type:           certificatesv1beta1.CertificateApproved,
+		Reason:         decision.reason,
+		Message:        decision.message,
+		LastUpdateTime: decision.time,
+	}
+	if !decision.approved {
+		condition.Type = certificatesv1beta1.CertificateDenied
+	}
+	instance.Status.Conditions = append(instance.Status.Conditions, condition)
+
+	signingRequest := c.kubeClient.CertificatesV1beta1().CertificateSigningRequests()
+	_, err := signingRequest.UpdateApproval(context.TODO(), instance, metav1.UpdateOptions{})
+	return err
+}
@@ -5,17 +5,20 @@ package csr
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"testing"
 
 	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
 	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	fakeclientset "k8s.io/client-go/kubernetes/fake"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -37,10 +40,28 @@ func TestReconcileCSR_Reconcile(t *testing.T) {
 		},
 		Spec: certificatesv1beta1.CertificateSigningRequestSpec{
 			Username: fmt.Sprintf(userNameSignature, clusterName, clusterName),
+			Request: newCSRRequest(t, "system:open-cluster-management:"+clusterName+":agent1",
+				[]string{"system:open-cluster-management:" + clusterName, managedClustersGroup}, newECDSAKey(t)),
+			Usages: []certificatesv1beta1.KeyUsage{
+				certificatesv1beta1.UsageDigitalSignature,
+				certificatesv1beta1.UsageKeyEncipherment,
+				certificatesv1beta1.UsageClientAuth,
+			},
 		},
 	}
+	deniedCSR := testCSR.DeepCopy()
+	deniedCSR.Spec.Request = newCSRRequest(t, "system:open-cluster-management:othercluster:agent1",
+		[]string{"system:open-cluster-management:othercluster"}, newECDSAKey(t))
 
 	testManagedCluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterName,
+		},
+		Spec: clusterv1.ManagedClusterSpec{
+			HubAcceptsClient: true,
+		},
+	}
+	notAcceptedManagedCluster := &clusterv1.ManagedCluster{
 		ObjectMeta: metav1.ObjectMeta{
 			Name: clusterName,
 		},
@@ -91,6 +112,42 @@ func TestReconcileCSR_Reconcile(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "testCSRDenied",
+			fields: fields{
+				client: fake.NewFakeClientWithScheme(testscheme,
+					testManagedCluster,
+					deniedCSR,
+				),
+				kubeClient: fakeclientset.NewSimpleClientset(deniedCSR),
+				scheme:     testscheme,
+			},
+			args: args{
+				request: req,
+			},
+			want: reconcile.Result{
+				Requeue: false,
+			},
+			wantErr: false,
+		},
+		{
+			name: "testCSRNotAccepted",
+			fields: fields{
+				client: fake.NewFakeClientWithScheme(testscheme,
+					notAcceptedManagedCluster,
+					testCSR,
+				),
+				kubeClient: fakeclientset.NewSimpleClientset(testCSR),
+				scheme:     testscheme,
+			},
+			args: args{
+				request: req,
+			},
+			want: reconcile.Result{
+				Requeue: false,
+			},
+			wantErr: false,
+		},
 		{
 			name: "testCSRClusterNotFound",
 			fields: fields{
@@ -112,9 +169,11 @@ func TestReconcileCSR_Reconcile(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Logf("Test name: %s", tt.name)
+			recorder := record.NewFakeRecorder(10)
 			r := &ReconcileCSR{
 				client:     tt.fields.client,
 				kubeClient: tt.fields.kubeClient,
+				recorder:   recorder,
 				scheme:     tt.fields.scheme,
 			}
 			got, err := r.Reconcile(tt.args.request)
@@ -135,6 +194,27 @@ func TestReconcileCSR_Reconcile(t *testing.T) {
 					if csr.Status.Conditions[0].Type != certificatesv1beta1.CertificateApproved {
 						t.Error("CSR not approved")
 					}
+					if len(recorder.Events) != 2 {
+						t.Errorf("expected 2 CSRApproved events, got %d", len(recorder.Events))
+					}
+					assertCSRAudit(t, r.client, string(certificatesv1beta1.CertificateApproved))
+				case "testCSRDenied":
+					if csr.Status.Conditions[0].Type != certificatesv1beta1.CertificateDenied ||
+						csr.Status.Conditions[0].Reason != "InvalidSubject" {
+						t.Errorf("CSR not denied, conditions %v", csr.Status.Conditions)
+					}
+					assertCSRAudit(t, r.client, string(certificatesv1beta1.CertificateDenied))
+				case "testCSRNotAccepted":
+					if len(csr.Status.Conditions) != 0 {
+						t.Error("CSR should not have been approved before the cluster is accepted")
+					}
+					cluster := &clusterv1.ManagedCluster{}
+					if err := r.client.Get(context.TODO(), types.NamespacedName{Name: clusterName}, cluster); err != nil {
+						t.Errorf("unexpected error %v", err)
+					}
+					if !meta.IsStatusConditionTrue(cluster.Status.Conditions, ManagedClusterCSRPending) {
+						t.Errorf("expected the CSR pending condition, got %v", cluster.Status.Conditions)
+					}
 				case "testCSRClusterNotFound":
 					if len(csr.Status.Conditions) != 0 {
 						t.Error("CSR should not have been approved")
@@ -423,3 +503,19 @@ func Test_csrPredicate(t *testing.T) {
 		})
 	}
 }
+
+func assertCSRAudit(t *testing.T, c client.Client, decision string) {
+	cluster := &clusterv1.ManagedCluster{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: clusterName}, cluster); err != nil {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+	audit := &csrAudit{}
+	if err := json.Unmarshal([]byte(cluster.Annotations[csrAuditAnnotation]), audit); err != nil {
+		t.Errorf("invalid audit annotation %v", err)
+		return
+	}
+	if audit.CSR != csrNameReconcile || audit.Decision != decision {
+		t.Errorf("audit = %v, want %s %s", audit, csrNameReconcile, decision)
+	}
+}
@@ -3,12 +3,16 @@
 package csr
 
 import (
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
 	libgoclient "github.com/open-cluster-management/library-go/pkg/client"
+	certificatesv1 "k8s.io/api/certificates/v1"
 	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
@@ -17,16 +21,29 @@ import (
 // Add creates a new ManagedCluster Controller and adds it to the Manager. The Manager will set fields on the Controller
 // and Start it when the Manager is Started.
 func Add(mgr manager.Manager) error {
-	return add(mgr, newReconciler(mgr))
+	limiter, err := newApprovalRateLimiter()
+	if err != nil {
+		return err
+	}
+	if err := metrics.Registry.Register(newThrottledClustersCollector(limiter)); err != nil {
+		return err
+	}
+	return add(mgr, newReconciler(mgr, limiter))
 }
 
 // newReconciler returns a new reconcile.Reconciler
-func newReconciler(mgr manager.Manager) reconcile.Reconciler {
+func newReconciler(mgr manager.Manager, limiter *approvalRateLimiter) reconcile.Reconciler {
 	kubeClient, err := libgoclient.NewDefaultKubeClient("")
 	if err != nil {
 		kubeClient = nil
 	}
-	return &ReconcileCSR{client: mgr.GetClient(), kubeClient: kubeClient, scheme: mgr.GetScheme()}
+	return &ReconcileCSR{
+		client:     mgr.GetClient(),
+		kubeClient: kubeClient,
+		recorder:   mgr.GetEventRecorderFor("managedcluster-import-controller"),
+		scheme:     mgr.GetScheme(),
+		limiter:    limiter,
+	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -59,5 +76,77 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
-	return nil
+	// Watch for the ManagedClusters accepted by the hub to approve their pending CSRs
+	return c.Watch(
+		&source.Kind{Type: &clusterv1.ManagedCluster{}},
+		&handler.EnqueueRequestsFromMapFunc{
+			ToRequests: newAcceptedClusterMapper(mgr.GetClient(), func() runtime.Object {
+				return &certificatesv1beta1.CertificateSigningRequestList{}
+			}),
+		},
+		hubAcceptsClientPredicate,
+	)
+}
+
+// AddV1 creates a new certificates.k8s.io/v1 CSR Controller and adds it to the Manager.
+func AddV1(mgr manager.Manager) error {
+	limiter, err := newApprovalRateLimiter()
+	if err != nil {
+		return err
+	}
+	if err := metrics.Registry.Register(newThrottledClustersCollector(limiter)); err != nil {
+		return err
+	}
+	return addV1(mgr, newReconcilerV1(mgr, limiter))
+}
+
+// newReconcilerV1 returns a new reconcile.Reconciler of the certificates.k8s.io/v1 CSRs
+func newReconcilerV1(mgr manager.Manager, limiter *approvalRateLimiter) reconcile.Reconciler {
+	kubeClient, err := libgoclient.NewDefaultKubeClient("")
+	if err != nil {
+		kubeClient = nil
+	}
+	return &ReconcileCSRV1{
+		client:     mgr.GetClient(),
+		kubeClient: kubeClient,
+		recorder:   mgr.GetEventRecorderFor("managedcluster-import-controller"),
+		scheme:     mgr.GetScheme(),
+		limiter:    limiter,
+	}
+}
+
+// addV1 adds a new certificates.k8s.io/v1 CSR Controller to mgr with r as the reconcile.Reconciler
+func addV1(mgr manager.Manager, r reconcile.Reconciler) error {
+	c, err := controller.New("csr-v1-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(
+		&source.Kind{Type: &certificatesv1.CertificateSigningRequest{}},
+		&handler.EnqueueRequestForObject{},
+		predicate.Funcs{
+			GenericFunc: func(e event.GenericEvent) bool { return false },
+			DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				return csrPredicateV1(e.ObjectNew.(*certificatesv1.CertificateSigningRequest))
+			},
+			CreateFunc: func(e event.CreateEvent) bool {
+				return csrPredicateV1(e.Object.(*certificatesv1.CertificateSigningRequest))
+			},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return c.Watch(
+		&source.Kind{Type: &clusterv1.ManagedCluster{}},
+		&handler.EnqueueRequestsFromMapFunc{
+			ToRequests: newAcceptedClusterMapper(mgr.GetClient(), func() runtime.Object {
+				return &certificatesv1.CertificateSigningRequestList{}
+			}),
+		},
+		hubAcceptsClientPredicate,
+	)
 }
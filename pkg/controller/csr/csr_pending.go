@@ -0,0 +1,107 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package csr
+
+import (
+	"context"
+	"fmt"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	"github.com/open-cluster-management/managedcluster-import-controller/pkg/helpers"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// ManagedClusterCSRPending is True while a CSR of the managed cluster waits for the hub to accept the cluster
+const ManagedClusterCSRPending = "ManagedClusterCSRPending"
+
+// checkHubAcceptsClient returns true if the managedCluster is accepted by the hub, otherwise the CSR csr
+// is reported pending with the ManagedClusterCSRPending condition and an event until the cluster is accepted
+func checkHubAcceptsClient(
+	c client.Client,
+	recorder record.EventRecorder,
+	csr runtime.Object,
+	csrName string,
+	managedCluster *clusterv1.ManagedCluster,
+) (bool, error) {
+	if managedCluster.Spec.HubAcceptsClient {
+		return true, nil
+	}
+	recorder.Eventf(csr, corev1.EventTypeNormal, "CSRPending",
+		"The CSR waits for the managed cluster %s to be accepted by the hub", managedCluster.Name)
+	recorder.Eventf(managedCluster, corev1.EventTypeNormal, "CSRPending",
+		"The CSR %s waits for the managed cluster to be accepted by the hub", csrName)
+	return false, helpers.SetManagedClusterCondition(c, managedCluster, metav1.Condition{
+		Type:    ManagedClusterCSRPending,
+		Status:  metav1.ConditionTrue,
+		Reason:  "HubAcceptsClientFalse",
+		Message: fmt.Sprintf("The CSR %s waits for hubAcceptsClient to be set to true", csrName),
+	})
+}
+
+// clearPendingCondition sets the ManagedClusterCSRPending condition of the managedCluster to False once
+// the CSR csrName is approved or denied, the condition is not added if the CSR was never pending
+func clearPendingCondition(c client.Client, managedCluster *clusterv1.ManagedCluster, csrName string, decision *csrDecision) error {
+	if meta.FindStatusCondition(managedCluster.Status.Conditions, ManagedClusterCSRPending) == nil {
+		return nil
+	}
+	reason, message := "CSRApproved", fmt.Sprintf("The CSR %s is approved", csrName)
+	if !decision.approved {
+		reason, message = "CSRDenied", fmt.Sprintf("The CSR %s is denied", csrName)
+	}
+	return helpers.SetManagedClusterCondition(c, managedCluster, metav1.Condition{
+		Type:    ManagedClusterCSRPending,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+}
+
+// hubAcceptsClientPredicate filters the ManagedCluster updates accepting the cluster
+var hubAcceptsClientPredicate = predicate.Funcs{
+	GenericFunc: func(e event.GenericEvent) bool { return false },
+	DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+	CreateFunc:  func(e event.CreateEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldCluster, okOld := e.ObjectOld.(*clusterv1.ManagedCluster)
+		newCluster, okNew := e.ObjectNew.(*clusterv1.ManagedCluster)
+		return okOld && okNew && !oldCluster.Spec.HubAcceptsClient && newCluster.Spec.HubAcceptsClient
+	},
+}
+
+// newAcceptedClusterMapper returns a mapper which enqueues the CSRs of an accepted ManagedCluster,
+// csrList is the list type of the CSRs of the API version of the controller
+func newAcceptedClusterMapper(c client.Client, csrList func() runtime.Object) handler.ToRequestsFunc {
+	return handler.ToRequestsFunc(func(obj handler.MapObject) []reconcile.Request {
+		list := csrList()
+		if err := c.List(context.TODO(), list, client.MatchingLabels{clusterLabel: obj.Meta.GetName()}); err != nil {
+			log.Error(err, "Failed to list the CSRs", "cluster", obj.Meta.GetName())
+			return nil
+		}
+		items, err := meta.ExtractList(list)
+		if err != nil {
+			log.Error(err, "Failed to extract the CSRs", "cluster", obj.Meta.GetName())
+			return nil
+		}
+		requests := []reconcile.Request{}
+		for _, item := range items {
+			accessor, err := meta.Accessor(item)
+			if err != nil {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: accessor.GetName()},
+			})
+		}
+		return requests
+	})
+}
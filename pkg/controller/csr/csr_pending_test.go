@@ -0,0 +1,159 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package csr
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	certificatesv1beta1 "k8s.io/api/certificates/v1beta1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+)
+
+func Test_checkHubAcceptsClient(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{})
+
+	csr := &certificatesv1beta1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{Name: csrNameReconcile},
+	}
+	approved := &csrDecision{approved: true, reason: "AutoApprovedByCSRController"}
+
+	managedCluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+	}
+	c := fake.NewFakeClientWithScheme(testscheme, managedCluster)
+	recorder := record.NewFakeRecorder(10)
+
+	accepted, err := checkHubAcceptsClient(c, recorder, csr, csr.Name, managedCluster)
+	if err != nil || accepted {
+		t.Errorf("checkHubAcceptsClient() = %v, %v, want false", accepted, err)
+	}
+	if len(recorder.Events) != 2 {
+		t.Errorf("expected 2 CSRPending events, got %d", len(recorder.Events))
+	}
+
+	managedCluster.Spec.HubAcceptsClient = true
+	accepted, err = checkHubAcceptsClient(c, recorder, csr, csr.Name, managedCluster)
+	if err != nil || !accepted {
+		t.Errorf("checkHubAcceptsClient() = %v, %v, want true", accepted, err)
+	}
+	if err := clearPendingCondition(c, managedCluster, csr.Name, approved); err != nil {
+		t.Errorf("clearPendingCondition() unexpected error %v", err)
+	}
+
+	cluster := &clusterv1.ManagedCluster{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: clusterName}, cluster); err != nil {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+	condition := meta.FindStatusCondition(cluster.Status.Conditions, ManagedClusterCSRPending)
+	if condition == nil || condition.Status != metav1.ConditionFalse || condition.Reason != "CSRApproved" {
+		t.Errorf("expected the CSR pending condition to be cleared, got %v", cluster.Status.Conditions)
+	}
+
+	// the condition is not added to the clusters which never had a pending CSR
+	other := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+	if err := clearPendingCondition(c, other, csr.Name, approved); err != nil || len(other.Status.Conditions) != 0 {
+		t.Errorf("clearPendingCondition() = %v, conditions %v", err, other.Status.Conditions)
+	}
+}
+
+func Test_hubAcceptsClientPredicate(t *testing.T) {
+	notAccepted := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: clusterName}}
+	accepted := notAccepted.DeepCopy()
+	accepted.Spec.HubAcceptsClient = true
+
+	tests := []struct {
+		name     string
+		old, new *clusterv1.ManagedCluster
+		want     bool
+	}{
+		{name: "accepted", old: notAccepted, new: accepted, want: true},
+		{name: "still accepted", old: accepted, new: accepted, want: false},
+		{name: "not accepted anymore", old: accepted, new: notAccepted, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hubAcceptsClientPredicate.Update(event.UpdateEvent{
+				MetaOld: tt.old, ObjectOld: tt.old, MetaNew: tt.new, ObjectNew: tt.new,
+			}); got != tt.want {
+				t.Errorf("hubAcceptsClientPredicate.Update() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newAcceptedClusterMapper(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(certificatesv1beta1.SchemeGroupVersion,
+		&certificatesv1beta1.CertificateSigningRequest{}, &certificatesv1beta1.CertificateSigningRequestList{})
+
+	newCSR := func(name, cluster string) *certificatesv1beta1.CertificateSigningRequest {
+		return &certificatesv1beta1.CertificateSigningRequest{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{clusterLabel: cluster}},
+		}
+	}
+	c := fake.NewFakeClientWithScheme(testscheme, newCSR("csr1", clusterName), newCSR("csr2", "other"))
+
+	managedCluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: clusterName}}
+	mapper := newAcceptedClusterMapper(c, func() runtime.Object {
+		return &certificatesv1beta1.CertificateSigningRequestList{}
+	})
+	requests := mapper(handler.MapObject{Meta: managedCluster, Object: managedCluster})
+	if len(requests) != 1 || requests[0].Name != "csr1" {
+		t.Errorf("expected the request of csr1, got %v", requests)
+	}
+}
+
+func Test_newAcceptedClusterMapper_foreignRequester(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{})
+
+	managedCluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+		Spec:       clusterv1.ManagedClusterSpec{HubAcceptsClient: true},
+	}
+	// the CSR has the label of the cluster but is requested by the bootstrap service account of another cluster
+	csr := newTestCSRV1(t, certificatesv1.KubeAPIServerClientSignerName)
+	csr.Spec.Username = fmt.Sprintf(userNameSignature, "othercluster", "othercluster")
+
+	r := &ReconcileCSRV1{
+		client:     fake.NewFakeClientWithScheme(testscheme, managedCluster.DeepCopy(), csr.DeepCopy()),
+		kubeClient: fakeclientset.NewSimpleClientset(csr.DeepCopy()),
+		recorder:   record.NewFakeRecorder(10),
+		scheme:     testscheme,
+	}
+	mapper := newAcceptedClusterMapper(r.client, func() runtime.Object {
+		return &certificatesv1.CertificateSigningRequestList{}
+	})
+	requests := mapper(handler.MapObject{Meta: managedCluster, Object: managedCluster})
+	if len(requests) != 1 {
+		t.Errorf("expected the request of %s, got %v", csr.Name, requests)
+		return
+	}
+	if _, err := r.Reconcile(requests[0]); err != nil {
+		t.Errorf("ReconcileCSRV1.Reconcile() unexpected error %v", err)
+		return
+	}
+	got, err := r.kubeClient.CertificatesV1().CertificateSigningRequests().Get(context.TODO(), csr.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Errorf("CSR not found: %v", err)
+		return
+	}
+	if len(got.Status.Conditions) != 0 {
+		t.Errorf("expected the CSR of a foreign requester to be ignored, got %v", got.Status.Conditions)
+	}
+}
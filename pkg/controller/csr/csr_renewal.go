@@ -0,0 +1,70 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package csr
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"strconv"
+	"strings"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// csrRenewalApprovalEnvVarName enables the approval of the CSRs renewing the client certificate of the
+// registration agents of the joined managed clusters
+const csrRenewalApprovalEnvVarName = "CSR_RENEWAL_APPROVAL"
+
+// renewalApprovalEnabled returns true if the renewal mode is enabled
+func renewalApprovalEnabled() bool {
+	enabled, err := strconv.ParseBool(os.Getenv(csrRenewalApprovalEnvVarName))
+	return err == nil && enabled
+}
+
+// isRenewalRequester returns true if username is the identity of a registration agent of the cluster
+// clusterName, system:open-cluster-management:<cluster>:<agent>
+func isRenewalRequester(username, clusterName string) bool {
+	prefix := clusterGroupPrefix + clusterName + ":"
+	return strings.HasPrefix(username, prefix) && len(username) > len(prefix)
+}
+
+// validRequester returns true if the CSR is requested by the bootstrap service account of the cluster
+// clusterName or, in renewal mode, by its registration agent
+func validRequester(username, clusterName string) bool {
+	if validUsernameSignature(username, clusterName) {
+		return true
+	}
+	return renewalApprovalEnabled() && isRenewalRequester(username, clusterName)
+}
+
+// validateRenewal checks that a renewal CSR is requested by the accepted identity of the joined
+// managedCluster, a policyViolation is returned if the CSR must be denied
+func validateRenewal(managedCluster *clusterv1.ManagedCluster, username string, groups []string, request []byte) error {
+	if !managedCluster.Spec.HubAcceptsClient {
+		return newPolicyViolation("ClusterNotAccepted", "The managed cluster %s is not accepted by the hub", managedCluster.Name)
+	}
+	if !meta.IsStatusConditionTrue(managedCluster.Status.Conditions, clusterv1.ManagedClusterConditionJoined) {
+		return newPolicyViolation("ClusterNotJoined", "The managed cluster %s has not joined the hub", managedCluster.Name)
+	}
+	clusterGroup := clusterGroupPrefix + managedCluster.Name
+	if !sets.NewString(groups...).Has(clusterGroup) {
+		return newPolicyViolation("InvalidRenewal", "The requester %s is not in the group %s", username, clusterGroup)
+	}
+	// the renewed certificate keeps the identity of the requester
+	block, _ := pem.Decode(request)
+	if block == nil {
+		return newPolicyViolation("InvalidRequest", "The request is not a PEM encoded certificate request")
+	}
+	x509CSR, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return newPolicyViolation("InvalidRequest", "Failed to parse the certificate request: %v", err)
+	}
+	if x509CSR.Subject.CommonName != username {
+		return newPolicyViolation("InvalidRenewal", "The common name %q is not the requester %s",
+			x509CSR.Subject.CommonName, username)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package csr
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_validRequester(t *testing.T) {
+	tests := []struct {
+		name     string
+		username string
+		renewal  string
+		want     bool
+	}{
+		{
+			name:     "bootstrap service account",
+			username: fmt.Sprintf(userNameSignature, clusterName, clusterName),
+			want:     true,
+		},
+		{
+			name:     "agent without renewal mode",
+			username: "system:open-cluster-management:" + clusterName + ":agent1",
+			want:     false,
+		},
+		{
+			name:     "agent with renewal mode",
+			username: "system:open-cluster-management:" + clusterName + ":agent1",
+			renewal:  "true",
+			want:     true,
+		},
+		{
+			name:     "agent of another cluster",
+			username: "system:open-cluster-management:othercluster:agent1",
+			renewal:  "true",
+			want:     false,
+		},
+		{
+			name:     "cluster group",
+			username: "system:open-cluster-management:" + clusterName + ":",
+			renewal:  "true",
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(csrRenewalApprovalEnvVarName, tt.renewal)
+			defer os.Unsetenv(csrRenewalApprovalEnvVarName)
+			if got := validRequester(tt.username, clusterName); got != tt.want {
+				t.Errorf("validRequester() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_decide_renewal(t *testing.T) {
+	agent := "system:open-cluster-management:" + clusterName + ":agent1"
+	clusterGroup := "system:open-cluster-management:" + clusterName
+	request := newCSRRequest(t, agent, []string{clusterGroup, managedClustersGroup}, newECDSAKey(t))
+	usages := []string{"digital signature", "key encipherment", "client auth"}
+
+	newManagedCluster := func(accepted bool, joined metav1.ConditionStatus) *clusterv1.ManagedCluster {
+		return &clusterv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: clusterName},
+			Spec:       clusterv1.ManagedClusterSpec{HubAcceptsClient: accepted},
+			Status: clusterv1.ManagedClusterStatus{
+				Conditions: []metav1.Condition{
+					{Type: clusterv1.ManagedClusterConditionJoined, Status: joined},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		managedCluster *clusterv1.ManagedCluster
+		username       string
+		groups         []string
+		wantApproved   bool
+		wantReason     string
+	}{
+		{
+			name:           "renewal approved",
+			managedCluster: newManagedCluster(true, metav1.ConditionTrue),
+			username:       agent,
+			groups:         []string{clusterGroup, managedClustersGroup},
+			wantApproved:   true,
+			wantReason:     "AutoApprovedRenewalByCSRController",
+		},
+		{
+			name:           "cluster not accepted",
+			managedCluster: newManagedCluster(false, metav1.ConditionTrue),
+			username:       agent,
+			groups:         []string{clusterGroup},
+			wantReason:     "ClusterNotAccepted",
+		},
+		{
+			name:           "cluster not joined",
+			managedCluster: newManagedCluster(true, metav1.ConditionFalse),
+			username:       agent,
+			groups:         []string{clusterGroup},
+			wantReason:     "ClusterNotJoined",
+		},
+		{
+			name:           "requester not in the cluster group",
+			managedCluster: newManagedCluster(true, metav1.ConditionTrue),
+			username:       agent,
+			groups:         []string{managedClustersGroup},
+			wantReason:     "InvalidRenewal",
+		},
+		{
+			name:           "identity changed",
+			managedCluster: newManagedCluster(true, metav1.ConditionTrue),
+			username:       "system:open-cluster-management:" + clusterName + ":agent2",
+			groups:         []string{clusterGroup},
+			wantReason:     "InvalidRenewal",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			decision, err := decide(tt.managedCluster, tt.username, tt.groups, request, nil, usages)
+			if err != nil {
+				t.Errorf("decide() unexpected error %v", err)
+				return
+			}
+			if decision.approved != tt.wantApproved || decision.reason != tt.wantReason {
+				t.Errorf("decide() = %v %s, want %v %s: %s",
+					decision.approved, decision.reason, tt.wantApproved, tt.wantReason, decision.message)
+			}
+		})
+	}
+}
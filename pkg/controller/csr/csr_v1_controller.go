@@ -0,0 +1,103 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package csr
+
+import (
+	"context"
+
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func getApprovalTypeV1(csr *certificatesv1.CertificateSigningRequest) string {
+	for _, c := range csr.Status.Conditions {
+		if c.Type == certificatesv1.CertificateApproved || c.Type == certificatesv1.CertificateDenied {
+			return string(c.Type)
+		}
+	}
+	return ""
+}
+
+func csrPredicateV1(csr *certificatesv1.CertificateSigningRequest) bool {
+	clusterName := getClusterName(csr)
+	return clusterName != "" &&
+		getApprovalTypeV1(csr) == "" &&
+		validRequester(csr.Spec.Username, clusterName)
+}
+
+// blank assignment to verify that ReconcileCSRV1 implements reconcile.Reconciler
+var _ reconcile.Reconciler = &ReconcileCSRV1{}
+
+// ReconcileCSRV1 reconciles the certificates.k8s.io/v1 CSRs of the managed clusters
+type ReconcileCSRV1 struct {
+	client     client.Client
+	kubeClient kubernetes.Interface
+	recorder   record.EventRecorder
+	scheme     *runtime.Scheme
+	limiter    *approvalRateLimiter
+}
+
+// Reconcile approves or denies a certificates.k8s.io/v1 CSR of a managed cluster according to the approval policy
+func (r *ReconcileCSRV1) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	return reconcileCSR(r.client, r.recorder, r.limiter, &csrV1Client{client: r.client, kubeClient: r.kubeClient},
+		request)
+}
+
+// csrV1Client gets and approves the certificates.k8s.io/v1 CSRs
+type csrV1Client struct {
+	client     client.Client
+	kubeClient kubernetes.Interface
+}
+
+func (c *csrV1Client) get(name types.NamespacedName) (*csrRequest, error) {
+	csr := &certificatesv1.CertificateSigningRequest{}
+	if err := c.client.Get(context.TODO(), name, csr); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	usages := []string{}
+	for _, usage := range csr.Spec.Usages {
+		usages = append(usages, string(usage))
+	}
+	return &csrRequest{
+		object:      csr,
+		name:        csr.Name,
+		clusterName: getClusterName(csr),
+		deleting:    csr.DeletionTimestamp != nil,
+		pending:     csrPredicateV1(csr),
+		username:    csr.Spec.Username,
+		groups:      csr.Spec.Groups,
+		request:     csr.Spec.Request,
+		signerName:  &csr.Spec.SignerName,
+		usages:      usages,
+	}, nil
+}
+
+func (c *csrV1Client) updateApproval(csr *csrRequest, decision *csrDecision) error {
+	instance := csr.object.(*certificatesv1.CertificateSigningRequest)
+	condition := certificatesv1.CertificateSigningRequestCondition{
+		Type:           certificatesv1.CertificateApproved,
+		Status:         corev1.ConditionTrue,
+		Reason:         decision.reason,
+		Message:        decision.message,
+		LastUpdateTime: decision.time,
+	}
+	if !decision.approved {
+		condition.Type = certificatesv1.CertificateDenied
+	}
+	instance.Status.Conditions = append(instance.Status.Conditions, condition)
+
+	signingRequest := c.kubeClient.CertificatesV1().CertificateSigningRequests()
+	_, err := signingRequest.UpdateApproval(context.TODO(), instance.Name, instance, metav1.UpdateOptions{})
+	return err
+}
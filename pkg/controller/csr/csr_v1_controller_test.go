@@ -0,0 +1,207 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package csr
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newTestCSRV1(t *testing.T, signerName string) *certificatesv1.CertificateSigningRequest {
+	return &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: csrNameReconcile,
+			Labels: map[string]string{
+				clusterLabel: clusterName,
+			},
+		},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Username: fmt.Sprintf(userNameSignature, clusterName, clusterName),
+			Request: newCSRRequest(t, "system:open-cluster-management:"+clusterName+":agent1",
+				[]string{"system:open-cluster-management:" + clusterName, managedClustersGroup}, newECDSAKey(t)),
+			SignerName: signerName,
+			Usages: []certificatesv1.KeyUsage{
+				certificatesv1.UsageDigitalSignature,
+				certificatesv1.UsageKeyEncipherment,
+				certificatesv1.UsageClientAuth,
+			},
+		},
+	}
+}
+
+func TestReconcileCSRV1_Reconcile(t *testing.T) {
+	testManagedCluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: clusterName,
+		},
+		Spec: clusterv1.ManagedClusterSpec{
+			HubAcceptsClient: true,
+		},
+	}
+
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{})
+
+	now := time.Now()
+	throttled := newTestRateLimiter(t, "1", "1", &now)
+	throttled.allow(clusterName)
+
+	revokedManagedCluster := testManagedCluster.DeepCopy()
+	revokedManagedCluster.Spec.HubAcceptsClient = false
+	revokedManagedCluster.Status.Conditions = []metav1.Condition{
+		{Type: clusterv1.ManagedClusterConditionJoined, Status: metav1.ConditionTrue},
+	}
+	renewalCSR := newTestCSRV1(t, certificatesv1.KubeAPIServerClientSignerName)
+	renewalCSR.Spec.Username = "system:open-cluster-management:" + clusterName + ":agent1"
+	renewalCSR.Spec.Groups = []string{"system:open-cluster-management:" + clusterName, managedClustersGroup}
+
+	tests := []struct {
+		name           string
+		csr            *certificatesv1.CertificateSigningRequest
+		withCluster    bool
+		cluster        *clusterv1.ManagedCluster
+		renewal        bool
+		limiter        *approvalRateLimiter
+		wantRequeue    bool
+		wantConditions int
+		wantType       certificatesv1.RequestConditionType
+		wantReason     string
+	}{
+		{
+			name:           "approved",
+			csr:            newTestCSRV1(t, certificatesv1.KubeAPIServerClientSignerName),
+			withCluster:    true,
+			wantConditions: 1,
+			wantType:       certificatesv1.CertificateApproved,
+			wantReason:     "AutoApprovedByCSRController",
+		},
+		{
+			name:           "denied signer",
+			csr:            newTestCSRV1(t, certificatesv1.KubeletServingSignerName),
+			withCluster:    true,
+			wantConditions: 1,
+			wantType:       certificatesv1.CertificateDenied,
+			wantReason:     "InvalidSignerName",
+		},
+		{
+			name:           "throttled",
+			csr:            newTestCSRV1(t, certificatesv1.KubeAPIServerClientSignerName),
+			withCluster:    true,
+			limiter:        throttled,
+			wantRequeue:    true,
+			wantConditions: 0,
+		},
+		{
+			name:           "cluster not found",
+			csr:            newTestCSRV1(t, certificatesv1.KubeAPIServerClientSignerName),
+			wantConditions: 0,
+		},
+		{
+			name:           "pending until the cluster is accepted",
+			csr:            newTestCSRV1(t, certificatesv1.KubeAPIServerClientSignerName),
+			withCluster:    true,
+			cluster:        revokedManagedCluster,
+			wantConditions: 0,
+		},
+		{
+			name:           "renewal of a revoked cluster",
+			csr:            renewalCSR,
+			withCluster:    true,
+			cluster:        revokedManagedCluster,
+			renewal:        true,
+			wantConditions: 1,
+			wantType:       certificatesv1.CertificateDenied,
+			wantReason:     "ClusterNotAccepted",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.renewal {
+				os.Setenv(csrRenewalApprovalEnvVarName, "true")
+				defer os.Unsetenv(csrRenewalApprovalEnvVarName)
+			}
+			cluster := testManagedCluster
+			if tt.cluster != nil {
+				cluster = tt.cluster
+			}
+			c := fake.NewFakeClientWithScheme(testscheme, tt.csr)
+			if tt.withCluster {
+				c = fake.NewFakeClientWithScheme(testscheme, cluster.DeepCopy(), tt.csr)
+			}
+			r := &ReconcileCSRV1{
+				client:     c,
+				kubeClient: fakeclientset.NewSimpleClientset(tt.csr),
+				recorder:   record.NewFakeRecorder(10),
+				scheme:     testscheme,
+				limiter:    tt.limiter,
+			}
+			result, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: csrNameReconcile}})
+			if err != nil {
+				t.Errorf("ReconcileCSRV1.Reconcile() unexpected error %v", err)
+				return
+			}
+			if (result.RequeueAfter > 0) != tt.wantRequeue {
+				t.Errorf("ReconcileCSRV1.Reconcile() result = %v, want requeue %v", result, tt.wantRequeue)
+			}
+			csr, err := r.kubeClient.CertificatesV1().CertificateSigningRequests().Get(context.TODO(), csrNameReconcile, metav1.GetOptions{})
+			if err != nil {
+				t.Errorf("CSR not found: %v", err)
+				return
+			}
+			if len(csr.Status.Conditions) != tt.wantConditions {
+				t.Errorf("expected %d conditions, got %v", tt.wantConditions, csr.Status.Conditions)
+				return
+			}
+			if tt.wantConditions == 0 {
+				return
+			}
+			if csr.Status.Conditions[0].Type != tt.wantType || csr.Status.Conditions[0].Reason != tt.wantReason {
+				t.Errorf("expected condition %s/%s, got %v", tt.wantType, tt.wantReason, csr.Status.Conditions[0])
+			}
+			assertCSRAudit(t, r.client, string(tt.wantType))
+		})
+	}
+}
+
+func Test_csrPredicateV1(t *testing.T) {
+	pending := newTestCSRV1(t, certificatesv1.KubeAPIServerClientSignerName)
+	approved := pending.DeepCopy()
+	approved.Status.Conditions = []certificatesv1.CertificateSigningRequestCondition{
+		{Type: certificatesv1.CertificateApproved},
+	}
+	badUsername := pending.DeepCopy()
+	badUsername.Spec.Username = "badUserName"
+	noLabel := pending.DeepCopy()
+	noLabel.Labels = nil
+
+	tests := []struct {
+		name string
+		csr  *certificatesv1.CertificateSigningRequest
+		want bool
+	}{
+		{name: "pending", csr: pending, want: true},
+		{name: "approved", csr: approved, want: false},
+		{name: "bad username", csr: badUsername, want: false},
+		{name: "no cluster label", csr: noLabel, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := csrPredicateV1(tt.csr); got != tt.want {
+				t.Errorf("csrPredicateV1() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package csr
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+var csrDecisions = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Namespace: "managedcluster_import",
+	Name:      "csr_decisions_total",
+	Help:      "Number of CSRs of the managed clusters approved, denied or failed to be approved.",
+}, []string{"decision"})
+
+func init() {
+	metrics.Registry.MustRegister(csrDecisions)
+}
@@ -0,0 +1,172 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package csr
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"golang.org/x/time/rate"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+)
+
+const (
+	// the environment variables overriding the default budget of CSR approvals of a managed cluster
+	csrApprovalRateEnvVarName  = "CSR_APPROVAL_RATE_PER_HOUR"
+	csrApprovalBurstEnvVarName = "CSR_APPROVAL_BURST"
+
+	defaultApprovalRatePerHour = 10
+	defaultApprovalBurst       = 10
+)
+
+// approvalRateLimiter is a token bucket per managed cluster limiting the number of CSRs approved,
+// a nil approvalRateLimiter allows all the approvals
+type approvalRateLimiter struct {
+	mu       sync.Mutex
+	limit    rate.Limit
+	burst    int
+	limiters map[string]*rate.Limiter
+	// refilled is the time the bucket of a cluster is full again, its limiter is then evicted
+	refilled  map[string]time.Time
+	throttled map[string]time.Time
+	now       func() time.Time
+}
+
+// newApprovalRateLimiter returns the approval rate limiter configured by the environment variables
+func newApprovalRateLimiter() (*approvalRateLimiter, error) {
+	perHour := float64(defaultApprovalRatePerHour)
+	burst := defaultApprovalBurst
+	var err error
+	if value := os.Getenv(csrApprovalRateEnvVarName); value != "" {
+		if perHour, err = strconv.ParseFloat(value, 64); err != nil || perHour <= 0 {
+			return nil, fmt.Errorf("invalid %s: %q", csrApprovalRateEnvVarName, value)
+		}
+	}
+	if value := os.Getenv(csrApprovalBurstEnvVarName); value != "" {
+		if burst, err = strconv.Atoi(value); err != nil || burst <= 0 {
+			return nil, fmt.Errorf("invalid %s: %q", csrApprovalBurstEnvVarName, value)
+		}
+	}
+	return &approvalRateLimiter{
+		limit:     rate.Limit(perHour / time.Hour.Seconds()),
+		burst:     burst,
+		limiters:  map[string]*rate.Limiter{},
+		refilled:  map[string]time.Time{},
+		throttled: map[string]time.Time{},
+		now:       time.Now,
+	}, nil
+}
+
+// allow takes a token of the bucket of the cluster clusterName to approve a CSR, if the bucket is empty
+// the cluster is throttled and the delay before the next token is returned
+func (l *approvalRateLimiter) allow(clusterName string) (bool, time.Duration) {
+	if l == nil {
+		return true, 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	l.evictRefilled(now)
+	limiter, ok := l.limiters[clusterName]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.limiters[clusterName] = limiter
+	}
+	reservation := limiter.ReserveN(now, 1)
+	if delay := reservation.DelayFrom(now); delay > 0 {
+		reservation.CancelAt(now)
+		l.throttled[clusterName] = now.Add(delay)
+		return false, delay
+	}
+	delete(l.throttled, clusterName)
+	// the token taken is added back to the bucket after the tokens taken before
+	refilled := now
+	if last, ok := l.refilled[clusterName]; ok && last.After(now) {
+		refilled = last
+	}
+	l.refilled[clusterName] = refilled.Add(time.Duration(float64(time.Second) / float64(l.limit)))
+	return true, 0
+}
+
+// evictRefilled removes the limiters of the clusters whose bucket is full again at now, they are
+// the same as new limiters, so that the limiters of the idle or deleted clusters are not kept
+func (l *approvalRateLimiter) evictRefilled(now time.Time) {
+	for clusterName, refilled := range l.refilled {
+		if !now.Before(refilled) {
+			delete(l.limiters, clusterName)
+			delete(l.refilled, clusterName)
+			delete(l.throttled, clusterName)
+		}
+	}
+}
+
+// throttledClusters returns the sorted names of the clusters currently throttled
+func (l *approvalRateLimiter) throttledClusters() []string {
+	if l == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	clusters := []string{}
+	for clusterName, until := range l.throttled {
+		if now.Before(until) {
+			clusters = append(clusters, clusterName)
+		}
+	}
+	sort.Strings(clusters)
+	return clusters
+}
+
+// recordThrottled records the CSR csr of the managedCluster refused by the approval rate limiter
+func recordThrottled(
+	recorder record.EventRecorder,
+	csr runtime.Object,
+	csrName string,
+	managedCluster *clusterv1.ManagedCluster,
+	delay time.Duration,
+) {
+	csrDecisions.WithLabelValues("throttled").Inc()
+	recorder.Eventf(csr, corev1.EventTypeWarning, "CSRApprovalThrottled",
+		"Too many CSRs of the managed cluster %s are approved, the approval is retried in %s",
+		managedCluster.Name, delay.Round(time.Second))
+	recorder.Eventf(managedCluster, corev1.EventTypeWarning, "CSRApprovalThrottled",
+		"Too many CSRs of the managed cluster are approved, the approval of the CSR %s is retried in %s",
+		csrName, delay.Round(time.Second))
+}
+
+// throttledClustersCollector reports the clusters currently throttled by the approval rate limiter
+type throttledClustersCollector struct {
+	limiter *approvalRateLimiter
+	desc    *prometheus.Desc
+}
+
+func newThrottledClustersCollector(limiter *approvalRateLimiter) *throttledClustersCollector {
+	return &throttledClustersCollector{
+		limiter: limiter,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName("managedcluster_import", "", "csr_throttled_cluster"),
+			"Managed clusters whose CSR approvals are currently throttled.",
+			[]string{"cluster"}, nil,
+		),
+	}
+}
+
+func (c *throttledClustersCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *throttledClustersCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, clusterName := range c.limiter.throttledClusters() {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, 1, clusterName)
+	}
+}
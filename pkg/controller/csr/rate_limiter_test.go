@@ -0,0 +1,132 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package csr
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func newTestRateLimiter(t *testing.T, perHour, burst string, now *time.Time) *approvalRateLimiter {
+	os.Setenv(csrApprovalRateEnvVarName, perHour)
+	os.Setenv(csrApprovalBurstEnvVarName, burst)
+	defer os.Unsetenv(csrApprovalRateEnvVarName)
+	defer os.Unsetenv(csrApprovalBurstEnvVarName)
+	limiter, err := newApprovalRateLimiter()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	limiter.now = func() time.Time { return *now }
+	return limiter
+}
+
+func Test_newApprovalRateLimiter(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr bool
+	}{
+		{name: "default"},
+		{name: "valid", env: map[string]string{csrApprovalRateEnvVarName: "0.5", csrApprovalBurstEnvVarName: "2"}},
+		{name: "invalid rate", env: map[string]string{csrApprovalRateEnvVarName: "fast"}, wantErr: true},
+		{name: "zero rate", env: map[string]string{csrApprovalRateEnvVarName: "0"}, wantErr: true},
+		{name: "invalid burst", env: map[string]string{csrApprovalBurstEnvVarName: "-1"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				os.Setenv(name, value)
+				defer os.Unsetenv(name)
+			}
+			if _, err := newApprovalRateLimiter(); (err != nil) != tt.wantErr {
+				t.Errorf("newApprovalRateLimiter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_approvalRateLimiter_allow(t *testing.T) {
+	now := time.Now()
+	limiter := newTestRateLimiter(t, "60", "2", &now)
+
+	for i := 0; i < 2; i++ {
+		if allowed, _ := limiter.allow("cluster1"); !allowed {
+			t.Errorf("approval %d should be allowed within the burst", i)
+		}
+	}
+	allowed, delay := limiter.allow("cluster1")
+	if allowed || delay <= 0 || delay > time.Minute {
+		t.Errorf("allow() = %v, %v, want throttled for less than a minute", allowed, delay)
+	}
+	// the buckets are per cluster
+	if allowed, _ := limiter.allow("cluster2"); !allowed {
+		t.Error("the approvals of cluster2 should not be throttled")
+	}
+	if got := limiter.throttledClusters(); !reflect.DeepEqual(got, []string{"cluster1"}) {
+		t.Errorf("throttledClusters() = %v, want [cluster1]", got)
+	}
+
+	expected := `
+# HELP managedcluster_import_csr_throttled_cluster Managed clusters whose CSR approvals are currently throttled.
+# TYPE managedcluster_import_csr_throttled_cluster gauge
+managedcluster_import_csr_throttled_cluster{cluster="cluster1"} 1
+`
+	if err := testutil.CollectAndCompare(newThrottledClustersCollector(limiter), strings.NewReader(expected)); err != nil {
+		t.Errorf("throttledClustersCollector unexpected metrics: %v", err)
+	}
+
+	// a token is added every minute
+	now = now.Add(time.Minute)
+	if got := limiter.throttledClusters(); len(got) != 0 {
+		t.Errorf("throttledClusters() = %v, want none", got)
+	}
+	if allowed, _ := limiter.allow("cluster1"); !allowed {
+		t.Error("the approval should be allowed once the bucket is refilled")
+	}
+}
+
+func Test_approvalRateLimiter_evictRefilled(t *testing.T) {
+	now := time.Now()
+	limiter := newTestRateLimiter(t, "60", "2", &now)
+
+	for i := 0; i < 3; i++ {
+		limiter.allow("cluster1")
+	}
+	limiter.allow("cluster2")
+	if len(limiter.limiters) != 2 {
+		t.Errorf("expected the limiters of cluster1 and cluster2, got %d", len(limiter.limiters))
+	}
+
+	// the bucket of cluster2 is full again after a minute, cluster1 took 2 tokens and is throttled
+	now = now.Add(time.Minute)
+	limiter.allow("cluster3")
+	if _, ok := limiter.limiters["cluster2"]; ok {
+		t.Error("expected the limiter of cluster2 to be evicted once its bucket is full")
+	}
+	if _, ok := limiter.limiters["cluster1"]; !ok {
+		t.Error("expected the limiter of cluster1 to be kept until its bucket is full")
+	}
+
+	// the buckets of cluster1 and cluster3 are full again 2 minutes later
+	now = now.Add(2 * time.Minute)
+	limiter.allow("cluster4")
+	if len(limiter.limiters) != 1 || len(limiter.refilled) != 1 || len(limiter.throttled) != 0 {
+		t.Errorf("expected only the limiter of cluster4, got %d limiters, %d refilled and %d throttled",
+			len(limiter.limiters), len(limiter.refilled), len(limiter.throttled))
+	}
+}
+
+func Test_approvalRateLimiter_nil(t *testing.T) {
+	var limiter *approvalRateLimiter
+	if allowed, _ := limiter.allow("cluster1"); !allowed {
+		t.Error("a nil limiter should allow all the approvals")
+	}
+	if got := limiter.throttledClusters(); len(got) != 0 {
+		t.Errorf("throttledClusters() = %v, want none", got)
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+// the keys of the auto-import/aks auto-import-secret
+const (
+	aksTenantIDKey       = "tenant_id"
+	aksClientIDKey       = "client_id"
+	aksClientSecretKey   = "client_secret"
+	aksSubscriptionIDKey = "subscription_id"
+	aksResourceGroupKey  = "resource_group"
+	aksClusterNameKey    = "cluster_name"
+)
+
+const aksAPIVersion = "2021-05-01"
+
+// aksProvider resolves the service principal of an auto-import/aks auto-import-secret, the kubeconfig of
+// the AKS cluster is listed with the Azure Resource Manager API
+type aksProvider struct {
+	loginURL      string
+	managementURL string
+	httpClient    *http.Client
+}
+
+func newAKSProvider() *aksProvider {
+	return &aksProvider{
+		loginURL:      "https://login.microsoftonline.com",
+		managementURL: "https://management.azure.com",
+		httpClient:    newCloudHTTPClient(),
+	}
+}
+
+// aksCredentialResults is the response of the listClusterAdminCredential action
+type aksCredentialResults struct {
+	Kubeconfigs []struct {
+		Name  string `json:"name"`
+		Value []byte `json:"value"`
+	} `json:"kubeconfigs"`
+}
+
+func (p *aksProvider) RestConfig(autoImportSecret *corev1.Secret) (*rest.Config, error) {
+	values, err := getSecretValues(autoImportSecret, aksTenantIDKey, aksClientIDKey, aksClientSecretKey,
+		aksSubscriptionIDKey, aksResourceGroupKey, aksClusterNameKey)
+	if err != nil {
+		return nil, err
+	}
+	clusterName := values[aksClusterNameKey]
+
+	credentials := &clientcredentials.Config{
+		ClientID:     values[aksClientIDKey],
+		ClientSecret: values[aksClientSecretKey],
+		TokenURL:     fmt.Sprintf("%s/%s/oauth2/v2.0/token", p.loginURL, values[aksTenantIDKey]),
+		Scopes:       []string{p.managementURL + "/.default"},
+	}
+	ctx := context.WithValue(context.TODO(), oauth2.HTTPClient, p.httpClient)
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf(
+		"%s/subscriptions/%s/resourceGroups/%s/providers/Microsoft.ContainerService/managedClusters/%s/listClusterAdminCredential?api-version=%s",
+		p.managementURL, values[aksSubscriptionIDKey], values[aksResourceGroupKey], clusterName, aksAPIVersion), nil)
+	if err != nil {
+		return nil, err
+	}
+	results := &aksCredentialResults{}
+	if err := doJSONRequest(credentials.Client(ctx), req, results); err != nil {
+		return nil, fmt.Errorf("failed to list the credentials of the AKS cluster %s: %v", clusterName, err)
+	}
+	if len(results.Kubeconfigs) == 0 {
+		return nil, fmt.Errorf("no credentials found for the AKS cluster %s", clusterName)
+	}
+	return clientcmd.RESTConfigFromKubeConfig(results.Kubeconfigs[0].Value)
+}
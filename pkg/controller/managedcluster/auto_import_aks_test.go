@@ -0,0 +1,91 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const aksKubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- cluster:
+    server: https://aks1.example.com:443
+  name: aks1
+contexts:
+- context:
+    cluster: aks1
+    user: clusterAdmin_rg1_aks1
+  name: aks1-admin
+current-context: aks1-admin
+users:
+- name: clusterAdmin_rg1_aks1
+  user:
+    token: admin-token
+`
+
+func Test_aksProvider_RestConfig(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/tenant1/oauth2/v2.0/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "client_credentials" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if user, password, _ := r.BasicAuth(); user != "client1" || password != "secret1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"arm-token","token_type":"Bearer","expires_in":3600}`)
+	})
+	mux.HandleFunc("/subscriptions/sub1/resourceGroups/rg1/providers/Microsoft.ContainerService/managedClusters/aks1/listClusterAdminCredential",
+		func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer arm-token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			fmt.Fprintf(w, `{"kubeconfigs":[{"name":"clusterAdmin","value":"%s"}]}`,
+				base64.StdEncoding.EncodeToString([]byte(aksKubeconfig)))
+		})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	provider := newAKSProvider()
+	provider.loginURL = server.URL
+	provider.managementURL = server.URL
+
+	newSecret := func(clientSecret string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: autoImportSecretName, Namespace: "cluster1"},
+			Type:       autoImportSecretTypeAKS,
+			Data: map[string][]byte{
+				aksTenantIDKey:       []byte("tenant1"),
+				aksClientIDKey:       []byte("client1"),
+				aksClientSecretKey:   []byte(clientSecret),
+				aksSubscriptionIDKey: []byte("sub1"),
+				aksResourceGroupKey:  []byte("rg1"),
+				aksClusterNameKey:    []byte("aks1"),
+			},
+		}
+	}
+
+	config, err := provider.RestConfig(newSecret("secret1"))
+	if err != nil {
+		t.Fatalf("RestConfig() unexpected error %v", err)
+	}
+	if config.Host != "https://aks1.example.com:443" || config.BearerToken != "admin-token" {
+		t.Errorf("RestConfig() = %s %s", config.Host, config.BearerToken)
+	}
+
+	if _, err := provider.RestConfig(newSecret("invalid")); err == nil {
+		t.Error("RestConfig() expected an error with an invalid client secret")
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// the environment variables configuring the backoff between the auto-import attempts
+	autoImportBackoffInitialDelayEnvVarName = "AUTO_IMPORT_BACKOFF_INITIAL_DELAY"
+	autoImportBackoffFactorEnvVarName       = "AUTO_IMPORT_BACKOFF_FACTOR"
+	autoImportBackoffMaxDelayEnvVarName     = "AUTO_IMPORT_BACKOFF_MAX_DELAY"
+
+	// autoImportRetryUntilName is the optional deadline of the auto-import retries in the auto-import-secret,
+	// it replaces the autoImportRetry count
+	autoImportRetryUntilName = "autoImportRetryUntil"
+
+	// the annotations of the auto-import-secret recording the failed auto-import attempts
+	autoImportAttemptsAnnotation        = "import.open-cluster-management.io/auto-import-attempts"
+	autoImportLastAttemptTimeAnnotation = "import.open-cluster-management.io/last-attempt-time"
+	autoImportNextAttemptTimeAnnotation = "import.open-cluster-management.io/next-attempt-time"
+)
+
+// autoImportBackoff is the exponential backoff between the failed auto-import attempts
+type autoImportBackoff struct {
+	initialDelay time.Duration
+	factor       float64
+	maxDelay     time.Duration
+}
+
+// getAutoImportBackoff returns the default backoff overridden by the environment variables
+func getAutoImportBackoff() (*autoImportBackoff, error) {
+	backoff := &autoImportBackoff{
+		initialDelay: 30 * time.Second,
+		factor:       2,
+		maxDelay:     30 * time.Minute,
+	}
+	var err error
+	if value := os.Getenv(autoImportBackoffInitialDelayEnvVarName); value != "" {
+		if backoff.initialDelay, err = time.ParseDuration(value); err != nil || backoff.initialDelay <= 0 {
+			return nil, fmt.Errorf("invalid %s: %q", autoImportBackoffInitialDelayEnvVarName, value)
+		}
+	}
+	if value := os.Getenv(autoImportBackoffFactorEnvVarName); value != "" {
+		if backoff.factor, err = strconv.ParseFloat(value, 64); err != nil || backoff.factor < 1 {
+			return nil, fmt.Errorf("invalid %s: %q", autoImportBackoffFactorEnvVarName, value)
+		}
+	}
+	if value := os.Getenv(autoImportBackoffMaxDelayEnvVarName); value != "" {
+		if backoff.maxDelay, err = time.ParseDuration(value); err != nil || backoff.maxDelay <= 0 {
+			return nil, fmt.Errorf("invalid %s: %q", autoImportBackoffMaxDelayEnvVarName, value)
+		}
+	}
+	return backoff, nil
+}
+
+// delay returns the delay after the failed attempt number attempts, starting at 1
+func (b *autoImportBackoff) delay(attempts int) time.Duration {
+	delay := float64(b.initialDelay) * math.Pow(b.factor, float64(attempts-1))
+	if delay > float64(b.maxDelay) {
+		return b.maxDelay
+	}
+	return time.Duration(delay)
+}
+
+// recordAutoImportAttempt records a failed auto-import attempt at now in the annotations of the
+// autoImportSecret and returns the time of the next attempt
+func recordAutoImportAttempt(autoImportSecret *corev1.Secret, backoff *autoImportBackoff, now time.Time) time.Time {
+	attempts, _ := strconv.Atoi(autoImportSecret.GetAnnotations()[autoImportAttemptsAnnotation])
+	attempts++
+	next := now.Add(backoff.delay(attempts))
+
+	annotations := autoImportSecret.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[autoImportAttemptsAnnotation] = strconv.Itoa(attempts)
+	annotations[autoImportLastAttemptTimeAnnotation] = now.UTC().Format(time.RFC3339)
+	annotations[autoImportNextAttemptTimeAnnotation] = next.UTC().Format(time.RFC3339)
+	autoImportSecret.SetAnnotations(annotations)
+	return next
+}
+
+// untilNextAutoImportAttempt returns the time to wait before the next auto-import attempt with the autoImportSecret
+func untilNextAutoImportAttempt(autoImportSecret *corev1.Secret, now time.Time) time.Duration {
+	if autoImportSecret == nil {
+		return 0
+	}
+	next, err := time.Parse(time.RFC3339, autoImportSecret.GetAnnotations()[autoImportNextAttemptTimeAnnotation])
+	if err != nil {
+		return 0
+	}
+	return next.Sub(now)
+}
+
+// getAutoImportRetryUntil returns the deadline of the auto-import retries if it is set in the autoImportSecret
+func getAutoImportRetryUntil(autoImportSecret *corev1.Secret) (*time.Time, error) {
+	value, ok := autoImportSecret.Data[autoImportRetryUntilName]
+	if !ok {
+		return nil, nil
+	}
+	deadline, err := time.Parse(time.RFC3339, string(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s in the auto-import-secret: %v", autoImportRetryUntilName, err)
+	}
+	return &deadline, nil
+}
@@ -0,0 +1,167 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_getAutoImportBackoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    autoImportBackoff
+		wantErr bool
+	}{
+		{
+			name: "default",
+			want: autoImportBackoff{initialDelay: 30 * time.Second, factor: 2, maxDelay: 30 * time.Minute},
+		},
+		{
+			name: "overridden",
+			env: map[string]string{
+				autoImportBackoffInitialDelayEnvVarName: "1m",
+				autoImportBackoffFactorEnvVarName:       "1.5",
+				autoImportBackoffMaxDelayEnvVarName:     "2h",
+			},
+			want: autoImportBackoff{initialDelay: time.Minute, factor: 1.5, maxDelay: 2 * time.Hour},
+		},
+		{
+			name:    "invalid delay",
+			env:     map[string]string{autoImportBackoffInitialDelayEnvVarName: "10"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid factor",
+			env:     map[string]string{autoImportBackoffFactorEnvVarName: "0.5"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				os.Setenv(name, value)
+				defer os.Unsetenv(name)
+			}
+			got, err := getAutoImportBackoff()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getAutoImportBackoff() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("getAutoImportBackoff() = %v, want %v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_autoImportBackoff_delay(t *testing.T) {
+	backoff := &autoImportBackoff{initialDelay: 30 * time.Second, factor: 2, maxDelay: 5 * time.Minute}
+	for attempts, want := range map[int]time.Duration{
+		1: 30 * time.Second,
+		2: time.Minute,
+		4: 4 * time.Minute,
+		5: 5 * time.Minute,
+		9: 5 * time.Minute,
+	} {
+		if got := backoff.delay(attempts); got != want {
+			t.Errorf("delay(%d) = %s, want %s", attempts, got, want)
+		}
+	}
+}
+
+func Test_recordAutoImportAttempt(t *testing.T) {
+	backoff := &autoImportBackoff{initialDelay: 30 * time.Second, factor: 2, maxDelay: 5 * time.Minute}
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	secret := &corev1.Secret{}
+
+	if wait := untilNextAutoImportAttempt(secret, now); wait > 0 {
+		t.Errorf("untilNextAutoImportAttempt() = %s before any attempt", wait)
+	}
+	recordAutoImportAttempt(secret, backoff, now)
+	next := recordAutoImportAttempt(secret, backoff, now)
+	if !next.Equal(now.Add(time.Minute)) {
+		t.Errorf("recordAutoImportAttempt() = %s, want %s", next, now.Add(time.Minute))
+	}
+	if got := secret.Annotations[autoImportAttemptsAnnotation]; got != "2" {
+		t.Errorf("attempts annotation = %s, want 2", got)
+	}
+	if got := secret.Annotations[autoImportLastAttemptTimeAnnotation]; got != "2021-06-01T10:00:00Z" {
+		t.Errorf("last attempt annotation = %s", got)
+	}
+	if wait := untilNextAutoImportAttempt(secret, now.Add(20*time.Second)); wait != 40*time.Second {
+		t.Errorf("untilNextAutoImportAttempt() = %s, want 40s", wait)
+	}
+}
+
+func TestReconcileManagedCluster_updateAutoImportRetry_deadline(t *testing.T) {
+	testscheme := scheme.Scheme
+	managedCluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}}
+
+	newSecret := func(deadline time.Time) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: autoImportSecretName, Namespace: "cluster1"},
+			Data: map[string][]byte{
+				"kubeconfig":             []byte("kubeconfig"),
+				autoImportRetryUntilName: []byte(deadline.UTC().Format(time.RFC3339)),
+			},
+		}
+	}
+
+	tests := []struct {
+		name        string
+		secret      *corev1.Secret
+		wantDeleted bool
+	}{
+		{
+			name:   "before the deadline",
+			secret: newSecret(time.Now().Add(time.Hour)),
+		},
+		{
+			name:        "deadline reached",
+			secret:      newSecret(time.Now().Add(10 * time.Second)),
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ReconcileManagedCluster{
+				client:   fake.NewFakeClientWithScheme(testscheme, tt.secret),
+				scheme:   testscheme,
+				recorder: record.NewFakeRecorder(10),
+			}
+			if err := r.updateAutoImportRetry(managedCluster, tt.secret); err != nil {
+				t.Errorf("updateAutoImportRetry() unexpected error %v", err)
+				return
+			}
+			secret := &corev1.Secret{}
+			err := r.client.Get(context.TODO(), types.NamespacedName{Name: autoImportSecretName, Namespace: "cluster1"}, secret)
+			if tt.wantDeleted {
+				if !errors.IsNotFound(err) {
+					t.Errorf("expected the auto-import-secret to be deleted, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error %v", err)
+				return
+			}
+			if secret.Annotations[autoImportNextAttemptTimeAnnotation] == "" {
+				t.Errorf("expected the next attempt time, got %v", secret.Annotations)
+			}
+		})
+	}
+}
@@ -0,0 +1,155 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// autoImportCleanupPolicyAnnotation is the cleanup policy of the auto-import-secret once the cluster is imported
+	autoImportCleanupPolicyAnnotation = "import.open-cluster-management.io/auto-import-cleanup-policy"
+	// autoImportSucceededTimeAnnotation records the last successful import with a retained auto-import-secret
+	autoImportSucceededTimeAnnotation = "import.open-cluster-management.io/auto-import-succeeded-time"
+)
+
+// the cleanup policies of the auto-import-secret
+const (
+	// autoImportCleanupPolicyDelete deletes the auto-import-secret once the cluster is imported
+	autoImportCleanupPolicyDelete = "Delete"
+	// autoImportCleanupPolicyKeep keeps the auto-import-secret to import the cluster again if it goes offline
+	autoImportCleanupPolicyKeep = "Keep"
+	// autoImportCleanupPolicyKeepUntilAvailable keeps the auto-import-secret until the cluster is available
+	autoImportCleanupPolicyKeepUntilAvailable = "KeepUntilAvailable"
+)
+
+// getAutoImportCleanupPolicy returns the cleanup policy of the autoImportSecret, Delete by default
+func getAutoImportCleanupPolicy(autoImportSecret *corev1.Secret) (string, error) {
+	policy, ok := autoImportSecret.GetAnnotations()[autoImportCleanupPolicyAnnotation]
+	if !ok {
+		return autoImportCleanupPolicyDelete, nil
+	}
+	switch policy {
+	case autoImportCleanupPolicyDelete, autoImportCleanupPolicyKeep, autoImportCleanupPolicyKeepUntilAvailable:
+		return policy, nil
+	}
+	return "", fmt.Errorf("invalid %s annotation %q, it must be %s, %s or %s", autoImportCleanupPolicyAnnotation, policy,
+		autoImportCleanupPolicyDelete, autoImportCleanupPolicyKeep, autoImportCleanupPolicyKeepUntilAvailable)
+}
+
+// getAutoImportSucceededTime returns the time of the last successful import with the retained autoImportSecret
+func getAutoImportSucceededTime(autoImportSecret *corev1.Secret) *time.Time {
+	if autoImportSecret == nil {
+		return nil
+	}
+	succeeded, err := time.Parse(time.RFC3339, autoImportSecret.GetAnnotations()[autoImportSucceededTimeAnnotation])
+	if err != nil {
+		return nil
+	}
+	return &succeeded
+}
+
+// cleanupAutoImportSecret applies the cleanup policy of the autoImportSecret once the cluster is imported,
+// a retained autoImportSecret records the successful import and its failed attempts are reset
+func (r *ReconcileManagedCluster) cleanupAutoImportSecret(
+	managedCluster *clusterv1.ManagedCluster,
+	autoImportSecret *corev1.Secret) error {
+	policy, err := getAutoImportCleanupPolicy(autoImportSecret)
+	if err != nil {
+		return err
+	}
+	if policy == autoImportCleanupPolicyDelete {
+		return r.client.Delete(context.TODO(), autoImportSecret)
+	}
+	annotations := autoImportSecret.GetAnnotations()
+	delete(annotations, autoImportAttemptsAnnotation)
+	delete(annotations, autoImportLastAttemptTimeAnnotation)
+	delete(annotations, autoImportNextAttemptTimeAnnotation)
+	annotations[autoImportSucceededTimeAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	autoImportSecret.SetAnnotations(annotations)
+	return r.client.Update(context.TODO(), autoImportSecret)
+}
+
+// reimportWithRetainedSecret returns true if the cluster already imported with the retained autoImportSecret
+// must be imported again as it went offline after the last import. The autoImportSecret with the
+// KeepUntilAvailable policy is deleted once the cluster is available.
+func (r *ReconcileManagedCluster) reimportWithRetainedSecret(
+	managedCluster *clusterv1.ManagedCluster,
+	autoImportSecret *corev1.Secret) (bool, error) {
+	succeeded := getAutoImportSucceededTime(autoImportSecret)
+	if succeeded == nil {
+		return true, nil
+	}
+	policy, err := getAutoImportCleanupPolicy(autoImportSecret)
+	if err != nil {
+		return false, err
+	}
+
+	available := meta.FindStatusCondition(managedCluster.Status.Conditions, clusterv1.ManagedClusterConditionAvailable)
+	switch {
+	case available == nil:
+		// the klusterlet has not joined yet
+		return false, nil
+	case available.Status == metav1.ConditionTrue:
+		return false, r.deleteAutoImportSecretKeptUntilAvailable(managedCluster, autoImportSecret, policy)
+	case available.LastTransitionTime.Time.After(*succeeded):
+		// the cluster went offline after the last import
+		r.recorder.Eventf(managedCluster, corev1.EventTypeNormal, "AutoImportRetriggered",
+			"The cluster is offline since %s, it is imported again with the retained %s/%s secret",
+			available.LastTransitionTime.UTC().Format(time.RFC3339), autoImportSecret.Namespace, autoImportSecret.Name)
+		return true, nil
+	}
+	return false, nil
+}
+
+// cleanupAvailableAutoImportSecret deletes the auto-import-secret retained with the KeepUntilAvailable policy
+// once the imported cluster is available
+func (r *ReconcileManagedCluster) cleanupAvailableAutoImportSecret(managedCluster *clusterv1.ManagedCluster) error {
+	autoImportSecret := &corev1.Secret{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{
+		Name:      autoImportSecretName,
+		Namespace: managedCluster.Name,
+	}, autoImportSecret)
+	if errors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if getAutoImportSucceededTime(autoImportSecret) == nil {
+		// the cluster is not imported with this auto-import-secret yet
+		return nil
+	}
+	policy, err := getAutoImportCleanupPolicy(autoImportSecret)
+	if err != nil {
+		return err
+	}
+	return r.deleteAutoImportSecretKeptUntilAvailable(managedCluster, autoImportSecret, policy)
+}
+
+// deleteAutoImportSecretKeptUntilAvailable deletes the autoImportSecret of the available cluster if its cleanup
+// policy is KeepUntilAvailable
+func (r *ReconcileManagedCluster) deleteAutoImportSecretKeptUntilAvailable(
+	managedCluster *clusterv1.ManagedCluster,
+	autoImportSecret *corev1.Secret,
+	policy string) error {
+	if policy != autoImportCleanupPolicyKeepUntilAvailable {
+		return nil
+	}
+	if err := r.client.Delete(context.TODO(), autoImportSecret); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	r.recorder.Eventf(managedCluster, corev1.EventTypeNormal, "AutoImportSecretDeleted",
+		"The cluster is available, the %s/%s secret is deleted", autoImportSecret.Namespace, autoImportSecret.Name)
+	return nil
+}
@@ -0,0 +1,280 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	workv1 "github.com/open-cluster-management/api/work/v1"
+	ocinfrav1 "github.com/openshift/api/config/v1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newRetainedAutoImportSecret(policy string, succeeded *time.Time) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        autoImportSecretName,
+			Namespace:   "cluster1",
+			Annotations: map[string]string{},
+		},
+		Data: map[string][]byte{
+			"kubeconfig":        []byte("kubeconfig"),
+			autoImportRetryName: []byte("2"),
+		},
+	}
+	if policy != "" {
+		secret.Annotations[autoImportCleanupPolicyAnnotation] = policy
+	}
+	if succeeded != nil {
+		secret.Annotations[autoImportSucceededTimeAnnotation] = succeeded.UTC().Format(time.RFC3339)
+	}
+	return secret
+}
+
+func Test_getAutoImportCleanupPolicy(t *testing.T) {
+	tests := []struct {
+		policy  string
+		want    string
+		wantErr bool
+	}{
+		{policy: "", want: autoImportCleanupPolicyDelete},
+		{policy: autoImportCleanupPolicyKeep, want: autoImportCleanupPolicyKeep},
+		{policy: autoImportCleanupPolicyKeepUntilAvailable, want: autoImportCleanupPolicyKeepUntilAvailable},
+		{policy: "Forever", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			got, err := getAutoImportCleanupPolicy(newRetainedAutoImportSecret(tt.policy, nil))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getAutoImportCleanupPolicy() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("getAutoImportCleanupPolicy() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileManagedCluster_cleanupAutoImportSecret(t *testing.T) {
+	testscheme := scheme.Scheme
+	managedCluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}}
+
+	tests := []struct {
+		name        string
+		policy      string
+		wantDeleted bool
+	}{
+		{name: "delete", wantDeleted: true},
+		{name: "keep", policy: autoImportCleanupPolicyKeep},
+		{name: "keep until available", policy: autoImportCleanupPolicyKeepUntilAvailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := newRetainedAutoImportSecret(tt.policy, nil)
+			secret.Annotations[autoImportNextAttemptTimeAnnotation] = "2021-06-01T10:00:00Z"
+			r := &ReconcileManagedCluster{
+				client:   fake.NewFakeClientWithScheme(testscheme, secret),
+				scheme:   testscheme,
+				recorder: record.NewFakeRecorder(10),
+			}
+			if err := r.cleanupAutoImportSecret(managedCluster, secret); err != nil {
+				t.Errorf("cleanupAutoImportSecret() unexpected error %v", err)
+				return
+			}
+			retained := &corev1.Secret{}
+			err := r.client.Get(context.TODO(), types.NamespacedName{Name: autoImportSecretName, Namespace: "cluster1"}, retained)
+			if tt.wantDeleted {
+				if !errors.IsNotFound(err) {
+					t.Errorf("expected the auto-import-secret to be deleted, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error %v", err)
+				return
+			}
+			if getAutoImportSucceededTime(retained) == nil {
+				t.Errorf("expected the succeeded time, got %v", retained.Annotations)
+			}
+			if _, ok := retained.Annotations[autoImportNextAttemptTimeAnnotation]; ok {
+				t.Errorf("expected the failed attempts to be reset, got %v", retained.Annotations)
+			}
+		})
+	}
+}
+
+func TestReconcileManagedCluster_reimportWithRetainedSecret(t *testing.T) {
+	testscheme := scheme.Scheme
+	succeeded := time.Now().Add(-time.Hour)
+
+	newManagedCluster := func(status metav1.ConditionStatus, transition time.Time) *clusterv1.ManagedCluster {
+		managedCluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}}
+		if status != "" {
+			managedCluster.Status.Conditions = []metav1.Condition{{
+				Type:               clusterv1.ManagedClusterConditionAvailable,
+				Status:             status,
+				LastTransitionTime: metav1.NewTime(transition),
+			}}
+		}
+		return managedCluster
+	}
+
+	tests := []struct {
+		name           string
+		managedCluster *clusterv1.ManagedCluster
+		secret         *corev1.Secret
+		wantReimport   bool
+		wantDeleted    bool
+	}{
+		{
+			name:           "not imported yet",
+			managedCluster: newManagedCluster("", time.Time{}),
+			secret:         newRetainedAutoImportSecret(autoImportCleanupPolicyKeep, nil),
+			wantReimport:   true,
+		},
+		{
+			name:           "joining",
+			managedCluster: newManagedCluster("", time.Time{}),
+			secret:         newRetainedAutoImportSecret(autoImportCleanupPolicyKeep, &succeeded),
+		},
+		{
+			name:           "available",
+			managedCluster: newManagedCluster(metav1.ConditionTrue, time.Now()),
+			secret:         newRetainedAutoImportSecret(autoImportCleanupPolicyKeep, &succeeded),
+		},
+		{
+			name:           "available with keep until available",
+			managedCluster: newManagedCluster(metav1.ConditionTrue, time.Now()),
+			secret:         newRetainedAutoImportSecret(autoImportCleanupPolicyKeepUntilAvailable, &succeeded),
+			wantDeleted:    true,
+		},
+		{
+			name:           "offline after the import",
+			managedCluster: newManagedCluster(metav1.ConditionUnknown, time.Now()),
+			secret:         newRetainedAutoImportSecret(autoImportCleanupPolicyKeep, &succeeded),
+			wantReimport:   true,
+		},
+		{
+			name:           "offline before the import",
+			managedCluster: newManagedCluster(metav1.ConditionUnknown, succeeded.Add(-time.Minute)),
+			secret:         newRetainedAutoImportSecret(autoImportCleanupPolicyKeep, &succeeded),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ReconcileManagedCluster{
+				client:   fake.NewFakeClientWithScheme(testscheme, tt.secret),
+				scheme:   testscheme,
+				recorder: record.NewFakeRecorder(10),
+			}
+			reimport, err := r.reimportWithRetainedSecret(tt.managedCluster, tt.secret)
+			if err != nil {
+				t.Errorf("reimportWithRetainedSecret() unexpected error %v", err)
+				return
+			}
+			if reimport != tt.wantReimport {
+				t.Errorf("reimportWithRetainedSecret() = %v, want %v", reimport, tt.wantReimport)
+			}
+			err = r.client.Get(context.TODO(), types.NamespacedName{Name: autoImportSecretName, Namespace: "cluster1"}, &corev1.Secret{})
+			if deleted := errors.IsNotFound(err); deleted != tt.wantDeleted {
+				t.Errorf("auto-import-secret deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
+
+func TestReconcileManagedCluster_Reconcile_keepUntilAvailable(t *testing.T) {
+	os.Setenv("DEFAULT_IMAGE_PULL_SECRET", imagePullSecretNameReconcile)
+	defer os.Unsetenv("DEFAULT_IMAGE_PULL_SECRET")
+	os.Setenv("POD_NAMESPACE", "open-cluster-management")
+	defer os.Unsetenv("POD_NAMESPACE")
+
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{})
+	testscheme.AddKnownTypes(hivev1.SchemeGroupVersion, &hivev1.ClusterDeployment{})
+	testscheme.AddKnownTypes(workv1.SchemeGroupVersion, &workv1.ManifestWork{}, &workv1.ManifestWorkList{})
+	testscheme.AddKnownTypes(ocinfrav1.SchemeGroupVersion, &ocinfrav1.Infrastructure{}, &ocinfrav1.APIServer{})
+
+	succeeded := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name        string
+		policy      string
+		wantDeleted bool
+	}{
+		{
+			name:   "keep",
+			policy: autoImportCleanupPolicyKeep,
+		},
+		{
+			name:        "keep until available",
+			policy:      autoImportCleanupPolicyKeepUntilAvailable,
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedCluster := &clusterv1.ManagedCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster1",
+				},
+				Status: clusterv1.ManagedClusterStatus{
+					Conditions: []metav1.Condition{
+						{Type: clusterv1.ManagedClusterConditionAvailable, Status: metav1.ConditionTrue},
+					},
+					Version: clusterv1.ManagedClusterVersion{Kubernetes: "1.17.0"},
+				},
+			}
+			serviceAccount, err := newBootstrapServiceAccount(managedCluster)
+			if err != nil {
+				t.Errorf("unexpected error %v", err)
+				return
+			}
+			tokenSecret, err := serviceAccountTokenSecret(serviceAccount)
+			if err != nil {
+				t.Errorf("unexpected error %v", err)
+				return
+			}
+			serviceAccount.Secrets = []corev1.ObjectReference{{Name: tokenSecret.Name}}
+			infraConfig := &ocinfrav1.Infrastructure{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+				Status:     ocinfrav1.InfrastructureStatus{APIServerURL: "http://127.0.0.1:6443"},
+			}
+			recorder := record.NewFakeRecorder(10)
+			r := &ReconcileManagedCluster{
+				client: fake.NewFakeClientWithScheme(testscheme,
+					&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}},
+					managedCluster,
+					serviceAccount,
+					tokenSecret,
+					newFakeImagePullSecret(),
+					infraConfig,
+					newRetainedAutoImportSecret(tt.policy, &succeeded),
+				),
+				scheme:   testscheme,
+				recorder: recorder,
+			}
+			if _, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Name: "cluster1"}}); err != nil {
+				t.Errorf("Reconcile() unexpected error %v", err)
+				return
+			}
+			err = r.client.Get(context.TODO(), types.NamespacedName{Name: autoImportSecretName, Namespace: "cluster1"}, &corev1.Secret{})
+			if deleted := errors.IsNotFound(err); deleted != tt.wantDeleted {
+				t.Errorf("auto-import-secret deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// the types of the auto-import-secrets carrying the credentials of a cloud identity
+const (
+	autoImportSecretTypeEKS corev1.SecretType = "auto-import/eks"
+	autoImportSecretTypeAKS corev1.SecretType = "auto-import/aks"
+	autoImportSecretTypeGKE corev1.SecretType = "auto-import/gke"
+)
+
+// cloudRequestTimeout bounds the requests to the cloud APIs, a cloud API which does not respond must not
+// block the reconcile of the managed clusters
+const cloudRequestTimeout = 30 * time.Second
+
+// cloudProvider resolves the cloud credentials of an auto-import-secret into the rest.Config of the managed cluster
+type cloudProvider interface {
+	RestConfig(autoImportSecret *corev1.Secret) (*rest.Config, error)
+}
+
+// cloudProviders are the cloud providers by type of auto-import-secret
+var cloudProviders = map[corev1.SecretType]cloudProvider{
+	autoImportSecretTypeEKS: newEKSProvider(),
+	autoImportSecretTypeAKS: newAKSProvider(),
+	autoImportSecretTypeGKE: newGKEProvider(),
+}
+
+// isCloudAutoImportSecret returns true if the auto-import-secret carries cloud credentials
+func isCloudAutoImportSecret(autoImportSecret *corev1.Secret) bool {
+	_, ok := cloudProviders[autoImportSecret.Type]
+	return ok
+}
+
+// getClientFromCloudCredentials creates the client of the managed cluster from the cloud credentials of
+// the auto-import-secret
+func getClientFromCloudCredentials(autoImportSecret *corev1.Secret) (client.Client, *rest.Config, error) {
+	provider, ok := cloudProviders[autoImportSecret.Type]
+	if !ok {
+		return nil, nil, fmt.Errorf("unsupported auto-import-secret type %s", autoImportSecret.Type)
+	}
+	restConfig, err := provider.RestConfig(autoImportSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+	clientClient, err := client.New(restConfig, client.Options{})
+	if err != nil {
+		return nil, nil, err
+	}
+	return clientClient, restConfig, nil
+}
+
+// getSecretValues returns the values of the keys of the auto-import-secret, an error is returned if a key is missing
+func getSecretValues(autoImportSecret *corev1.Secret, keys ...string) (map[string]string, error) {
+	values := map[string]string{}
+	for _, key := range keys {
+		value, ok := autoImportSecret.Data[key]
+		if !ok || len(value) == 0 {
+			return nil, fmt.Errorf("the key %s is missing in the %s auto-import-secret", key, autoImportSecret.Type)
+		}
+		values[key] = string(value)
+	}
+	return values, nil
+}
+
+// newCloudHTTPClient returns the client of the requests to the cloud APIs
+func newCloudHTTPClient() *http.Client {
+	return &http.Client{Timeout: cloudRequestTimeout}
+}
+
+// doJSONRequest sends the request and decodes the JSON response in out
+func doJSONRequest(httpClient *http.Client, req *http.Request, out interface{}) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s %s failed with status %d: %s", req.Method, req.URL.Path, resp.StatusCode, string(body))
+	}
+	return json.Unmarshal(body, out)
+}
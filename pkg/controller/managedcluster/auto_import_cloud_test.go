@@ -0,0 +1,85 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func Test_getSecretValues(t *testing.T) {
+	secret := &corev1.Secret{
+		Type: autoImportSecretTypeEKS,
+		Data: map[string][]byte{
+			"key1": []byte("value1"),
+			"key2": []byte(""),
+		},
+	}
+	values, err := getSecretValues(secret, "key1")
+	if err != nil || values["key1"] != "value1" {
+		t.Errorf("getSecretValues() = %v, %v", values, err)
+	}
+	if _, err := getSecretValues(secret, "key1", "key2"); err == nil {
+		t.Error("getSecretValues() expected an error as key2 is empty")
+	}
+	if _, err := getSecretValues(secret, "key3"); err == nil {
+		t.Error("getSecretValues() expected an error as key3 is missing")
+	}
+}
+
+func Test_isCloudAutoImportSecret(t *testing.T) {
+	tests := []struct {
+		secretType corev1.SecretType
+		want       bool
+	}{
+		{secretType: autoImportSecretTypeEKS, want: true},
+		{secretType: autoImportSecretTypeAKS, want: true},
+		{secretType: autoImportSecretTypeGKE, want: true},
+		{secretType: corev1.SecretTypeOpaque, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.secretType), func(t *testing.T) {
+			if got := isCloudAutoImportSecret(&corev1.Secret{Type: tt.secretType}); got != tt.want {
+				t.Errorf("isCloudAutoImportSecret() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_cloudProviders_httpClientTimeout(t *testing.T) {
+	clients := map[string]*http.Client{
+		"aks": newAKSProvider().httpClient,
+		"eks": newEKSProvider().httpClient,
+		"gke": newGKEProvider().httpClient,
+	}
+	for name, httpClient := range clients {
+		if httpClient == http.DefaultClient || httpClient.Timeout != cloudRequestTimeout {
+			t.Errorf("the %s provider must use a client with the %s timeout, got %v", name, cloudRequestTimeout, httpClient.Timeout)
+		}
+	}
+}
+
+func Test_doJSONRequest_timeout(t *testing.T) {
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-done
+	}))
+	defer server.Close()
+	defer close(done)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	if err != nil {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+	httpClient := newCloudHTTPClient()
+	httpClient.Timeout = 100 * time.Millisecond
+	if err := doJSONRequest(httpClient, req, &struct{}{}); err == nil {
+		t.Error("doJSONRequest() expected a timeout error")
+	}
+}
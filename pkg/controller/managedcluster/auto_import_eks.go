@@ -0,0 +1,116 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"time"
+
+	awscredentials "github.com/aws/aws-sdk-go/aws/credentials"
+	v4 "github.com/aws/aws-sdk-go/aws/signer/v4"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+// the keys of the auto-import/eks auto-import-secret
+const (
+	eksAccessKeyIDKey     = "aws_access_key_id"
+	eksSecretAccessKeyKey = "aws_secret_access_key"
+	eksSessionTokenKey    = "aws_session_token"
+	eksRegionKey          = "region"
+	eksClusterNameKey     = "cluster_name"
+)
+
+const (
+	// eksTokenPrefix is the prefix of the tokens accepted by the aws-iam-authenticator of the EKS clusters
+	eksTokenPrefix = "k8s-aws-v1."
+	// eksClusterIDHeader is the header binding a token to an EKS cluster
+	eksClusterIDHeader = "x-k8s-aws-id"
+	// eksTokenPresignExpiry is the expiry of the presigned URL of a token, the same as the aws-iam-authenticator
+	eksTokenPresignExpiry = 60 * time.Second
+)
+
+// eksProvider resolves the AWS access keys of an auto-import/eks auto-import-secret, the endpoint and the CA of
+// the EKS cluster are described with the EKS API and its token is a presigned STS GetCallerIdentity request
+type eksProvider struct {
+	eksURL     func(region string) string
+	stsURL     func(region string) string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+func newEKSProvider() *eksProvider {
+	return &eksProvider{
+		eksURL:     func(region string) string { return fmt.Sprintf("https://eks.%s.amazonaws.com", region) },
+		stsURL:     func(region string) string { return fmt.Sprintf("https://sts.%s.amazonaws.com", region) },
+		httpClient: newCloudHTTPClient(),
+		now:        time.Now,
+	}
+}
+
+// eksDescribeClusterResponse is the part of the EKS DescribeCluster response used to connect to the cluster
+type eksDescribeClusterResponse struct {
+	Cluster struct {
+		Endpoint             string `json:"endpoint"`
+		CertificateAuthority struct {
+			Data string `json:"data"`
+		} `json:"certificateAuthority"`
+	} `json:"cluster"`
+}
+
+func (p *eksProvider) RestConfig(autoImportSecret *corev1.Secret) (*rest.Config, error) {
+	values, err := getSecretValues(autoImportSecret, eksAccessKeyIDKey, eksSecretAccessKeyKey, eksRegionKey, eksClusterNameKey)
+	if err != nil {
+		return nil, err
+	}
+	credentials := awscredentials.NewStaticCredentials(values[eksAccessKeyIDKey], values[eksSecretAccessKeyKey],
+		string(autoImportSecret.Data[eksSessionTokenKey]))
+	region, clusterName := values[eksRegionKey], values[eksClusterNameKey]
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/clusters/%s", p.eksURL(region), clusterName), nil)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := v4.NewSigner(credentials).Sign(req, nil, "eks", region, p.now()); err != nil {
+		return nil, fmt.Errorf("failed to sign the request of the EKS cluster %s: %v", clusterName, err)
+	}
+	cluster := &eksDescribeClusterResponse{}
+	if err := doJSONRequest(p.httpClient, req, cluster); err != nil {
+		return nil, fmt.Errorf("failed to describe the EKS cluster %s: %v", clusterName, err)
+	}
+	ca, err := base64.StdEncoding.DecodeString(cluster.Cluster.CertificateAuthority.Data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid certificate authority of the EKS cluster %s: %v", clusterName, err)
+	}
+
+	token, err := p.token(credentials, region, clusterName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rest.Config{
+		Host:        cluster.Cluster.Endpoint,
+		BearerToken: token,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: ca,
+		},
+	}, nil
+}
+
+// token returns the bearer token of the EKS cluster clusterName, a presigned STS GetCallerIdentity URL
+// bound to the cluster. As with the aws-iam-authenticator, the URL is presigned for eksTokenPresignExpiry
+// but the EKS cluster accepts the token for 15 minutes after it is signed.
+func (p *eksProvider) token(credentials *awscredentials.Credentials, region, clusterName string) (string, error) {
+	req, err := http.NewRequest(http.MethodGet, p.stsURL(region)+"/?Action=GetCallerIdentity&Version=2011-06-15", nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set(eksClusterIDHeader, clusterName)
+	if _, err := v4.NewSigner(credentials).Presign(req, nil, "sts", region, eksTokenPresignExpiry, p.now()); err != nil {
+		return "", fmt.Errorf("failed to presign the token of the EKS cluster %s: %v", clusterName, err)
+	}
+	return eksTokenPrefix + base64.RawURLEncoding.EncodeToString([]byte(req.URL.String())), nil
+}
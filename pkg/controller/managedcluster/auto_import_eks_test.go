@@ -0,0 +1,143 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	awscredentials "github.com/aws/aws-sdk-go/aws/credentials"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_eksProvider_token(t *testing.T) {
+	provider := newEKSProvider()
+	provider.now = func() time.Time { return time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC) }
+
+	tests := []struct {
+		name         string
+		sessionToken string
+	}{
+		{
+			name: "access keys",
+		},
+		{
+			name:         "session token",
+			sessionToken: "session",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := provider.token(awscredentials.NewStaticCredentials("AKID", "secret", tt.sessionToken),
+				"eu-west-1", "eks1")
+			if err != nil {
+				t.Fatalf("token() unexpected error %v", err)
+			}
+			presigned, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(token, eksTokenPrefix))
+			if err != nil {
+				t.Fatalf("invalid token %v", err)
+			}
+			u, err := url.Parse(string(presigned))
+			if err != nil {
+				t.Fatalf("invalid presigned URL %v", err)
+			}
+			query := u.Query()
+			if query.Get("X-Amz-Credential") != "AKID/20150830/eu-west-1/sts/aws4_request" ||
+				query.Get("X-Amz-Date") != "20150830T123600Z" ||
+				query.Get("X-Amz-Expires") != "60" ||
+				query.Get("X-Amz-Security-Token") != tt.sessionToken {
+				t.Errorf("unexpected presigned URL %s", u)
+			}
+		})
+	}
+}
+
+func newEKSAutoImportSecret(data map[string]string) *corev1.Secret {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: autoImportSecretName, Namespace: "cluster1"},
+		Type:       autoImportSecretTypeEKS,
+		Data:       map[string][]byte{},
+	}
+	for key, value := range data {
+		secret.Data[key] = []byte(value)
+	}
+	return secret
+}
+
+func Test_eksProvider_RestConfig(t *testing.T) {
+	ca := base64.StdEncoding.EncodeToString([]byte("eks-ca"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/clusters/eks1" ||
+			!strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") ||
+			!strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/eks/aws4_request") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		fmt.Fprintf(w, `{"cluster":{"endpoint":"https://eks1.example.com","certificateAuthority":{"data":"%s"}}}`, ca)
+	}))
+	defer server.Close()
+
+	provider := newEKSProvider()
+	provider.eksURL = func(region string) string { return server.URL }
+
+	validData := map[string]string{
+		eksAccessKeyIDKey:     "AKID",
+		eksSecretAccessKeyKey: "secret",
+		eksRegionKey:          "eu-west-1",
+		eksClusterNameKey:     "eks1",
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		config, err := provider.RestConfig(newEKSAutoImportSecret(validData))
+		if err != nil {
+			t.Fatalf("RestConfig() unexpected error %v", err)
+		}
+		if config.Host != "https://eks1.example.com" || string(config.CAData) != "eks-ca" {
+			t.Errorf("RestConfig() = %s %s", config.Host, string(config.CAData))
+		}
+		if !strings.HasPrefix(config.BearerToken, eksTokenPrefix) {
+			t.Fatalf("RestConfig() token %s is not an EKS token", config.BearerToken)
+		}
+		presigned, err := base64.RawURLEncoding.DecodeString(strings.TrimPrefix(config.BearerToken, eksTokenPrefix))
+		if err != nil {
+			t.Fatalf("invalid token %v", err)
+		}
+		u, err := url.Parse(string(presigned))
+		if err != nil {
+			t.Fatalf("invalid presigned URL %v", err)
+		}
+		query := u.Query()
+		if u.Host != "sts.eu-west-1.amazonaws.com" ||
+			query.Get("Action") != "GetCallerIdentity" ||
+			query.Get("X-Amz-SignedHeaders") != "host;x-k8s-aws-id" ||
+			query.Get("X-Amz-Signature") == "" {
+			t.Errorf("unexpected presigned URL %s", u)
+		}
+	})
+
+	t.Run("missing key", func(t *testing.T) {
+		data := map[string]string{eksAccessKeyIDKey: "AKID", eksSecretAccessKeyKey: "secret", eksRegionKey: "eu-west-1"}
+		if _, err := provider.RestConfig(newEKSAutoImportSecret(data)); err == nil {
+			t.Error("RestConfig() expected an error as the cluster name is missing")
+		}
+	})
+
+	t.Run("unknown cluster", func(t *testing.T) {
+		data := map[string]string{}
+		for key, value := range validData {
+			data[key] = value
+		}
+		data[eksClusterNameKey] = "eks2"
+		if _, err := provider.RestConfig(newEKSAutoImportSecret(data)); err == nil {
+			t.Error("RestConfig() expected an error as the cluster is not found")
+		}
+	})
+}
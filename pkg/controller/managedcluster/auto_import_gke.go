@@ -0,0 +1,113 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+// the keys of the auto-import/gke auto-import-secret
+const (
+	gkeServiceAccountKey = "service_account.json"
+	gkeProjectKey        = "project"
+	gkeLocationKey       = "location"
+	gkeClusterNameKey    = "cluster_name"
+)
+
+const (
+	gkeDefaultTokenURL = "https://oauth2.googleapis.com/token"
+	gkeScope           = "https://www.googleapis.com/auth/cloud-platform"
+)
+
+// gkeProvider resolves the service account key of an auto-import/gke auto-import-secret, the endpoint and
+// the CA of the GKE cluster are read with the Kubernetes Engine API and its token is an OAuth2 access token
+// of the service account
+type gkeProvider struct {
+	containerURL string
+	httpClient   *http.Client
+}
+
+func newGKEProvider() *gkeProvider {
+	return &gkeProvider{
+		containerURL: "https://container.googleapis.com",
+		httpClient:   newCloudHTTPClient(),
+	}
+}
+
+// gkeServiceAccount is the part of the JSON key of a service account used to get its access tokens
+type gkeServiceAccount struct {
+	ClientEmail  string `json:"client_email"`
+	PrivateKey   string `json:"private_key"`
+	PrivateKeyID string `json:"private_key_id"`
+	TokenURI     string `json:"token_uri"`
+}
+
+// gkeCluster is the part of the GKE cluster used to connect to the cluster
+type gkeCluster struct {
+	Endpoint   string `json:"endpoint"`
+	MasterAuth struct {
+		ClusterCACertificate string `json:"clusterCaCertificate"`
+	} `json:"masterAuth"`
+}
+
+func (p *gkeProvider) RestConfig(autoImportSecret *corev1.Secret) (*rest.Config, error) {
+	values, err := getSecretValues(autoImportSecret, gkeServiceAccountKey, gkeProjectKey, gkeLocationKey, gkeClusterNameKey)
+	if err != nil {
+		return nil, err
+	}
+	clusterName := values[gkeClusterNameKey]
+
+	serviceAccount := &gkeServiceAccount{}
+	if err := json.Unmarshal([]byte(values[gkeServiceAccountKey]), serviceAccount); err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", gkeServiceAccountKey, err)
+	}
+	tokenURL := serviceAccount.TokenURI
+	if tokenURL == "" {
+		tokenURL = gkeDefaultTokenURL
+	}
+	credentials := &jwt.Config{
+		Email:        serviceAccount.ClientEmail,
+		PrivateKey:   []byte(serviceAccount.PrivateKey),
+		PrivateKeyID: serviceAccount.PrivateKeyID,
+		Scopes:       []string{gkeScope},
+		TokenURL:     tokenURL,
+	}
+	ctx := context.WithValue(context.TODO(), oauth2.HTTPClient, p.httpClient)
+	token, err := credentials.TokenSource(ctx).Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the access token of %s: %v", serviceAccount.ClientEmail, err)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/v1/projects/%s/locations/%s/clusters/%s",
+		p.containerURL, values[gkeProjectKey], values[gkeLocationKey], clusterName), nil)
+	if err != nil {
+		return nil, err
+	}
+	token.SetAuthHeader(req)
+	cluster := &gkeCluster{}
+	if err := doJSONRequest(p.httpClient, req, cluster); err != nil {
+		return nil, fmt.Errorf("failed to get the GKE cluster %s: %v", clusterName, err)
+	}
+	ca, err := base64.StdEncoding.DecodeString(cluster.MasterAuth.ClusterCACertificate)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CA certificate of the GKE cluster %s: %v", clusterName, err)
+	}
+
+	return &rest.Config{
+		Host:        "https://" + cluster.Endpoint,
+		BearerToken: token.AccessToken,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: ca,
+		},
+	}, nil
+}
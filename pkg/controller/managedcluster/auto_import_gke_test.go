@@ -0,0 +1,86 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func Test_gkeProvider_RestConfig(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	privateKey := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil || r.PostForm.Get("assertion") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"gcp-token","token_type":"Bearer","expires_in":3600}`)
+	})
+	mux.HandleFunc("/v1/projects/project1/locations/europe-west1/clusters/gke1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer gcp-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprintf(w, `{"endpoint":"10.0.0.1","masterAuth":{"clusterCaCertificate":"%s"}}`,
+			base64.StdEncoding.EncodeToString([]byte("gke-ca")))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	provider := newGKEProvider()
+	provider.containerURL = server.URL
+
+	serviceAccount, err := json.Marshal(gkeServiceAccount{
+		ClientEmail:  "import@project1.iam.gserviceaccount.com",
+		PrivateKey:   string(privateKey),
+		PrivateKeyID: "key1",
+		TokenURI:     server.URL + "/token",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+
+	newSecret := func(clusterName string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: autoImportSecretName, Namespace: "cluster1"},
+			Type:       autoImportSecretTypeGKE,
+			Data: map[string][]byte{
+				gkeServiceAccountKey: serviceAccount,
+				gkeProjectKey:        []byte("project1"),
+				gkeLocationKey:       []byte("europe-west1"),
+				gkeClusterNameKey:    []byte(clusterName),
+			},
+		}
+	}
+
+	config, err := provider.RestConfig(newSecret("gke1"))
+	if err != nil {
+		t.Fatalf("RestConfig() unexpected error %v", err)
+	}
+	if config.Host != "https://10.0.0.1" || config.BearerToken != "gcp-token" || string(config.CAData) != "gke-ca" {
+		t.Errorf("RestConfig() = %s %s %s", config.Host, config.BearerToken, string(config.CAData))
+	}
+
+	if _, err := provider.RestConfig(newSecret("gke2")); err == nil {
+		t.Error("RestConfig() expected an error as the cluster is not found")
+	}
+}
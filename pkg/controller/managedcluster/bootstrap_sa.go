@@ -11,11 +11,18 @@ import (
 
 	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 )
 
-const bootstrapServiceAccountNamePostfix = "-bootstrap-sa"
+const (
+	bootstrapServiceAccountNamePostfix = "-bootstrap-sa"
+	bootstrapSATokenSecretNamePostfix  = "-token"
+)
 
 func bootstrapServiceAccountNsN(managedCluster *clusterv1.ManagedCluster) (types.NamespacedName, error) {
 	if managedCluster == nil {
@@ -30,6 +37,38 @@ func bootstrapServiceAccountNsN(managedCluster *clusterv1.ManagedCluster) (types
 }
 
 func getBootstrapSecret(
+	client client.Client,
+	managedCluster *clusterv1.ManagedCluster) (*corev1.Secret, error) {
+	tokenConfig, err := getBootstrapTokenConfig()
+	if err != nil {
+		return nil, err
+	}
+	if tokenConfig != nil {
+		secret, err := getBootstrapTokenSecret(client, managedCluster)
+		if err != nil {
+			return nil, err
+		}
+		if secret == nil {
+			return nil, fmt.Errorf("bootstrap token of %s not found", managedCluster.Name)
+		}
+		return secret, nil
+	}
+
+	secret, err := getBootstrapSATokenSecret(client, managedCluster)
+	if err != nil {
+		return nil, err
+	}
+	if secret == nil || len(secret.Data["token"]) == 0 {
+		saNsN, _ := bootstrapServiceAccountNsN(managedCluster)
+		return nil, fmt.Errorf("the token of the service account %s/%s is not ready", saNsN.Namespace, saNsN.Name)
+	}
+	return secret, nil
+}
+
+// getBootstrapSATokenSecret returns the service account token secret of the bootstrap service account,
+// either created by the token controller or created explicitly by ensureBootstrapSATokenSecret.
+// nil is returned if there is no service account token secret.
+func getBootstrapSATokenSecret(
 	client client.Client,
 	managedCluster *clusterv1.ManagedCluster) (*corev1.Secret, error) {
 	sa := &corev1.ServiceAccount{}
@@ -41,7 +80,6 @@ func getBootstrapSecret(
 	if err := client.Get(context.TODO(), saNsN, sa); err != nil {
 		return nil, err
 	}
-	var secret *corev1.Secret
 	log.Info("sa", "sa", sa.Name, "sa.Secrets", sa.Secrets)
 	for _, objectRef := range sa.Secrets {
 		log.Info("Bootstrap Service Account secret",
@@ -55,22 +93,74 @@ func getBootstrapSecret(
 			prefix = prefix[:37]
 		}
 		if strings.HasPrefix(objectRef.Name, prefix) {
-			secret = &corev1.Secret{}
+			secret := &corev1.Secret{}
 			err = client.Get(context.TODO(), types.NamespacedName{Name: objectRef.Name, Namespace: managedCluster.Name}, secret)
 			if err != nil {
 				continue
 			}
 			if secret.Type == corev1.SecretTypeServiceAccountToken {
-				break
+				return secret, nil
 			}
 		}
 	}
-	if secret == nil {
-		return nil, fmt.Errorf("secret with prefix %s amd type %s not found in service account %s/%s",
-			managedCluster.Name+bootstrapServiceAccountNamePostfix,
-			corev1.SecretTypeServiceAccountToken,
-			saNsN.Name,
-			managedCluster.Name)
+
+	// since Kubernetes 1.24 the token controller doesn't create the service account token secrets,
+	// look for the secret created explicitly
+	secret := &corev1.Secret{}
+	err = client.Get(context.TODO(), bootstrapSATokenSecretNsN(saNsN), secret)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if secret.Type != corev1.SecretTypeServiceAccountToken {
+		return nil, fmt.Errorf("secret %s/%s should have type=%s",
+			secret.Namespace, secret.Name, corev1.SecretTypeServiceAccountToken)
+	}
+	return secret, nil
+}
+
+func bootstrapSATokenSecretNsN(saNsN types.NamespacedName) types.NamespacedName {
+	return types.NamespacedName{
+		Name:      saNsN.Name + bootstrapSATokenSecretNamePostfix,
+		Namespace: saNsN.Namespace,
+	}
+}
+
+// ensureBootstrapSATokenSecret returns the service account token secret of the bootstrap service account,
+// if there is none it creates a secret owned by the managedCluster and annotated with the service account name
+// which is populated by the token controller. The returned secret may not be populated yet.
+func ensureBootstrapSATokenSecret(
+	client client.Client,
+	scheme *runtime.Scheme,
+	managedCluster *clusterv1.ManagedCluster) (*corev1.Secret, error) {
+	secret, err := getBootstrapSATokenSecret(client, managedCluster)
+	if err != nil || secret != nil {
+		return secret, err
+	}
+
+	saNsN, err := bootstrapServiceAccountNsN(managedCluster)
+	if err != nil {
+		return nil, err
+	}
+	secretNsN := bootstrapSATokenSecretNsN(saNsN)
+	secret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretNsN.Name,
+			Namespace: secretNsN.Namespace,
+			Annotations: map[string]string{
+				corev1.ServiceAccountNameKey: saNsN.Name,
+			},
+		},
+		Type: corev1.SecretTypeServiceAccountToken,
+	}
+	if err := controllerutil.SetControllerReference(managedCluster, secret, scheme); err != nil {
+		return nil, err
+	}
+	log.Info("Create the bootstrap service account token secret", "name", secret.Name, "namespace", secret.Namespace)
+	if err := client.Create(context.TODO(), secret); err != nil && !errors.IsAlreadyExists(err) {
+		return nil, err
 	}
 	return secret, nil
 }
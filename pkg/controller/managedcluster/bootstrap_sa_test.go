@@ -9,8 +9,12 @@ import (
 	"testing"
 
 	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 func Test_bootstrapServiceAccountNsN(t *testing.T) {
@@ -62,3 +66,97 @@ func Test_bootstrapServiceAccountNsN(t *testing.T) {
 		})
 	}
 }
+
+func Test_ensureBootstrapSATokenSecret(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{})
+
+	managedCluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster1",
+		},
+	}
+	sa := &corev1.ServiceAccount{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1-bootstrap-sa",
+			Namespace: "cluster1",
+		},
+	}
+	legacySA := sa.DeepCopy()
+	legacySA.Secrets = []corev1.ObjectReference{{Name: "cluster1-bootstrap-sa-token-5pw5c"}}
+	legacySecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1-bootstrap-sa-token-5pw5c",
+			Namespace: "cluster1",
+		},
+		Data: map[string][]byte{"token": []byte("legacy-token")},
+		Type: corev1.SecretTypeServiceAccountToken,
+	}
+	populatedSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1-bootstrap-sa-token",
+			Namespace: "cluster1",
+		},
+		Data: map[string][]byte{"token": []byte("explicit-token")},
+		Type: corev1.SecretTypeServiceAccountToken,
+	}
+
+	tests := []struct {
+		name           string
+		objs           []runtime.Object
+		wantSecretName string
+		wantToken      string
+	}{
+		{
+			name:           "legacy service account token secret",
+			objs:           []runtime.Object{legacySA, legacySecret},
+			wantSecretName: "cluster1-bootstrap-sa-token-5pw5c",
+			wantToken:      "legacy-token",
+		},
+		{
+			name:           "create the service account token secret",
+			objs:           []runtime.Object{sa},
+			wantSecretName: "cluster1-bootstrap-sa-token",
+		},
+		{
+			name:           "populated service account token secret",
+			objs:           []runtime.Object{sa, populatedSecret},
+			wantSecretName: "cluster1-bootstrap-sa-token",
+			wantToken:      "explicit-token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(testscheme, tt.objs...)
+			secret, err := ensureBootstrapSATokenSecret(c, testscheme, managedCluster)
+			if err != nil {
+				t.Errorf("ensureBootstrapSATokenSecret() unexpected error %v", err)
+				return
+			}
+			if secret.Name != tt.wantSecretName {
+				t.Errorf("ensureBootstrapSATokenSecret() = %v, want %v", secret.Name, tt.wantSecretName)
+			}
+			if secret.Type != corev1.SecretTypeServiceAccountToken {
+				t.Errorf("ensureBootstrapSATokenSecret() type = %v", secret.Type)
+			}
+
+			got, err := getBootstrapSecret(c, managedCluster)
+			if tt.wantToken == "" {
+				if err == nil {
+					t.Errorf("getBootstrapSecret() expected an error as the token is not populated")
+				}
+				if secret.Annotations[corev1.ServiceAccountNameKey] != "cluster1-bootstrap-sa" {
+					t.Errorf("ensureBootstrapSATokenSecret() annotations = %v", secret.Annotations)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("getBootstrapSecret() unexpected error %v", err)
+				return
+			}
+			if string(got.Data["token"]) != tt.wantToken {
+				t.Errorf("getBootstrapSecret() token = %s, want %s", got.Data["token"], tt.wantToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,315 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// bootstrapTokenExpirationEnvVarName enables the bootstrap tokens requested with the TokenRequest API,
+	// the value is the lifetime of the tokens (for example 24h)
+	bootstrapTokenExpirationEnvVarName = "BOOTSTRAP_TOKEN_EXPIRATION"
+	// bootstrapTokenRotationEnvVarName is the duration after which a bootstrap token is rotated,
+	// it defaults to 80% of the token lifetime
+	bootstrapTokenRotationEnvVarName = "BOOTSTRAP_TOKEN_ROTATION"
+
+	// bootstrapTokenLabel labels the secrets the bootstrap tokens are bound to, the value is the cluster name
+	bootstrapTokenLabel = "import.open-cluster-management.io/bootstrap-token"
+	// bootstrapTokenExpirationAnnotation records the expiration (RFC3339) of the bootstrap token
+	// on its bound secret and on the import secret
+	bootstrapTokenExpirationAnnotation = "import.open-cluster-management.io/bootstrap-token-expiration"
+
+	bootstrapTokenSecretNamePostfix = "-bootstrap-token-"
+	kubeRootCAConfigMapName         = "kube-root-ca.crt"
+
+	// minBootstrapTokenExpiration is the minimum lifetime of a token accepted by the TokenRequest API
+	minBootstrapTokenExpiration = 10 * time.Minute
+)
+
+// bootstrapTokenConfig defines the lifetime and the rotation of the bootstrap tokens
+type bootstrapTokenConfig struct {
+	expiration time.Duration
+	rotation   time.Duration
+}
+
+// getBootstrapTokenConfig returns the configuration of the bootstrap tokens,
+// nil is returned if the bootstrap tokens are not requested with the TokenRequest API
+func getBootstrapTokenConfig() (*bootstrapTokenConfig, error) {
+	value := os.Getenv(bootstrapTokenExpirationEnvVarName)
+	if value == "" {
+		return nil, nil
+	}
+	expiration, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %v", bootstrapTokenExpirationEnvVarName, err)
+	}
+	if expiration < minBootstrapTokenExpiration {
+		return nil, fmt.Errorf("%s must be at least %s", bootstrapTokenExpirationEnvVarName, minBootstrapTokenExpiration)
+	}
+
+	config := &bootstrapTokenConfig{
+		expiration: expiration,
+		rotation:   expiration * 4 / 5,
+	}
+	if value := os.Getenv(bootstrapTokenRotationEnvVarName); value != "" {
+		config.rotation, err = time.ParseDuration(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", bootstrapTokenRotationEnvVarName, err)
+		}
+		if config.rotation <= 0 || config.rotation > expiration {
+			return nil, fmt.Errorf("%s must be positive and lower than %s",
+				bootstrapTokenRotationEnvVarName, bootstrapTokenExpirationEnvVarName)
+		}
+	}
+	return config, nil
+}
+
+// rotationTime returns the time after which the token of the secret must be rotated
+func (c *bootstrapTokenConfig) rotationTime(secret *corev1.Secret) time.Time {
+	expiration, err := getBootstrapTokenExpiration(secret)
+	if err != nil {
+		return time.Time{}
+	}
+	return expiration.Add(c.rotation - c.expiration)
+}
+
+func getBootstrapTokenExpiration(secret *corev1.Secret) (time.Time, error) {
+	return time.Parse(time.RFC3339, secret.GetAnnotations()[bootstrapTokenExpirationAnnotation])
+}
+
+// listBootstrapTokenSecrets returns the secrets the bootstrap tokens of the managedCluster are bound to,
+// sorted from the newest to the oldest. The client must list the secrets without cache, see customClient,
+// to find the secret of a token requested by the previous reconcile.
+func listBootstrapTokenSecrets(c client.Client, managedCluster *clusterv1.ManagedCluster) ([]corev1.Secret, error) {
+	secrets := &corev1.SecretList{}
+	if err := c.List(context.TODO(), secrets,
+		client.InNamespace(managedCluster.Name),
+		client.MatchingLabels{bootstrapTokenLabel: managedCluster.Name}); err != nil {
+		return nil, err
+	}
+	items := secrets.Items
+	sort.SliceStable(items, func(i, j int) bool {
+		ei, _ := getBootstrapTokenExpiration(&items[i])
+		ej, _ := getBootstrapTokenExpiration(&items[j])
+		return ei.After(ej)
+	})
+	return items, nil
+}
+
+// getBootstrapTokenSecret returns the newest secret holding a bootstrap token which is not expired,
+// nil is returned if there is none
+func getBootstrapTokenSecret(client client.Client, managedCluster *clusterv1.ManagedCluster) (*corev1.Secret, error) {
+	secrets, err := listBootstrapTokenSecrets(client, managedCluster)
+	if err != nil {
+		return nil, err
+	}
+	for i := range secrets {
+		expiration, err := getBootstrapTokenExpiration(&secrets[i])
+		if err != nil || !time.Now().Before(expiration) || len(secrets[i].Data["token"]) == 0 {
+			continue
+		}
+		return &secrets[i], nil
+	}
+	return nil, nil
+}
+
+// ensureBootstrapToken requests a new bootstrap token if there is no token or if the current token must be rotated.
+// It returns the secret holding the current token and the duration until its rotation.
+func ensureBootstrapToken(
+	client client.Client,
+	kubeClient kubernetes.Interface,
+	scheme *runtime.Scheme,
+	managedCluster *clusterv1.ManagedCluster,
+	config *bootstrapTokenConfig,
+) (*corev1.Secret, time.Duration, error) {
+	secret, err := getBootstrapTokenSecret(client, managedCluster)
+	if err != nil {
+		return nil, 0, err
+	}
+	if secret != nil {
+		if untilRotation := time.Until(config.rotationTime(secret)); untilRotation > 0 {
+			return secret, untilRotation, nil
+		}
+		log.Info("Rotate the bootstrap token", "secret", secret.Name, "managedcluster", managedCluster.Name)
+	}
+
+	secret, err = requestBootstrapToken(client, kubeClient, scheme, managedCluster, config)
+	if err != nil {
+		return nil, 0, err
+	}
+	return secret, config.rotation, nil
+}
+
+// requestBootstrapToken requests a token of the bootstrap service account with the TokenRequest API,
+// the token is bound to a new secret owned by the managedCluster and stored in it.
+func requestBootstrapToken(
+	client client.Client,
+	kubeClient kubernetes.Interface,
+	scheme *runtime.Scheme,
+	managedCluster *clusterv1.ManagedCluster,
+	config *bootstrapTokenConfig,
+) (*corev1.Secret, error) {
+	saNsN, err := bootstrapServiceAccountNsN(managedCluster)
+	if err != nil {
+		return nil, err
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: managedCluster.Name + bootstrapTokenSecretNamePostfix,
+			Namespace:    managedCluster.Name,
+			Labels: map[string]string{
+				bootstrapTokenLabel: managedCluster.Name,
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	if err := controllerutil.SetControllerReference(managedCluster, secret, scheme); err != nil {
+		return nil, err
+	}
+	if err := client.Create(context.TODO(), secret); err != nil {
+		return nil, err
+	}
+
+	expirationSeconds := int64(config.expiration.Seconds())
+	tokenRequest, err := kubeClient.CoreV1().ServiceAccounts(saNsN.Namespace).CreateToken(
+		context.TODO(),
+		saNsN.Name,
+		&authenticationv1.TokenRequest{
+			Spec: authenticationv1.TokenRequestSpec{
+				ExpirationSeconds: &expirationSeconds,
+				// the token is revoked when the secret is deleted
+				BoundObjectRef: &authenticationv1.BoundObjectReference{
+					Kind:       "Secret",
+					APIVersion: "v1",
+					Name:       secret.Name,
+					UID:        secret.UID,
+				},
+			},
+		},
+		metav1.CreateOptions{},
+	)
+	if err != nil {
+		log.Error(err, "Failed to request a bootstrap token", "serviceaccount", saNsN)
+		if errDelete := client.Delete(context.TODO(), secret); errDelete != nil && !errors.IsNotFound(errDelete) {
+			log.Error(errDelete, "Failed to delete the bootstrap token secret", "name", secret.Name)
+		}
+		return nil, err
+	}
+
+	caData, err := getKubeRootCA(client, managedCluster.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Annotations = map[string]string{
+		bootstrapTokenExpirationAnnotation: tokenRequest.Status.ExpirationTimestamp.UTC().Format(time.RFC3339),
+	}
+	secret.Data = map[string][]byte{
+		"token": []byte(tokenRequest.Status.Token),
+	}
+	if len(caData) > 0 {
+		secret.Data["ca.crt"] = caData
+	}
+	if err := client.Update(context.TODO(), secret); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// getKubeRootCA returns the ca.crt of the kube-root-ca.crt configmap of the namespace, nil if it doesn't exist
+func getKubeRootCA(client client.Client, namespace string) ([]byte, error) {
+	cm := &corev1.ConfigMap{}
+	if err := client.Get(context.TODO(), types.NamespacedName{Name: kubeRootCAConfigMapName, Namespace: namespace}, cm); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return []byte(cm.Data["ca.crt"]), nil
+}
+
+// revokeBootstrapTokens deletes the secrets of the expired bootstrap tokens and, once the managedCluster
+// has joined the hub, the secrets of all the tokens except the current one, which revokes the tokens.
+func revokeBootstrapTokens(
+	client client.Client,
+	managedCluster *clusterv1.ManagedCluster,
+	current *corev1.Secret,
+) error {
+	secrets, err := listBootstrapTokenSecrets(client, managedCluster)
+	if err != nil {
+		return err
+	}
+	joined := !checkOffLine(managedCluster)
+	for i := range secrets {
+		if current != nil && secrets[i].Name == current.Name {
+			continue
+		}
+		expiration, err := getBootstrapTokenExpiration(&secrets[i])
+		if !joined && err == nil && time.Now().Before(expiration) {
+			continue
+		}
+		log.Info("Revoke the bootstrap token", "secret", secrets[i].Name, "managedcluster", managedCluster.Name)
+		if err := client.Delete(context.TODO(), &secrets[i]); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+	return nil
+}
+
+// setImportSecretTokenExpiration records the expiration of the bootstrap token on the import secret
+func setImportSecretTokenExpiration(
+	client client.Client,
+	managedCluster *clusterv1.ManagedCluster,
+	tokenSecret *corev1.Secret,
+) error {
+	secretNsN, err := importSecretNsN(managedCluster)
+	if err != nil {
+		return err
+	}
+	importSecret := &corev1.Secret{}
+	if err := client.Get(context.TODO(), secretNsN, importSecret); err != nil {
+		return err
+	}
+	expiration := tokenSecret.GetAnnotations()[bootstrapTokenExpirationAnnotation]
+	if importSecret.GetAnnotations()[bootstrapTokenExpirationAnnotation] == expiration {
+		return nil
+	}
+	annotations := importSecret.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[bootstrapTokenExpirationAnnotation] = expiration
+	importSecret.SetAnnotations(annotations)
+	return client.Update(context.TODO(), importSecret)
+}
+
+// requeueForTokenRotation returns the result of a reconcile requeued at the latest when the bootstrap token
+// has to be rotated
+func requeueForTokenRotation(result reconcile.Result, untilTokenRotation time.Duration) reconcile.Result {
+	if untilTokenRotation <= 0 || (result.Requeue && result.RequeueAfter == 0) {
+		return result
+	}
+	if result.RequeueAfter == 0 || untilTokenRotation < result.RequeueAfter {
+		result.RequeueAfter = untilTokenRotation
+	}
+	return result
+}
@@ -0,0 +1,364 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	fakeclientset "k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	clienttesting "k8s.io/client-go/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newBootstrapTokenSecret(name, cluster string, expiration time.Time) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: cluster,
+			Labels:    map[string]string{bootstrapTokenLabel: cluster},
+			Annotations: map[string]string{
+				bootstrapTokenExpirationAnnotation: expiration.UTC().Format(time.RFC3339),
+			},
+		},
+		Data: map[string][]byte{"token": []byte(name)},
+	}
+}
+
+func newTokenRequestClientset(token string, expiration time.Time) *fakeclientset.Clientset {
+	kubeClient := fakeclientset.NewSimpleClientset()
+	kubeClient.PrependReactor("create", "serviceaccounts",
+		func(action clienttesting.Action) (bool, runtime.Object, error) {
+			if action.GetSubresource() != "token" {
+				return false, nil, nil
+			}
+			return true, &authenticationv1.TokenRequest{
+				Status: authenticationv1.TokenRequestStatus{
+					Token:               token,
+					ExpirationTimestamp: metav1.NewTime(expiration),
+				},
+			}, nil
+		})
+	return kubeClient
+}
+
+func Test_getBootstrapTokenConfig(t *testing.T) {
+	tests := []struct {
+		name       string
+		expiration string
+		rotation   string
+		want       *bootstrapTokenConfig
+		wantErr    bool
+	}{
+		{
+			name: "disabled",
+		},
+		{
+			name:       "default rotation",
+			expiration: "10h",
+			want:       &bootstrapTokenConfig{expiration: 10 * time.Hour, rotation: 8 * time.Hour},
+		},
+		{
+			name:       "custom rotation",
+			expiration: "10h",
+			rotation:   "1h",
+			want:       &bootstrapTokenConfig{expiration: 10 * time.Hour, rotation: time.Hour},
+		},
+		{
+			name:       "expiration too short",
+			expiration: "1m",
+			wantErr:    true,
+		},
+		{
+			name:       "rotation after expiration",
+			expiration: "1h",
+			rotation:   "2h",
+			wantErr:    true,
+		},
+		{
+			name:       "invalid expiration",
+			expiration: "1 day",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(bootstrapTokenExpirationEnvVarName, tt.expiration)
+			defer os.Unsetenv(bootstrapTokenExpirationEnvVarName)
+			os.Setenv(bootstrapTokenRotationEnvVarName, tt.rotation)
+			defer os.Unsetenv(bootstrapTokenRotationEnvVarName)
+
+			got, err := getBootstrapTokenConfig()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getBootstrapTokenConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if (got == nil) != (tt.want == nil) || (got != nil && *got != *tt.want) {
+				t.Errorf("getBootstrapTokenConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_ensureBootstrapToken(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{})
+
+	managedCluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster1",
+		},
+	}
+	config := &bootstrapTokenConfig{expiration: 10 * time.Hour, rotation: 8 * time.Hour}
+	rootCA := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      kubeRootCAConfigMapName,
+			Namespace: "cluster1",
+		},
+		Data: map[string]string{"ca.crt": "root-ca-data"},
+	}
+
+	tests := []struct {
+		name      string
+		objs      []runtime.Object
+		wantToken string
+	}{
+		{
+			name:      "request a token",
+			objs:      []runtime.Object{rootCA},
+			wantToken: "new-token",
+		},
+		{
+			name: "keep the current token",
+			objs: []runtime.Object{
+				rootCA,
+				newBootstrapTokenSecret("current", "cluster1", time.Now().Add(5*time.Hour)),
+				newBootstrapTokenSecret("old", "cluster1", time.Now().Add(time.Hour)),
+			},
+			wantToken: "current",
+		},
+		{
+			name: "rotate the token",
+			objs: []runtime.Object{
+				rootCA,
+				newBootstrapTokenSecret("current", "cluster1", time.Now().Add(time.Hour)),
+			},
+			wantToken: "new-token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(testscheme, tt.objs...)
+			kubeClient := newTokenRequestClientset("new-token", time.Now().Add(10*time.Hour))
+
+			secret, untilRotation, err := ensureBootstrapToken(c, kubeClient, testscheme, managedCluster, config)
+			if err != nil {
+				t.Errorf("ensureBootstrapToken() unexpected error %v", err)
+				return
+			}
+			if string(secret.Data["token"]) != tt.wantToken {
+				t.Errorf("ensureBootstrapToken() token = %s, want %s", secret.Data["token"], tt.wantToken)
+			}
+			if untilRotation <= 0 || untilRotation > config.rotation {
+				t.Errorf("ensureBootstrapToken() unexpected rotation in %v", untilRotation)
+			}
+
+			got, err := getBootstrapSecretWithTokenConfig(c, managedCluster)
+			if err != nil {
+				t.Errorf("getBootstrapSecret() unexpected error %v", err)
+				return
+			}
+			if got.Name != secret.Name {
+				t.Errorf("getBootstrapSecret() = %s, want %s", got.Name, secret.Name)
+			}
+			if tt.wantToken == "new-token" && string(got.Data["ca.crt"]) != "root-ca-data" {
+				t.Errorf("getBootstrapSecret() ca.crt = %s, want root-ca-data", got.Data["ca.crt"])
+			}
+		})
+	}
+}
+
+// getBootstrapSecretWithTokenConfig gets the bootstrap secret with the bootstrap tokens enabled
+func getBootstrapSecretWithTokenConfig(c client.Client, managedCluster *clusterv1.ManagedCluster) (*corev1.Secret, error) {
+	os.Setenv(bootstrapTokenExpirationEnvVarName, "10h")
+	defer os.Unsetenv(bootstrapTokenExpirationEnvVarName)
+	return getBootstrapSecret(c, managedCluster)
+}
+
+func Test_revokeBootstrapTokens(t *testing.T) {
+	current := newBootstrapTokenSecret("current", "cluster1", time.Now().Add(5*time.Hour))
+	previous := newBootstrapTokenSecret("previous", "cluster1", time.Now().Add(time.Hour))
+	expired := newBootstrapTokenSecret("expired", "cluster1", time.Now().Add(-time.Hour))
+
+	tests := []struct {
+		name        string
+		available   metav1.ConditionStatus
+		wantSecrets []string
+	}{
+		{
+			name:        "cluster not joined",
+			available:   metav1.ConditionUnknown,
+			wantSecrets: []string{"current", "previous"},
+		},
+		{
+			name:        "cluster joined",
+			available:   metav1.ConditionTrue,
+			wantSecrets: []string{"current"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedCluster := &clusterv1.ManagedCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster1",
+				},
+				Status: clusterv1.ManagedClusterStatus{
+					Conditions: []metav1.Condition{
+						{Type: clusterv1.ManagedClusterConditionAvailable, Status: tt.available},
+					},
+				},
+			}
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, current.DeepCopy(), previous.DeepCopy(), expired.DeepCopy())
+
+			if err := revokeBootstrapTokens(c, managedCluster, current); err != nil {
+				t.Errorf("revokeBootstrapTokens() unexpected error %v", err)
+				return
+			}
+			secrets, err := listBootstrapTokenSecrets(c, managedCluster)
+			if err != nil {
+				t.Errorf("listBootstrapTokenSecrets() unexpected error %v", err)
+				return
+			}
+			got := []string{}
+			for _, secret := range secrets {
+				got = append(got, secret.Name)
+			}
+			if len(got) != len(tt.wantSecrets) {
+				t.Errorf("revokeBootstrapTokens() remaining secrets = %v, want %v", got, tt.wantSecrets)
+				return
+			}
+			for i := range got {
+				if got[i] != tt.wantSecrets[i] {
+					t.Errorf("revokeBootstrapTokens() remaining secrets = %v, want %v", got, tt.wantSecrets)
+				}
+			}
+		})
+	}
+}
+
+func Test_setImportSecretTokenExpiration(t *testing.T) {
+	managedCluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster1",
+		},
+	}
+	importSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1" + importSecretNamePostfix,
+			Namespace: "cluster1",
+		},
+	}
+	expiration := time.Now().Add(time.Hour)
+	c := fake.NewFakeClientWithScheme(scheme.Scheme, importSecret)
+
+	err := setImportSecretTokenExpiration(c, managedCluster, newBootstrapTokenSecret("current", "cluster1", expiration))
+	if err != nil {
+		t.Errorf("setImportSecretTokenExpiration() unexpected error %v", err)
+		return
+	}
+	got := &corev1.Secret{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: importSecret.Name, Namespace: "cluster1"}, got); err != nil {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+	if got.Annotations[bootstrapTokenExpirationAnnotation] != expiration.UTC().Format(time.RFC3339) {
+		t.Errorf("setImportSecretTokenExpiration() annotation = %v, want %v",
+			got.Annotations[bootstrapTokenExpirationAnnotation], expiration.UTC().Format(time.RFC3339))
+	}
+}
+
+func Test_requestBootstrapToken_sameSecond(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{})
+
+	managedCluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster1",
+		},
+	}
+	config := &bootstrapTokenConfig{expiration: 10 * time.Hour, rotation: 8 * time.Hour}
+	c := fake.NewFakeClientWithScheme(testscheme)
+	kubeClient := newTokenRequestClientset("new-token", time.Now().Add(10*time.Hour))
+
+	first, err := requestBootstrapToken(c, kubeClient, testscheme, managedCluster, config)
+	if err != nil {
+		t.Errorf("requestBootstrapToken() unexpected error %v", err)
+		return
+	}
+	second, err := requestBootstrapToken(c, kubeClient, testscheme, managedCluster, config)
+	if err != nil {
+		t.Errorf("requestBootstrapToken() unexpected error %v", err)
+		return
+	}
+	if first.Name == second.Name {
+		t.Errorf("requestBootstrapToken() requested two tokens bound to the same secret %s", first.Name)
+	}
+}
+
+func Test_requeueForTokenRotation(t *testing.T) {
+	tests := []struct {
+		name               string
+		result             reconcile.Result
+		untilTokenRotation time.Duration
+		want               reconcile.Result
+	}{
+		{
+			name:   "tokens disabled",
+			result: reconcile.Result{},
+			want:   reconcile.Result{},
+		},
+		{
+			name:               "imported",
+			result:             reconcile.Result{},
+			untilTokenRotation: time.Hour,
+			want:               reconcile.Result{RequeueAfter: time.Hour},
+		},
+		{
+			name:               "requeued before the rotation",
+			result:             reconcile.Result{RequeueAfter: time.Minute},
+			untilTokenRotation: time.Hour,
+			want:               reconcile.Result{RequeueAfter: time.Minute},
+		},
+		{
+			name:               "requeued after the rotation",
+			result:             reconcile.Result{RequeueAfter: 2 * time.Hour},
+			untilTokenRotation: time.Hour,
+			want:               reconcile.Result{RequeueAfter: time.Hour},
+		},
+		{
+			name:               "requeued immediately",
+			result:             reconcile.Result{Requeue: true},
+			untilTokenRotation: time.Hour,
+			want:               reconcile.Result{Requeue: true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := requeueForTokenRotation(tt.result, tt.untilTokenRotation); got != tt.want {
+				t.Errorf("requeueForTokenRotation() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
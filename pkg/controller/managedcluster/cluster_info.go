@@ -12,11 +12,14 @@ package managedcluster
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
 
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
 	ocinfrav1 "github.com/openshift/api/config/v1"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -27,6 +30,21 @@ const (
 	openshiftConfigNamespace = "openshift-config"
 )
 
+const (
+	// hubKubeAPIServerURLEnvVarName is the URL of the hub kube apiserver used in the bootstrap kubeconfig,
+	// the static hubInfoProvider is used if it is set
+	hubKubeAPIServerURLEnvVarName = "HUB_KUBE_API_SERVER_URL"
+	// hubKubeAPIServerCASecretEnvVarName is the name of a secret in the controller namespace
+	// holding the CA bundle (ca.crt) of the hub kube apiserver used in the bootstrap kubeconfig
+	hubKubeAPIServerCASecretEnvVarName = "HUB_KUBE_API_SERVER_CA_SECRET"
+
+	// hubKubeAPIServerURLAnnotation overrides the hub kube apiserver URL for a managed cluster
+	hubKubeAPIServerURLAnnotation = "import.open-cluster-management.io/hub-kube-api-server-url"
+	// hubKubeAPIServerCASecretAnnotation overrides the hub kube apiserver CA bundle for a managed cluster,
+	// the value is the name of a secret in the cluster namespace holding the ca.crt
+	hubKubeAPIServerCASecretAnnotation = "import.open-cluster-management.io/hub-kube-api-server-ca-secret"
+)
+
 func infrastructureConfigNameNsN() types.NamespacedName {
 	return types.NamespacedName{
 		Name: infrastructureConfigName,
@@ -37,12 +55,67 @@ func getKubeAPIServerAddress(client client.Client) (string, error) {
 	infraConfig := &ocinfrav1.Infrastructure{}
 
 	if err := client.Get(context.TODO(), infrastructureConfigNameNsN(), infraConfig); err != nil {
+		if meta.IsNoMatchError(err) {
+			return "", fmt.Errorf("the hub is not an OpenShift cluster, the hub kube apiserver URL must be set with %s",
+				hubKubeAPIServerURLEnvVarName)
+		}
 		return "", err
 	}
 
 	return infraConfig.Status.APIServerURL, nil
 }
 
+// getKubeAPIServerOverride returns the hub kube apiserver URL and CA bundle configured by the annotations
+// of the managedCluster. An empty URL or CA bundle is returned if it is not configured.
+func getKubeAPIServerOverride(client client.Client, managedCluster *clusterv1.ManagedCluster) (string, []byte, error) {
+	kubeAPIServer := managedCluster.GetAnnotations()[hubKubeAPIServerURLAnnotation]
+	if kubeAPIServer != "" {
+		if u, err := url.Parse(kubeAPIServer); err != nil || u.Host == "" {
+			return "", nil, fmt.Errorf("the hub kube apiserver URL %q of %s is invalid", kubeAPIServer, managedCluster.Name)
+		}
+	}
+
+	secretName := managedCluster.GetAnnotations()[hubKubeAPIServerCASecretAnnotation]
+	if secretName == "" {
+		return kubeAPIServer, nil, nil
+	}
+	caData, err := getCABundle(client, types.NamespacedName{Name: secretName, Namespace: managedCluster.Name})
+	if err != nil {
+		return "", nil, err
+	}
+	return kubeAPIServer, caData, nil
+}
+
+// getCABundle returns the ca.crt of the secret
+func getCABundle(client client.Client, secretNsN types.NamespacedName) ([]byte, error) {
+	secret := &corev1.Secret{}
+	if err := client.Get(context.TODO(), secretNsN, secret); err != nil {
+		log.Error(err, fmt.Sprintf("Failed to get secret %s", secretNsN))
+		return nil, err
+	}
+	caData, ok := secret.Data["ca.crt"]
+	if !ok {
+		return nil, fmt.Errorf("failed to find data[ca.crt] in secret %s", secretNsN)
+	}
+	return caData, nil
+}
+
+// getKubeAPIServerNamedCertificate returns the named certificate of the kubeAPIServer host if any
+func getKubeAPIServerNamedCertificate(client client.Client, kubeAPIServer string) ([]byte, error) {
+	u, err := url.Parse(kubeAPIServer)
+	if err != nil {
+		return nil, nil
+	}
+	apiServerCertSecretName, err := getKubeAPIServerSecretName(client, u.Hostname())
+	if err != nil {
+		return nil, err
+	}
+	if len(apiServerCertSecretName) == 0 {
+		return nil, nil
+	}
+	return getKubeAPIServerCertificate(client, apiServerCertSecretName)
+}
+
 // getKubeAPIServerSecretName iterate through all namespacedCertificates
 // returns the first one which has a name matches the given dnsName
 func getKubeAPIServerSecretName(client client.Client, dnsName string) (string, error) {
@@ -52,7 +125,7 @@ func getKubeAPIServerSecretName(client client.Client, dnsName string) (string, e
 		types.NamespacedName{Name: apiserverConfigName},
 		apiserver,
 	); err != nil {
-		if errors.IsNotFound(err) {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
 			log.Info("APIServer cluster not found")
 			return "", nil
 		}
@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"testing"
 
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
 	ocinfrav1 "github.com/openshift/api/config/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -69,6 +70,89 @@ func Test_getKubeAPIServerAddress(t *testing.T) {
 		})
 	}
 }
+func Test_getKubeAPIServerOverride(t *testing.T) {
+	s := scheme.Scheme
+	clusterCASecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hub-ca",
+			Namespace: "cluster1",
+		},
+		Data: map[string][]byte{
+			"ca.crt": []byte("cluster-ca-data"),
+		},
+	}
+	noCASecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "no-ca",
+			Namespace: "cluster1",
+		},
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantURL     string
+		wantCA      []byte
+		wantErr     bool
+	}{
+		{
+			name: "not configured",
+		},
+		{
+			name: "configured by the managed cluster",
+			annotations: map[string]string{
+				hubKubeAPIServerURLAnnotation:      "https://lb.hub.example.com:6443",
+				hubKubeAPIServerCASecretAnnotation: "hub-ca",
+			},
+			wantURL: "https://lb.hub.example.com:6443",
+			wantCA:  []byte("cluster-ca-data"),
+		},
+		{
+			name: "invalid url",
+			annotations: map[string]string{
+				hubKubeAPIServerURLAnnotation: "lb.hub.example.com",
+			},
+			wantErr: true,
+		},
+		{
+			name: "ca secret not found",
+			annotations: map[string]string{
+				hubKubeAPIServerCASecretAnnotation: "missing",
+			},
+			wantErr: true,
+		},
+		{
+			name: "ca secret without ca.crt",
+			annotations: map[string]string{
+				hubKubeAPIServerCASecretAnnotation: "no-ca",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedCluster := &clusterv1.ManagedCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "cluster1",
+					Annotations: tt.annotations,
+				},
+			}
+			c := fake.NewFakeClientWithScheme(s, clusterCASecret, noCASecret)
+			gotURL, gotCA, err := getKubeAPIServerOverride(c, managedCluster)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getKubeAPIServerOverride() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if gotURL != tt.wantURL {
+				t.Errorf("getKubeAPIServerOverride() url = %v, want %v", gotURL, tt.wantURL)
+			}
+			if !reflect.DeepEqual(gotCA, tt.wantCA) {
+				t.Errorf("getKubeAPIServerOverride() ca = %v, want %v", gotCA, tt.wantCA)
+			}
+		})
+	}
+}
+
 func Test_getKubeAPIServerSecretName(t *testing.T) {
 	s := scheme.Scheme
 	s.AddKnownTypes(ocinfrav1.SchemeGroupVersion, &ocinfrav1.APIServer{})
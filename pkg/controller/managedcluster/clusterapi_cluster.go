@@ -0,0 +1,292 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+const (
+	// clusterAPIClusterAnnotation references the Cluster API Cluster of a ManagedCluster,
+	// the value is "<namespace>/<name>"
+	clusterAPIClusterAnnotation = "import.open-cluster-management.io/cluster-api-cluster"
+	// createManagedClusterAnnotation on a Cluster API Cluster overrides the CLUSTERAPI_CREATE_MANAGED_CLUSTER
+	// environment variable
+	createManagedClusterAnnotation = "import.open-cluster-management.io/create-managed-cluster"
+	// createManagedClusterEnvVarName enables the creation of the ManagedClusters of the Cluster API Clusters
+	createManagedClusterEnvVarName = "CLUSTERAPI_CREATE_MANAGED_CLUSTER"
+	// the secret holding the kubeconfig of a Cluster API Cluster is <cluster>-kubeconfig, its key is value
+	clusterAPIKubeconfigSecretPostfix = "-kubeconfig"
+	clusterAPIKubeconfigSecretKey     = "value"
+)
+
+// ClusterAPIGroupVersion is the Cluster API version of the Cluster watched by the Cluster API controller
+var ClusterAPIGroupVersion = schema.GroupVersion{Group: "cluster.x-k8s.io", Version: "v1beta1"}
+
+// newClusterAPICluster returns an empty Cluster API Cluster, the Cluster API types are read as
+// unstructured to not depend on the Cluster API go module
+func newClusterAPICluster() *unstructured.Unstructured {
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(ClusterAPIGroupVersion.WithKind("Cluster"))
+	return cluster
+}
+
+// clusterAPIClusterNsN returns the namespace/name of the Cluster API Cluster referenced by the managedCluster,
+// the returned bool is false if the managedCluster doesn't reference any Cluster
+func clusterAPIClusterNsN(managedCluster *clusterv1.ManagedCluster) (types.NamespacedName, bool, error) {
+	return namespacedNameAnnotation(managedCluster, clusterAPIClusterAnnotation)
+}
+
+// isClusterAPI returns true if the managedCluster references a Cluster API Cluster
+func isClusterAPI(managedCluster *clusterv1.ManagedCluster) bool {
+	_, ok := managedCluster.GetAnnotations()[clusterAPIClusterAnnotation]
+	return ok
+}
+
+// isClusterAPIClusterOf returns true if the Cluster API Cluster may be imported as the managedCluster, the Cluster
+// is in the namespace of the managedCluster or references the managedCluster, an annotation of a ManagedCluster
+// alone never grants access to the Cluster of another namespace
+func isClusterAPIClusterOf(managedCluster *clusterv1.ManagedCluster, cluster *unstructured.Unstructured) bool {
+	return cluster.GetNamespace() == managedCluster.Name ||
+		cluster.GetAnnotations()[managedClusterReferenceAnnotation] == managedCluster.Name
+}
+
+// getClusterAPICluster returns the Cluster API Cluster referenced by the managedCluster, an error is returned
+// if the Cluster may not be imported as the managedCluster
+func getClusterAPICluster(c client.Client, managedCluster *clusterv1.ManagedCluster,
+	clusterNsN types.NamespacedName) (*unstructured.Unstructured, error) {
+	cluster := newClusterAPICluster()
+	if err := c.Get(context.TODO(), clusterNsN, cluster); err != nil {
+		return nil, err
+	}
+	if !isClusterAPIClusterOf(managedCluster, cluster) {
+		return nil, fmt.Errorf("the Cluster API Cluster %s is not in the namespace %s and its %s annotation does not reference the ManagedCluster %s",
+			clusterNsN, managedCluster.Name, managedClusterReferenceAnnotation, managedCluster.Name)
+	}
+	return cluster, nil
+}
+
+// isControlPlaneReady returns true once the control plane of the Cluster API Cluster is ready
+func isControlPlaneReady(cluster *unstructured.Unstructured) bool {
+	if ready, _, _ := unstructured.NestedBool(cluster.Object, "status", "controlPlaneReady"); ready {
+		return true
+	}
+	conditions, _, _ := unstructured.NestedSlice(cluster.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if ok && condition["type"] == "ControlPlaneReady" && condition["status"] == "True" {
+			return true
+		}
+	}
+	return false
+}
+
+// shouldCreateManagedCluster returns true if the ManagedCluster of the Cluster API Cluster is created by
+// the controller, the annotation of the Cluster overrides the environment variable
+func shouldCreateManagedCluster(cluster *unstructured.Unstructured) (bool, error) {
+	if value, ok := cluster.GetAnnotations()[createManagedClusterAnnotation]; ok {
+		create, err := strconv.ParseBool(value)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s annotation %q: %v", createManagedClusterAnnotation, value, err)
+		}
+		return create, nil
+	}
+	value := os.Getenv(createManagedClusterEnvVarName)
+	if value == "" {
+		return false, nil
+	}
+	create, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %q", createManagedClusterEnvVarName, value)
+	}
+	return create, nil
+}
+
+// newClusterAPIManagedCluster returns the ManagedCluster of the Cluster API Cluster, named after the Cluster
+func newClusterAPIManagedCluster(cluster *unstructured.Unstructured) *clusterv1.ManagedCluster {
+	return &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: cluster.GetName(),
+			Annotations: map[string]string{
+				clusterAPIClusterAnnotation: fmt.Sprintf("%s/%s", cluster.GetNamespace(), cluster.GetName()),
+				createdViaAnnotation:        createdViaAnnotationClusterAPI,
+			},
+		},
+		Spec: clusterv1.ManagedClusterSpec{
+			HubAcceptsClient: true,
+		},
+	}
+}
+
+// getManagedClusterClientFromClusterAPI returns the client of the Cluster API Cluster from its <cluster>-kubeconfig secret
+func (r *ReconcileManagedCluster) getManagedClusterClientFromClusterAPI(
+	managedCluster *clusterv1.ManagedCluster,
+	clusterNsN types.NamespacedName) (client.Client, *rest.Config, error) {
+	if _, err := getClusterAPICluster(r.client, managedCluster, clusterNsN); err != nil {
+		return nil, nil, err
+	}
+	kubeconfigSecret := &corev1.Secret{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{
+		Name:      clusterNsN.Name + clusterAPIKubeconfigSecretPostfix,
+		Namespace: clusterNsN.Namespace,
+	}, kubeconfigSecret)
+	if err != nil {
+		return nil, nil, err
+	}
+	kubeconfig, ok := kubeconfigSecret.Data[clusterAPIKubeconfigSecretKey]
+	if !ok {
+		return nil, nil, fmt.Errorf("the secret %s/%s has no %s key",
+			kubeconfigSecret.Namespace, kubeconfigSecret.Name, clusterAPIKubeconfigSecretKey)
+	}
+	return getClientFromKubeConfig(kubeconfig)
+}
+
+// isClusterAPIClusterReadyToReconcile returns true once the control plane of the Cluster API Cluster is ready
+func (r *ReconcileManagedCluster) isClusterAPIClusterReadyToReconcile(
+	managedCluster *clusterv1.ManagedCluster,
+	clusterNsN types.NamespacedName) (bool, error) {
+	cluster := newClusterAPICluster()
+	if err := r.client.Get(context.TODO(), clusterNsN, cluster); err != nil {
+		if errors.IsNotFound(err) {
+			klog.Infof("not ready to reconcile, the Cluster API Cluster %s is not found", clusterNsN)
+			return false, nil
+		}
+		return false, err
+	}
+	if !isClusterAPIClusterOf(managedCluster, cluster) {
+		klog.Infof("not ready to reconcile, the Cluster API Cluster %s does not reference the cluster %s",
+			clusterNsN, managedCluster.Name)
+		r.recorder.Eventf(managedCluster, corev1.EventTypeWarning, "ClusterAPIClusterNotReferenced",
+			"The Cluster API Cluster %s is not in the namespace %s and its %s annotation does not reference the cluster",
+			clusterNsN, managedCluster.Name, managedClusterReferenceAnnotation)
+		return false, nil
+	}
+	if !isControlPlaneReady(cluster) {
+		klog.Infof("not ready to reconcile, the control plane of the Cluster API Cluster %s is not ready", clusterNsN)
+		return false, nil
+	}
+	klog.Infof("ready to reconcile, the control plane of the Cluster API Cluster %s is ready", clusterNsN)
+	return true, nil
+}
+
+// ReconcileClusterAPICluster creates the ManagedClusters of the Cluster API Clusters if configured to,
+// the ManagedClusters are imported by the ManagedCluster reconciler with the kubeconfig of their Cluster
+type ReconcileClusterAPICluster struct {
+	client   client.Client
+	recorder record.EventRecorder
+}
+
+// AddClusterAPI creates the Cluster API Cluster controller and adds it to the Manager
+func AddClusterAPI(mgr manager.Manager) error {
+	r := &ReconcileClusterAPICluster{
+		client:   mgr.GetClient(),
+		recorder: mgr.GetEventRecorderFor("managedcluster-import-controller"),
+	}
+	c, err := controller.New("clusterapi-cluster-controller", mgr, controller.Options{Reconciler: r})
+	if err != nil {
+		return err
+	}
+
+	err = c.Watch(&source.Kind{Type: newClusterAPICluster()}, &handler.EnqueueRequestForObject{})
+	if err != nil {
+		log.Error(err, "Fail to add Watch for Cluster API Cluster to controller")
+		return err
+	}
+	return nil
+}
+
+// Reconcile waits for the control plane of the Cluster API Cluster to be ready and creates its ManagedCluster
+// if configured to
+func (r *ReconcileClusterAPICluster) Reconcile(request reconcile.Request) (reconcile.Result, error) {
+	reqLogger := log.WithValues("Request.Namespace", request.Namespace, "Request.Name", request.Name)
+	reqLogger.Info("Reconciling Cluster API Cluster")
+
+	cluster := newClusterAPICluster()
+	if err := r.client.Get(context.TODO(), request.NamespacedName, cluster); err != nil {
+		if errors.IsNotFound(err) {
+			return reconcile.Result{}, nil
+		}
+		return reconcile.Result{}, err
+	}
+	if cluster.GetDeletionTimestamp() != nil {
+		return reconcile.Result{}, nil
+	}
+	if !isControlPlaneReady(cluster) {
+		reqLogger.Info("Waiting for the control plane of the Cluster to be ready")
+		return reconcile.Result{}, nil
+	}
+
+	managedCluster := &clusterv1.ManagedCluster{}
+	err := r.client.Get(context.TODO(), types.NamespacedName{Name: cluster.GetName()}, managedCluster)
+	if err == nil {
+		if clusterNsN, ok, _ := clusterAPIClusterNsN(managedCluster); !ok || clusterNsN != request.NamespacedName {
+			reqLogger.Info("The ManagedCluster does not reference the Cluster, it is not imported with its kubeconfig",
+				"ManagedCluster", managedCluster.Name)
+		}
+		return reconcile.Result{}, nil
+	}
+	if !errors.IsNotFound(err) {
+		return reconcile.Result{}, err
+	}
+
+	create, err := shouldCreateManagedCluster(cluster)
+	if err != nil || !create {
+		return reconcile.Result{}, err
+	}
+	// the Cluster references the ManagedCluster before it is created, the reference is removed if the
+	// ManagedCluster is not created by the controller
+	if err := setClusterAPIManagedClusterAnnotation(r.client, cluster, cluster.GetName()); err != nil {
+		return reconcile.Result{}, err
+	}
+	managedCluster = newClusterAPIManagedCluster(cluster)
+	if err := r.client.Create(context.TODO(), managedCluster); err != nil {
+		if errRef := setClusterAPIManagedClusterAnnotation(r.client, cluster, ""); errRef != nil {
+			klog.Error(errRef)
+		}
+		return reconcile.Result{}, err
+	}
+	r.recorder.Eventf(managedCluster, corev1.EventTypeNormal, "ManagedClusterCreated",
+		"The ManagedCluster is created for the Cluster API Cluster %s", request.NamespacedName)
+	return reconcile.Result{}, nil
+}
+
+// setClusterAPIManagedClusterAnnotation sets the ManagedCluster referenced by the Cluster API Cluster,
+// the annotation is removed if name is empty
+func setClusterAPIManagedClusterAnnotation(c client.Client, cluster *unstructured.Unstructured, name string) error {
+	if cluster.GetAnnotations()[managedClusterReferenceAnnotation] == name {
+		return nil
+	}
+	patch := client.MergeFrom(cluster.DeepCopy())
+	annotations := cluster.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if name == "" {
+		delete(annotations, managedClusterReferenceAnnotation)
+	} else {
+		annotations[managedClusterReferenceAnnotation] = name
+	}
+	cluster.SetAnnotations(annotations)
+	return c.Patch(context.TODO(), cluster, patch)
+}
@@ -0,0 +1,280 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newTestClusterAPICluster(controlPlaneReady bool, annotations map[string]string) *unstructured.Unstructured {
+	cluster := newClusterAPICluster()
+	cluster.SetName("cluster1")
+	cluster.SetNamespace("capi")
+	cluster.SetAnnotations(annotations)
+	if controlPlaneReady {
+		_ = unstructured.SetNestedSlice(cluster.Object, []interface{}{
+			map[string]interface{}{"type": "ControlPlaneReady", "status": "True"},
+		}, "status", "conditions")
+	}
+	return cluster
+}
+
+func Test_isControlPlaneReady(t *testing.T) {
+	ready := newClusterAPICluster()
+	_ = unstructured.SetNestedField(ready.Object, true, "status", "controlPlaneReady")
+	tests := []struct {
+		name    string
+		cluster *unstructured.Unstructured
+		want    bool
+	}{
+		{name: "not ready", cluster: newTestClusterAPICluster(false, nil)},
+		{name: "condition", cluster: newTestClusterAPICluster(true, nil), want: true},
+		{name: "status", cluster: ready, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isControlPlaneReady(tt.cluster); got != tt.want {
+				t.Errorf("isControlPlaneReady() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_shouldCreateManagedCluster(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        string
+		annotation string
+		want       bool
+		wantErr    bool
+	}{
+		{name: "default"},
+		{name: "enabled", env: "true", want: true},
+		{name: "disabled on the cluster", env: "true", annotation: "false"},
+		{name: "enabled on the cluster", annotation: "true", want: true},
+		{name: "invalid", annotation: "always", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(createManagedClusterEnvVarName, tt.env)
+			defer os.Unsetenv(createManagedClusterEnvVarName)
+			annotations := map[string]string{}
+			if tt.annotation != "" {
+				annotations[createManagedClusterAnnotation] = tt.annotation
+			}
+			got, err := shouldCreateManagedCluster(newTestClusterAPICluster(true, annotations))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("shouldCreateManagedCluster() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("shouldCreateManagedCluster() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileManagedCluster_getManagedClusterClientFromClusterAPI(t *testing.T) {
+	other := newTestClusterAPICluster(true, nil)
+	other.SetName("cluster3")
+	r := &ReconcileManagedCluster{
+		client: fake.NewFakeClientWithScheme(scheme.Scheme,
+			newTestClusterAPICluster(true, map[string]string{managedClusterReferenceAnnotation: "cluster1"}),
+			other,
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster1-kubeconfig", Namespace: "capi"},
+				Data:       map[string][]byte{"kubeconfig": []byte("")},
+			},
+		),
+	}
+	managedCluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}}
+	_, _, err := r.getManagedClusterClientFromClusterAPI(managedCluster, types.NamespacedName{Namespace: "capi", Name: "cluster1"})
+	if err == nil || err.Error() != "the secret capi/cluster1-kubeconfig has no value key" {
+		t.Errorf("getManagedClusterClientFromClusterAPI() unexpected error %v", err)
+	}
+	_, _, err = r.getManagedClusterClientFromClusterAPI(managedCluster, types.NamespacedName{Namespace: "capi", Name: "cluster2"})
+	if !errors.IsNotFound(err) {
+		t.Errorf("getManagedClusterClientFromClusterAPI() expected a not found error, got %v", err)
+	}
+	// the Cluster of another namespace does not reference the ManagedCluster
+	_, _, err = r.getManagedClusterClientFromClusterAPI(managedCluster, types.NamespacedName{Namespace: "capi", Name: "cluster3"})
+	if err == nil || !strings.Contains(err.Error(), "does not reference the ManagedCluster cluster1") {
+		t.Errorf("getManagedClusterClientFromClusterAPI() expected a not referenced error, got %v", err)
+	}
+}
+
+func TestReconcileClusterAPICluster_Reconcile(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{})
+
+	imported := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster1",
+			Annotations: map[string]string{clusterAPIClusterAnnotation: "capi/cluster1"},
+		},
+	}
+	other := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster1",
+			Annotations: map[string]string{clusterAPIClusterAnnotation: "other/cluster1"},
+		},
+	}
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		create      string
+		wantCreated bool
+	}{
+		{
+			name:    "control plane not ready",
+			objects: []runtime.Object{newTestClusterAPICluster(false, nil)},
+			create:  "true",
+		},
+		{
+			name:    "no managed cluster",
+			objects: []runtime.Object{newTestClusterAPICluster(true, nil)},
+		},
+		{
+			name:        "managed cluster created",
+			objects:     []runtime.Object{newTestClusterAPICluster(true, nil)},
+			create:      "true",
+			wantCreated: true,
+		},
+		{
+			name:    "managed cluster of another cluster",
+			objects: []runtime.Object{newTestClusterAPICluster(true, nil), other},
+		},
+		{
+			name:    "managed cluster of the cluster",
+			objects: []runtime.Object{newTestClusterAPICluster(true, nil), imported},
+			create:  "true",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(createManagedClusterEnvVarName, tt.create)
+			defer os.Unsetenv(createManagedClusterEnvVarName)
+			recorder := record.NewFakeRecorder(10)
+			r := &ReconcileClusterAPICluster{
+				client:   fake.NewFakeClientWithScheme(testscheme, tt.objects...),
+				recorder: recorder,
+			}
+			result, err := r.Reconcile(reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "capi", Name: "cluster1"}})
+			if err != nil {
+				t.Errorf("Reconcile() unexpected error %v", err)
+				return
+			}
+			if result.Requeue {
+				t.Errorf("Reconcile() unexpected requeue")
+			}
+			if len(recorder.Events) > 0 != tt.wantCreated {
+				t.Errorf("expected the managed cluster created %v, got %d events", tt.wantCreated, len(recorder.Events))
+			}
+			if !tt.wantCreated {
+				return
+			}
+			managedCluster := &clusterv1.ManagedCluster{}
+			if err := r.client.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, managedCluster); err != nil {
+				t.Errorf("expected the managed cluster to be created, got %v", err)
+				return
+			}
+			if managedCluster.Annotations[clusterAPIClusterAnnotation] != "capi/cluster1" ||
+				managedCluster.Annotations[createdViaAnnotation] != createdViaAnnotationClusterAPI {
+				t.Errorf("unexpected annotations %v", managedCluster.Annotations)
+			}
+			cluster := newClusterAPICluster()
+			if err := r.client.Get(context.TODO(), types.NamespacedName{Namespace: "capi", Name: "cluster1"}, cluster); err != nil {
+				t.Errorf("unexpected error %v", err)
+				return
+			}
+			if cluster.GetAnnotations()[managedClusterReferenceAnnotation] != "cluster1" {
+				t.Errorf("expected the cluster to reference the managed cluster, got %v", cluster.GetAnnotations())
+			}
+		})
+	}
+}
+
+func TestReconcileManagedCluster_isReadyToReconcile_clusterAPI(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{})
+
+	referencing := map[string]string{managedClusterReferenceAnnotation: "cluster1"}
+	sameNamespace := newTestClusterAPICluster(true, nil)
+	sameNamespace.SetNamespace("cluster1")
+	tests := []struct {
+		name      string
+		objects   []runtime.Object
+		reference string
+		wantReady bool
+	}{
+		{
+			name: "cluster not found",
+		},
+		{
+			name:    "control plane not ready",
+			objects: []runtime.Object{newTestClusterAPICluster(false, referencing)},
+		},
+		{
+			name:      "control plane ready",
+			objects:   []runtime.Object{newTestClusterAPICluster(true, referencing)},
+			wantReady: true,
+		},
+		{
+			name:    "cluster of another namespace",
+			objects: []runtime.Object{newTestClusterAPICluster(true, nil)},
+		},
+		{
+			name:    "cluster referencing another managed cluster",
+			objects: []runtime.Object{newTestClusterAPICluster(true, map[string]string{managedClusterReferenceAnnotation: "cluster2"})},
+		},
+		{
+			name:      "cluster of the namespace of the managed cluster",
+			objects:   []runtime.Object{sameNamespace},
+			reference: "cluster1/cluster1",
+			wantReady: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reference := "capi/cluster1"
+			if tt.reference != "" {
+				reference = tt.reference
+			}
+			managedCluster := &clusterv1.ManagedCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "cluster1",
+					Annotations: map[string]string{clusterAPIClusterAnnotation: reference},
+				},
+			}
+			r := &ReconcileManagedCluster{
+				client:   fake.NewFakeClientWithScheme(testscheme, append(tt.objects, managedCluster)...),
+				scheme:   testscheme,
+				recorder: record.NewFakeRecorder(10),
+			}
+			clusterDeployment, ready, err := r.isReadyToReconcile(managedCluster)
+			if err != nil {
+				t.Errorf("isReadyToReconcile() unexpected error %v", err)
+				return
+			}
+			if clusterDeployment != nil || ready != tt.wantReady {
+				t.Errorf("isReadyToReconcile() = %v, %v, want ready %v", clusterDeployment, ready, tt.wantReady)
+			}
+		})
+	}
+}
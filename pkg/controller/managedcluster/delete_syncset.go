@@ -10,7 +10,9 @@ import (
 	"time"
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -35,6 +37,7 @@ func syncSetNsN(managedCluster *clusterv1.ManagedCluster) (types.NamespacedName,
 
 func deleteKlusterletSyncSets(
 	client client.Client,
+	recorder record.EventRecorder,
 	managedCluster *clusterv1.ManagedCluster,
 ) (res reconcile.Result, err error) {
 	ssNsN, err := syncSetNsN(managedCluster)
@@ -43,17 +46,19 @@ func deleteKlusterletSyncSets(
 	}
 
 	//Delete the CRD syncset
-	result, err := deleteKlusterletSyncSet(client, ssNsN.Name+syncsetCRDSPostfix, ssNsN.Namespace)
+	result, err := deleteKlusterletSyncSet(client, recorder, managedCluster, ssNsN.Name+syncsetCRDSPostfix, ssNsN.Namespace)
 	if err != nil {
 		return result, err
 	}
 
 	//Delete the YAML syncset
-	return deleteKlusterletSyncSet(client, ssNsN.Name, ssNsN.Namespace)
+	return deleteKlusterletSyncSet(client, recorder, managedCluster, ssNsN.Name, ssNsN.Namespace)
 }
 
 func deleteKlusterletSyncSet(
 	client client.Client,
+	recorder record.EventRecorder,
+	managedCluster *clusterv1.ManagedCluster,
 	name string,
 	namespace string,
 ) (res reconcile.Result, err error) {
@@ -75,6 +80,9 @@ func deleteKlusterletSyncSet(
 				return reconcile.Result{}, err
 			}
 			klog.Infof("SyncSet %s set with upsert mode, requeue to wait hive to process", oldSyncSet.GetName())
+			recorder.Eventf(managedCluster, corev1.EventTypeNormal, "SyncSetUpsertMode",
+				"The syncset %s/%s is set with upsert mode before its deletion, the klusterlet is now managed with manifestworks",
+				namespace, name)
 			return reconcile.Result{Requeue: true, RequeueAfter: 1 * time.Minute}, nil
 		}
 		//Now delete syncset.
@@ -84,6 +92,8 @@ func deleteKlusterletSyncSet(
 			return reconcile.Result{}, err
 		}
 		klog.Infof("SyncSet %s deleted", oldSyncSet.GetName())
+		recorder.Eventf(managedCluster, corev1.EventTypeNormal, "SyncSetDeleted",
+			"The syncset %s/%s is deleted", namespace, name)
 	}
 	return reconcile.Result{}, nil
 }
@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -133,15 +134,19 @@ func Test_deleteSyncSets(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
 			//Set upsert
-			if _, err := deleteKlusterletSyncSets(tt.args.client, tt.args.managedCluster); (err != nil) != tt.wantErr {
+			if _, err := deleteKlusterletSyncSets(tt.args.client, recorder, tt.args.managedCluster); (err != nil) != tt.wantErr {
 				t.Errorf("deleteSyncSets() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			//Delete syncset as upsert is set
-			if _, err := deleteKlusterletSyncSets(tt.args.client, tt.args.managedCluster); (err != nil) != tt.wantErr {
+			if _, err := deleteKlusterletSyncSets(tt.args.client, recorder, tt.args.managedCluster); (err != nil) != tt.wantErr {
 				t.Errorf("deleteSyncSets() error = %v, wantErr %v", err, tt.wantErr)
 			}
 			if !tt.wantErr {
+				if len(recorder.Events) == 0 {
+					t.Error("expected syncset events")
+				}
 				crds := &hivev1.SyncSet{}
 				err := tt.args.client.Get(context.TODO(),
 					types.NamespacedName{
@@ -0,0 +1,190 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	agentClusterInstallKind = "AgentClusterInstall"
+	// importBeforeInstalledAnnotation on a ManagedCluster overrides the AGENT_CLUSTER_INSTALL_IMPORT_BEFORE_INSTALLED
+	// environment variable
+	importBeforeInstalledAnnotation = "import.open-cluster-management.io/import-before-installed"
+	// importBeforeInstalledEnvVarName enables the import of the Assisted Installer clusters as soon as
+	// their kubeconfig is available, before their ClusterDeployment is installed
+	importBeforeInstalledEnvVarName = "AGENT_CLUSTER_INSTALL_IMPORT_BEFORE_INSTALLED"
+)
+
+// AgentClusterInstallGroupVersion is the version of the Assisted Installer AgentClusterInstall
+var AgentClusterInstallGroupVersion = schema.GroupVersion{Group: "extensions.hive.openshift.io", Version: "v1beta1"}
+
+// newAgentClusterInstall returns an empty AgentClusterInstall, the Assisted Installer types are read as
+// unstructured to not depend on the assisted-service go module
+func newAgentClusterInstall() *unstructured.Unstructured {
+	agentClusterInstall := &unstructured.Unstructured{}
+	agentClusterInstall.SetGroupVersionKind(AgentClusterInstallGroupVersion.WithKind(agentClusterInstallKind))
+	return agentClusterInstall
+}
+
+// isAgentClusterInstall returns true if the clusterDeployment is installed by an AgentClusterInstall
+func isAgentClusterInstall(clusterDeployment *hivev1.ClusterDeployment) bool {
+	installRef := clusterDeployment.Spec.ClusterInstallRef
+	return installRef != nil && installRef.Group == AgentClusterInstallGroupVersion.Group &&
+		installRef.Kind == agentClusterInstallKind
+}
+
+// getAgentClusterInstall returns the AgentClusterInstall of the clusterDeployment,
+// the AgentClusterInstall is in the namespace of the clusterDeployment
+func getAgentClusterInstall(c client.Client, clusterDeployment *hivev1.ClusterDeployment) (*unstructured.Unstructured, error) {
+	installRef := clusterDeployment.Spec.ClusterInstallRef
+	agentClusterInstall := &unstructured.Unstructured{}
+	agentClusterInstall.SetGroupVersionKind(schema.GroupVersionKind{
+		Group:   installRef.Group,
+		Version: installRef.Version,
+		Kind:    installRef.Kind,
+	})
+	err := c.Get(context.TODO(), types.NamespacedName{
+		Name:      installRef.Name,
+		Namespace: clusterDeployment.Namespace,
+	}, agentClusterInstall)
+	return agentClusterInstall, err
+}
+
+// getAgentClusterInstallKubeconfigSecretName returns the name of the admin kubeconfig secret of the
+// AgentClusterInstall, the name is empty until the kubeconfig is available
+func getAgentClusterInstallKubeconfigSecretName(agentClusterInstall *unstructured.Unstructured) string {
+	name, _, _ := unstructured.NestedString(agentClusterInstall.Object,
+		"spec", "clusterMetadata", "adminKubeconfigSecretRef", "name")
+	return name
+}
+
+// getAgentClusterInstallState returns the installation state reported by the Assisted Installer
+func getAgentClusterInstallState(agentClusterInstall *unstructured.Unstructured) string {
+	state, _, _ := unstructured.NestedString(agentClusterInstall.Object, "status", "debugInfo", "state")
+	return state
+}
+
+// shouldImportBeforeInstalled returns true if the managedCluster is imported as soon as the kubeconfig
+// of its AgentClusterInstall is available, the annotation of the managedCluster overrides the environment variable
+func shouldImportBeforeInstalled(managedCluster *clusterv1.ManagedCluster) (bool, error) {
+	if value, ok := managedCluster.GetAnnotations()[importBeforeInstalledAnnotation]; ok {
+		importBeforeInstalled, err := strconv.ParseBool(value)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s annotation %q: %v", importBeforeInstalledAnnotation, value, err)
+		}
+		return importBeforeInstalled, nil
+	}
+	value := os.Getenv(importBeforeInstalledEnvVarName)
+	if value == "" {
+		return false, nil
+	}
+	importBeforeInstalled, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s: %q", importBeforeInstalledEnvVarName, value)
+	}
+	return importBeforeInstalled, nil
+}
+
+// getAdminKubeconfigSecretName returns the name of the admin kubeconfig secret of the clusterDeployment.
+// The cluster metadata of an Assisted Installer ClusterDeployment is only set once the cluster is installed,
+// before that the secret is read from its AgentClusterInstall.
+func getAdminKubeconfigSecretName(c client.Client, clusterDeployment *hivev1.ClusterDeployment) (string, error) {
+	if clusterDeployment.Spec.ClusterMetadata != nil &&
+		clusterDeployment.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name != "" {
+		return clusterDeployment.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name, nil
+	}
+	if isAgentClusterInstall(clusterDeployment) {
+		agentClusterInstall, err := getAgentClusterInstall(c, clusterDeployment)
+		if err != nil {
+			return "", err
+		}
+		if name := getAgentClusterInstallKubeconfigSecretName(agentClusterInstall); name != "" {
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("the ClusterDeployment %s/%s has no admin kubeconfig",
+		clusterDeployment.Namespace, clusterDeployment.Name)
+}
+
+// isAgentClusterInstallReadyToReconcile returns true if the Assisted Installer cluster, not yet installed,
+// is imported before it is installed and the admin kubeconfig of its AgentClusterInstall is available
+func (r *ReconcileManagedCluster) isAgentClusterInstallReadyToReconcile(
+	managedCluster *clusterv1.ManagedCluster,
+	clusterDeployment *hivev1.ClusterDeployment) (bool, error) {
+	if !isAgentClusterInstall(clusterDeployment) {
+		return false, nil
+	}
+	importBeforeInstalled, err := shouldImportBeforeInstalled(managedCluster)
+	if err != nil || !importBeforeInstalled {
+		return false, err
+	}
+	agentClusterInstall, err := getAgentClusterInstall(r.client, clusterDeployment)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			klog.Infof("not ready to reconcile, the AgentClusterInstall of cluster %s is not found", clusterDeployment.Name)
+			return false, nil
+		}
+		return false, err
+	}
+	secretName := getAgentClusterInstallKubeconfigSecretName(agentClusterInstall)
+	if secretName == "" {
+		klog.Infof("not ready to reconcile, the kubeconfig of cluster %s is not available, installation state %q",
+			clusterDeployment.Name, getAgentClusterInstallState(agentClusterInstall))
+		return false, nil
+	}
+	err = r.client.Get(context.TODO(), types.NamespacedName{
+		Name:      secretName,
+		Namespace: clusterDeployment.Namespace,
+	}, &corev1.Secret{})
+	if err != nil {
+		if errors.IsNotFound(err) {
+			klog.Infof("not ready to reconcile, the kubeconfig secret %s/%s of cluster %s is not found",
+				clusterDeployment.Namespace, secretName, clusterDeployment.Name)
+			return false, nil
+		}
+		return false, err
+	}
+	klog.Infof("ready to reconcile, the kubeconfig of cluster %s is available before its installation, installation state %q",
+		clusterDeployment.Name, getAgentClusterInstallState(agentClusterInstall))
+	return true, nil
+}
+
+// newAgentClusterInstallMapper returns a mapper which enqueues the ManagedCluster of the ClusterDeployment
+// of an AgentClusterInstall
+func newAgentClusterInstallMapper() handler.ToRequestsFunc {
+	return handler.ToRequestsFunc(func(obj handler.MapObject) []reconcile.Request {
+		agentClusterInstall, ok := obj.Object.(*unstructured.Unstructured)
+		if !ok {
+			return nil
+		}
+		name, _, _ := unstructured.NestedString(agentClusterInstall.Object, "spec", "clusterDeploymentRef", "name")
+		if name == "" {
+			return nil
+		}
+		return []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Name:      name,
+					Namespace: agentClusterInstall.GetNamespace(),
+				},
+			},
+		}
+	})
+}
@@ -0,0 +1,213 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newAgentClusterDeployment(installed bool) *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1",
+			Namespace: "cluster1",
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			Installed: installed,
+			ClusterInstallRef: &hivev1.ClusterInstallLocalReference{
+				Group:   AgentClusterInstallGroupVersion.Group,
+				Version: AgentClusterInstallGroupVersion.Version,
+				Kind:    agentClusterInstallKind,
+				Name:    "cluster1-install",
+			},
+		},
+	}
+}
+
+func newTestAgentClusterInstall(kubeconfigSecretName string) *unstructured.Unstructured {
+	agentClusterInstall := newAgentClusterInstall()
+	agentClusterInstall.SetName("cluster1-install")
+	agentClusterInstall.SetNamespace("cluster1")
+	_ = unstructured.SetNestedField(agentClusterInstall.Object, "cluster1", "spec", "clusterDeploymentRef", "name")
+	_ = unstructured.SetNestedField(agentClusterInstall.Object, "installing", "status", "debugInfo", "state")
+	if kubeconfigSecretName != "" {
+		_ = unstructured.SetNestedField(agentClusterInstall.Object, kubeconfigSecretName,
+			"spec", "clusterMetadata", "adminKubeconfigSecretRef", "name")
+	}
+	return agentClusterInstall
+}
+
+func newAdminKubeconfigSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1-admin-kubeconfig",
+			Namespace: "cluster1",
+		},
+	}
+}
+
+func Test_shouldImportBeforeInstalled(t *testing.T) {
+	tests := []struct {
+		name       string
+		env        string
+		annotation string
+		want       bool
+		wantErr    bool
+	}{
+		{name: "default"},
+		{name: "enabled", env: "true", want: true},
+		{name: "disabled on the cluster", env: "true", annotation: "false"},
+		{name: "enabled on the cluster", annotation: "true", want: true},
+		{name: "invalid", env: "yes please", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(importBeforeInstalledEnvVarName, tt.env)
+			defer os.Unsetenv(importBeforeInstalledEnvVarName)
+			managedCluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}}
+			if tt.annotation != "" {
+				managedCluster.Annotations = map[string]string{importBeforeInstalledAnnotation: tt.annotation}
+			}
+			got, err := shouldImportBeforeInstalled(managedCluster)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("shouldImportBeforeInstalled() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("shouldImportBeforeInstalled() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getAdminKubeconfigSecretName(t *testing.T) {
+	installed := newAgentClusterDeployment(true)
+	installed.Spec.ClusterMetadata = &hivev1.ClusterMetadata{
+		AdminKubeconfigSecretRef: corev1.LocalObjectReference{Name: "cluster1-installed-kubeconfig"},
+	}
+	tests := []struct {
+		name              string
+		clusterDeployment *hivev1.ClusterDeployment
+		objects           []runtime.Object
+		want              string
+		wantErr           bool
+	}{
+		{
+			name:              "cluster metadata",
+			clusterDeployment: installed,
+			want:              "cluster1-installed-kubeconfig",
+		},
+		{
+			name:              "agent cluster install",
+			clusterDeployment: newAgentClusterDeployment(false),
+			objects:           []runtime.Object{newTestAgentClusterInstall("cluster1-admin-kubeconfig")},
+			want:              "cluster1-admin-kubeconfig",
+		},
+		{
+			name:              "kubeconfig not available",
+			clusterDeployment: newAgentClusterDeployment(false),
+			objects:           []runtime.Object{newTestAgentClusterInstall("")},
+			wantErr:           true,
+		},
+		{
+			name:              "no cluster metadata",
+			clusterDeployment: newHibernationClusterDeployment("", "", ""),
+			wantErr:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(newClusterClaimTestScheme(), tt.objects...)
+			got, err := getAdminKubeconfigSecretName(c, tt.clusterDeployment)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getAdminKubeconfigSecretName() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("getAdminKubeconfigSecretName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileManagedCluster_isReadyToReconcile_agentClusterInstall(t *testing.T) {
+	tests := []struct {
+		name       string
+		objects    []runtime.Object
+		annotation string
+		wantReady  bool
+	}{
+		{
+			name:      "installed",
+			objects:   []runtime.Object{newAgentClusterDeployment(true)},
+			wantReady: true,
+		},
+		{
+			name:    "installing",
+			objects: []runtime.Object{newAgentClusterDeployment(false), newTestAgentClusterInstall("cluster1-admin-kubeconfig"), newAdminKubeconfigSecret()},
+		},
+		{
+			name:       "kubeconfig not available",
+			objects:    []runtime.Object{newAgentClusterDeployment(false), newTestAgentClusterInstall("")},
+			annotation: "true",
+		},
+		{
+			name:       "kubeconfig secret not found",
+			objects:    []runtime.Object{newAgentClusterDeployment(false), newTestAgentClusterInstall("cluster1-admin-kubeconfig")},
+			annotation: "true",
+		},
+		{
+			name:       "imported before installed",
+			objects:    []runtime.Object{newAgentClusterDeployment(false), newTestAgentClusterInstall("cluster1-admin-kubeconfig"), newAdminKubeconfigSecret()},
+			annotation: "true",
+			wantReady:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedCluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}}
+			if tt.annotation != "" {
+				managedCluster.Annotations = map[string]string{importBeforeInstalledAnnotation: tt.annotation}
+			}
+			r := &ReconcileManagedCluster{
+				client:   fake.NewFakeClientWithScheme(newClusterClaimTestScheme(), append(tt.objects, managedCluster)...),
+				scheme:   newClusterClaimTestScheme(),
+				recorder: record.NewFakeRecorder(10),
+			}
+			clusterDeployment, ready, err := r.isReadyToReconcile(managedCluster)
+			if err != nil {
+				t.Errorf("isReadyToReconcile() unexpected error %v", err)
+				return
+			}
+			if clusterDeployment == nil || ready != tt.wantReady {
+				t.Errorf("isReadyToReconcile() = %v, %v, want ready %v", clusterDeployment, ready, tt.wantReady)
+			}
+		})
+	}
+}
+
+func Test_newAgentClusterInstallMapper(t *testing.T) {
+	agentClusterInstall := newTestAgentClusterInstall("")
+	got := newAgentClusterInstallMapper().Map(handler.MapObject{Meta: agentClusterInstall, Object: agentClusterInstall})
+	want := []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "cluster1", Namespace: "cluster1"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newAgentClusterInstallMapper() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// clusterClaimAnnotation references the hive ClusterClaim of a ManagedCluster claimed from a ClusterPool.
+// The value is "<namespace>/<name>", the namespace is the namespace of the ClusterPool.
+const clusterClaimAnnotation = "import.open-cluster-management.io/cluster-claim"
+
+// clusterClaimNsN returns the namespace/name of the ClusterClaim referenced by the managedCluster,
+// the returned bool is false if the managedCluster doesn't reference any ClusterClaim
+func clusterClaimNsN(managedCluster *clusterv1.ManagedCluster) (types.NamespacedName, bool, error) {
+	return namespacedNameAnnotation(managedCluster, clusterClaimAnnotation)
+}
+
+// claimStatus is the ClusterDeployment assigned to a ClusterClaim, clusterDeployment is nil while the claim is
+// pending, released or unresolved
+type claimStatus struct {
+	clusterDeployment *hivev1.ClusterDeployment
+	// released is true once the release of the claim is observed, the claim is being deleted or the
+	// ClusterDeployment of the claim no longer references a claim
+	released bool
+	// unresolved is the reason the claim or its ClusterDeployment is not resolved, the claim may be misspelled
+	// or not yet in the cache, a cluster is not detached before its release is observed
+	unresolved string
+}
+
+// getClaimedClusterDeployment returns the status of the claim and the ClusterDeployment assigned to it. The claim
+// must reference the ManagedCluster managedClusterName, the claims of the other namespaces are not resolved
+// from the annotation of a ManagedCluster alone.
+func getClaimedClusterDeployment(c client.Reader, managedClusterName string, claimNsN types.NamespacedName) (*claimStatus, error) {
+	claim := &hivev1.ClusterClaim{}
+	if err := c.Get(context.TODO(), claimNsN, claim); err != nil {
+		if errors.IsNotFound(err) {
+			return &claimStatus{unresolved: fmt.Sprintf("the ClusterClaim %s is not found", claimNsN)}, nil
+		}
+		return nil, err
+	}
+	if claim.DeletionTimestamp != nil {
+		return &claimStatus{released: true}, nil
+	}
+	if claim.GetAnnotations()[managedClusterReferenceAnnotation] != managedClusterName {
+		return &claimStatus{unresolved: fmt.Sprintf("the %s annotation of the ClusterClaim %s does not reference the cluster %s",
+			managedClusterReferenceAnnotation, claimNsN, managedClusterName)}, nil
+	}
+	if claim.Spec.Namespace == "" {
+		klog.Infof("the ClusterClaim %s is pending", claimNsN)
+		return &claimStatus{}, nil
+	}
+
+	// the ClusterDeployments of a pool are named after their namespace
+	clusterDeployment := &hivev1.ClusterDeployment{}
+	if err := c.Get(context.TODO(), types.NamespacedName{
+		Name:      claim.Spec.Namespace,
+		Namespace: claim.Spec.Namespace,
+	}, clusterDeployment); err != nil {
+		if errors.IsNotFound(err) {
+			return &claimStatus{unresolved: fmt.Sprintf("the ClusterDeployment %s/%s of the ClusterClaim %s is not found",
+				claim.Spec.Namespace, claim.Spec.Namespace, claimNsN)}, nil
+		}
+		return nil, err
+	}
+	poolRef := clusterDeployment.Spec.ClusterPoolRef
+	switch {
+	case poolRef != nil && poolRef.ClaimName == "":
+		return &claimStatus{released: true}, nil
+	case !isClaimedBy(clusterDeployment, claimNsN):
+		return &claimStatus{unresolved: fmt.Sprintf("the ClusterDeployment %s/%s is not claimed by the ClusterClaim %s",
+			clusterDeployment.Namespace, clusterDeployment.Name, claimNsN)}, nil
+	case clusterDeployment.DeletionTimestamp != nil:
+		return &claimStatus{unresolved: fmt.Sprintf("the ClusterDeployment %s/%s of the ClusterClaim %s is being deleted",
+			clusterDeployment.Namespace, clusterDeployment.Name, claimNsN)}, nil
+	}
+	return &claimStatus{clusterDeployment: clusterDeployment}, nil
+}
+
+// isClaimedBy returns true if the clusterDeployment is assigned to the claim by its ClusterPool
+func isClaimedBy(clusterDeployment *hivev1.ClusterDeployment, claimNsN types.NamespacedName) bool {
+	poolRef := clusterDeployment.Spec.ClusterPoolRef
+	return poolRef != nil && poolRef.Namespace == claimNsN.Namespace && poolRef.ClaimName == claimNsN.Name
+}
+
+// isClaimedClusterReadyToReconcile resolves the ClusterDeployment of a managedCluster claimed from a ClusterPool.
+// The managedCluster is detached once the release of its claim is observed without the cache.
+func (r *ReconcileManagedCluster) isClaimedClusterReadyToReconcile(
+	managedCluster *clusterv1.ManagedCluster,
+	claimNsN types.NamespacedName) (*hivev1.ClusterDeployment, bool, error) {
+	status, err := getClaimedClusterDeployment(r.client, managedCluster.Name, claimNsN)
+	if err != nil {
+		return nil, false, err
+	}
+	if status.released || status.unresolved != "" {
+		// confirm with the apiserver, the cache may lag behind the claim and its ClusterDeployment
+		status, err = getClaimedClusterDeployment(apiReader(r.client), managedCluster.Name, claimNsN)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+	if status.released {
+		return nil, false, r.detachReleasedCluster(managedCluster, claimNsN)
+	}
+	if status.unresolved != "" {
+		klog.Infof("not ready to reconcile, cluster %s: %s", managedCluster.Name, status.unresolved)
+		r.recorder.Eventf(managedCluster, corev1.EventTypeWarning, "ClusterClaimNotResolved",
+			"The cluster is not imported nor detached, %s", status.unresolved)
+		return nil, false, nil
+	}
+	clusterDeployment := status.clusterDeployment
+	if clusterDeployment == nil {
+		klog.Infof("not ready to reconcile, cluster %s waits for the ClusterClaim %s", managedCluster.Name, claimNsN)
+		return nil, false, nil
+	}
+	if !clusterDeployment.Spec.Installed {
+		klog.Infof("not ready to reconcile, claimed cluster %s/%s not yet installed",
+			clusterDeployment.Namespace, clusterDeployment.Name)
+		return clusterDeployment, false, nil
+	}
+	klog.Infof("ready to reconcile, cluster %s claimed the ClusterDeployment %s/%s",
+		managedCluster.Name, clusterDeployment.Namespace, clusterDeployment.Name)
+	return clusterDeployment, true, nil
+}
+
+// detachReleasedCluster deletes the managedCluster of a released claim, the klusterlet is removed
+// from the cluster by the detach before the cluster is returned to hive
+func (r *ReconcileManagedCluster) detachReleasedCluster(
+	managedCluster *clusterv1.ManagedCluster,
+	claimNsN types.NamespacedName) error {
+	klog.Infof("the ClusterClaim %s of the cluster %s is released, detach the cluster", claimNsN, managedCluster.Name)
+	r.recorder.Eventf(managedCluster, corev1.EventTypeNormal, "ClusterClaimReleased",
+		"The ClusterClaim %s is released, the cluster is detached", claimNsN)
+	if err := r.client.Delete(context.TODO(), managedCluster); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// newClusterClaimMapper returns a mapper which enqueues the ManagedClusters referencing a ClusterClaim
+func newClusterClaimMapper(c client.Client) handler.ToRequestsFunc {
+	return handler.ToRequestsFunc(func(obj handler.MapObject) []reconcile.Request {
+		return getClaimingManagedClusters(c, types.NamespacedName{
+			Namespace: obj.Meta.GetNamespace(),
+			Name:      obj.Meta.GetName(),
+		})
+	})
+}
+
+// newClusterDeploymentMapper returns a mapper which enqueues the ManagedCluster named after the
+// ClusterDeployment and the ManagedClusters referencing the claim of a pool ClusterDeployment
+func newClusterDeploymentMapper(c client.Client) handler.ToRequestsFunc {
+	return handler.ToRequestsFunc(func(obj handler.MapObject) []reconcile.Request {
+		requests := []reconcile.Request{
+			{
+				NamespacedName: types.NamespacedName{
+					Name:      obj.Meta.GetName(),
+					Namespace: obj.Meta.GetNamespace(),
+				},
+			},
+		}
+		clusterDeployment, ok := obj.Object.(*hivev1.ClusterDeployment)
+		if !ok || clusterDeployment.Spec.ClusterPoolRef == nil || clusterDeployment.Spec.ClusterPoolRef.ClaimName == "" {
+			return requests
+		}
+		return append(requests, getClaimingManagedClusters(c, types.NamespacedName{
+			Namespace: clusterDeployment.Spec.ClusterPoolRef.Namespace,
+			Name:      clusterDeployment.Spec.ClusterPoolRef.ClaimName,
+		})...)
+	})
+}
+
+// getClaimingManagedClusters returns the requests of the ManagedClusters referencing the claim
+func getClaimingManagedClusters(c client.Client, claimNsN types.NamespacedName) []reconcile.Request {
+	managedClusters := &clusterv1.ManagedClusterList{}
+	if err := c.List(context.TODO(), managedClusters); err != nil {
+		log.Error(err, "Failed to list managedclusters", "ClusterClaim", claimNsN.String())
+		return nil
+	}
+	requests := []reconcile.Request{}
+	for i := range managedClusters.Items {
+		nsN, ok, err := clusterClaimNsN(&managedClusters.Items[i])
+		if err != nil || !ok || nsN != claimNsN {
+			continue
+		}
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: managedClusters.Items[i].Name},
+		})
+	}
+	return requests
+}
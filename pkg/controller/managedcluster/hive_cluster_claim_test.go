@@ -0,0 +1,337 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newClusterClaimTestScheme() *runtime.Scheme {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{}, &clusterv1.ManagedClusterList{})
+	testscheme.AddKnownTypes(hivev1.SchemeGroupVersion, &hivev1.ClusterDeployment{}, &hivev1.ClusterClaim{})
+	return testscheme
+}
+
+func newClaimedManagedCluster(name, claim string) *clusterv1.ManagedCluster {
+	managedCluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+	}
+	if claim != "" {
+		managedCluster.Annotations = map[string]string{clusterClaimAnnotation: claim}
+	}
+	return managedCluster
+}
+
+func newClusterClaim(namespace string) *hivev1.ClusterClaim {
+	return &hivev1.ClusterClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "claim1",
+			Namespace:   "pool",
+			Annotations: map[string]string{managedClusterReferenceAnnotation: "cluster1"},
+		},
+		Spec: hivev1.ClusterClaimSpec{
+			ClusterPoolName: "pool1",
+			Namespace:       namespace,
+		},
+	}
+}
+
+func newPoolClusterDeployment(claimName string, installed bool) *hivev1.ClusterDeployment {
+	return &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "pool1-abcde",
+			Namespace: "pool1-abcde",
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			Installed: installed,
+			ClusterPoolRef: &hivev1.ClusterPoolReference{
+				Namespace: "pool",
+				PoolName:  "pool1",
+				ClaimName: claimName,
+			},
+		},
+	}
+}
+
+func Test_clusterClaimNsN(t *testing.T) {
+	tests := []struct {
+		name    string
+		claim   string
+		want    types.NamespacedName
+		wantOk  bool
+		wantErr bool
+	}{
+		{name: "no claim"},
+		{name: "claim", claim: "pool/claim1", want: types.NamespacedName{Namespace: "pool", Name: "claim1"}, wantOk: true},
+		{name: "no namespace", claim: "claim1", wantErr: true},
+		{name: "no name", claim: "pool/", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok, err := clusterClaimNsN(newClaimedManagedCluster("cluster1", tt.claim))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("clusterClaimNsN() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want || ok != tt.wantOk {
+				t.Errorf("clusterClaimNsN() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+}
+
+func Test_getClaimedClusterDeployment(t *testing.T) {
+	claimNsN := types.NamespacedName{Namespace: "pool", Name: "claim1"}
+	deletedClaim := newClusterClaim("pool1-abcde")
+	deletedClaim.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	deletedClusterDeployment := newPoolClusterDeployment("claim1", true)
+	deletedClusterDeployment.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	otherClaim := newClusterClaim("pool1-abcde")
+	otherClaim.Annotations = map[string]string{managedClusterReferenceAnnotation: "cluster2"}
+	tests := []struct {
+		name           string
+		objects        []runtime.Object
+		wantCD         bool
+		wantReleased   bool
+		wantUnresolved bool
+	}{
+		{
+			name:           "claim not found",
+			wantUnresolved: true,
+		},
+		{
+			name:    "claim pending",
+			objects: []runtime.Object{newClusterClaim("")},
+		},
+		{
+			name:    "cluster claimed",
+			objects: []runtime.Object{newClusterClaim("pool1-abcde"), newPoolClusterDeployment("claim1", true)},
+			wantCD:  true,
+		},
+		{
+			name:         "claim deleted",
+			objects:      []runtime.Object{deletedClaim, newPoolClusterDeployment("claim1", true)},
+			wantReleased: true,
+		},
+		{
+			name:         "cluster released",
+			objects:      []runtime.Object{newClusterClaim("pool1-abcde"), newPoolClusterDeployment("", true)},
+			wantReleased: true,
+		},
+		{
+			name:           "cluster not found",
+			objects:        []runtime.Object{newClusterClaim("pool1-abcde")},
+			wantUnresolved: true,
+		},
+		{
+			name:           "claim of another managed cluster",
+			objects:        []runtime.Object{otherClaim, newPoolClusterDeployment("claim1", true)},
+			wantUnresolved: true,
+		},
+		{
+			name:           "cluster claimed by another claim",
+			objects:        []runtime.Object{newClusterClaim("pool1-abcde"), newPoolClusterDeployment("claim2", true)},
+			wantUnresolved: true,
+		},
+		{
+			name:           "cluster deleted",
+			objects:        []runtime.Object{newClusterClaim("pool1-abcde"), deletedClusterDeployment},
+			wantUnresolved: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(newClusterClaimTestScheme(), tt.objects...)
+			status, err := getClaimedClusterDeployment(c, "cluster1", claimNsN)
+			if err != nil {
+				t.Errorf("getClaimedClusterDeployment() unexpected error %v", err)
+				return
+			}
+			if (status.clusterDeployment != nil) != tt.wantCD || status.released != tt.wantReleased ||
+				(status.unresolved != "") != tt.wantUnresolved {
+				t.Errorf("getClaimedClusterDeployment() = %+v, want the clusterdeployment %v, released %v, unresolved %v",
+					status, tt.wantCD, tt.wantReleased, tt.wantUnresolved)
+			}
+		})
+	}
+}
+
+func TestReconcileManagedCluster_isReadyToReconcile_claimed(t *testing.T) {
+	hibernating := newPoolClusterDeployment("claim1", true)
+	hibernating.Spec.PowerState = hivev1.HibernatingClusterPowerState
+	deletedClaim := newClusterClaim("pool1-abcde")
+	deletedClaim.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	unreferencedClaim := newClusterClaim("pool1-abcde")
+	unreferencedClaim.Annotations = nil
+	tests := []struct {
+		name        string
+		objects     []runtime.Object
+		wantCD      bool
+		wantReady   bool
+		wantDeleted bool
+		wantEvent   string
+	}{
+		{
+			name:      "claimed and installed",
+			objects:   []runtime.Object{newClusterClaim("pool1-abcde"), newPoolClusterDeployment("claim1", true)},
+			wantCD:    true,
+			wantReady: true,
+		},
+		{
+			name:    "claimed and not installed",
+			objects: []runtime.Object{newClusterClaim("pool1-abcde"), newPoolClusterDeployment("claim1", false)},
+			wantCD:  true,
+		},
+		{
+			name:      "claimed and hibernating",
+			objects:   []runtime.Object{newClusterClaim("pool1-abcde"), hibernating},
+			wantCD:    true,
+			wantReady: true,
+		},
+		{
+			name:        "released",
+			objects:     []runtime.Object{newClusterClaim("pool1-abcde"), newPoolClusterDeployment("", true)},
+			wantDeleted: true,
+			wantEvent:   "ClusterClaimReleased",
+		},
+		{
+			name:        "claim deleted",
+			objects:     []runtime.Object{deletedClaim, newPoolClusterDeployment("claim1", true)},
+			wantDeleted: true,
+			wantEvent:   "ClusterClaimReleased",
+		},
+		{
+			name:      "claim not found",
+			wantEvent: "ClusterClaimNotResolved",
+		},
+		{
+			name:      "claim of another managed cluster",
+			objects:   []runtime.Object{unreferencedClaim, newPoolClusterDeployment("claim1", true)},
+			wantEvent: "ClusterClaimNotResolved",
+		},
+		{
+			name:      "cluster not found",
+			objects:   []runtime.Object{newClusterClaim("pool1-abcde")},
+			wantEvent: "ClusterClaimNotResolved",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedCluster := newClaimedManagedCluster("cluster1", "pool/claim1")
+			recorder := record.NewFakeRecorder(10)
+			r := &ReconcileManagedCluster{
+				client:   fake.NewFakeClientWithScheme(newClusterClaimTestScheme(), append(tt.objects, managedCluster)...),
+				scheme:   newClusterClaimTestScheme(),
+				recorder: recorder,
+			}
+			clusterDeployment, ready, err := r.isReadyToReconcile(managedCluster)
+			if err != nil {
+				t.Errorf("isReadyToReconcile() unexpected error %v", err)
+				return
+			}
+			if (clusterDeployment != nil) != tt.wantCD || ready != tt.wantReady {
+				t.Errorf("isReadyToReconcile() = %v, %v, want the clusterdeployment %v, ready %v",
+					clusterDeployment, ready, tt.wantCD, tt.wantReady)
+			}
+			err = r.client.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, &clusterv1.ManagedCluster{})
+			if errors.IsNotFound(err) != tt.wantDeleted {
+				t.Errorf("expected the managed cluster deleted %v, got %v", tt.wantDeleted, err)
+			}
+			event := ""
+			select {
+			case event = <-recorder.Events:
+			default:
+			}
+			if !strings.Contains(event, tt.wantEvent) || (tt.wantEvent == "") != (event == "") {
+				t.Errorf("expected the event %q, got %q", tt.wantEvent, event)
+			}
+		})
+	}
+}
+
+func TestReconcileManagedCluster_isReadyToReconcile_claimedCacheLag(t *testing.T) {
+	managedCluster := newClaimedManagedCluster("cluster1", "pool/claim1")
+	tests := []struct {
+		name        string
+		cached      []runtime.Object
+		live        []runtime.Object
+		wantReady   bool
+		wantDeleted bool
+	}{
+		{
+			name:      "released in the cache only",
+			cached:    []runtime.Object{newClusterClaim("pool1-abcde"), newPoolClusterDeployment("", true)},
+			live:      []runtime.Object{newClusterClaim("pool1-abcde"), newPoolClusterDeployment("claim1", true)},
+			wantReady: true,
+		},
+		{
+			name:        "released and not yet in the cache",
+			live:        []runtime.Object{newClusterClaim("pool1-abcde"), newPoolClusterDeployment("", true)},
+			wantDeleted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &ReconcileManagedCluster{
+				client: newCustomClient(
+					fake.NewFakeClientWithScheme(newClusterClaimTestScheme(), append(tt.cached, managedCluster.DeepCopy())...),
+					fake.NewFakeClientWithScheme(newClusterClaimTestScheme(), tt.live...),
+				),
+				scheme:   newClusterClaimTestScheme(),
+				recorder: record.NewFakeRecorder(10),
+			}
+			_, ready, err := r.isReadyToReconcile(managedCluster.DeepCopy())
+			if err != nil || ready != tt.wantReady {
+				t.Errorf("isReadyToReconcile() = %v, %v, want ready %v", ready, err, tt.wantReady)
+			}
+			err = r.client.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, &clusterv1.ManagedCluster{})
+			if errors.IsNotFound(err) != tt.wantDeleted {
+				t.Errorf("expected the managed cluster deleted %v, got %v", tt.wantDeleted, err)
+			}
+		})
+	}
+}
+
+func Test_newClusterDeploymentMapper(t *testing.T) {
+	c := fake.NewFakeClientWithScheme(newClusterClaimTestScheme(),
+		newClaimedManagedCluster("cluster1", "pool/claim1"),
+		newClaimedManagedCluster("cluster2", "pool/claim2"),
+		newClaimedManagedCluster("cluster3", ""),
+	)
+
+	clusterDeployment := newPoolClusterDeployment("claim1", true)
+	got := newClusterDeploymentMapper(c).Map(handler.MapObject{Meta: clusterDeployment, Object: clusterDeployment})
+	want := []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "pool1-abcde", Namespace: "pool1-abcde"}},
+		{NamespacedName: types.NamespacedName{Name: "cluster1"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newClusterDeploymentMapper() = %v, want %v", got, want)
+	}
+
+	claim := newClusterClaim("pool1-abcde")
+	got = newClusterClaimMapper(c).Map(handler.MapObject{Meta: claim, Object: claim})
+	if !reflect.DeepEqual(got, want[1:]) {
+		t.Errorf("newClusterClaimMapper() = %v, want %v", got, want[1:])
+	}
+}
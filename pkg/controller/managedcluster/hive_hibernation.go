@@ -0,0 +1,160 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	"github.com/open-cluster-management/managedcluster-import-controller/pkg/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// hibernatingDetachTimeoutEnvVarName is the maximum wait of the detach of a hibernating cluster for the cluster
+	// to run again, its manifestworks are evicted once the timeout expires
+	hibernatingDetachTimeoutEnvVarName = "HIBERNATING_DETACH_TIMEOUT"
+	// forceDetachAnnotation on a deleted ManagedCluster evicts the manifestworks of its hibernating cluster
+	// without waiting for the cluster to run again
+	forceDetachAnnotation = "import.open-cluster-management.io/force-detach"
+)
+
+// defaultHibernatingDetachTimeout is the maximum wait of the detach of a hibernating cluster
+const defaultHibernatingDetachTimeout = 24 * time.Hour
+
+// getHibernatingDetachTimeout returns the default timeout overridden by the environment variable
+func getHibernatingDetachTimeout() (time.Duration, error) {
+	value := os.Getenv(hibernatingDetachTimeoutEnvVarName)
+	if value == "" {
+		return defaultHibernatingDetachTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("invalid %s: %q", hibernatingDetachTimeoutEnvVarName, value)
+	}
+	return timeout, nil
+}
+
+// untilHibernatingDetachTimeout returns the duration the detach of the hibernating managedCluster still waits for
+// the cluster to run again, the wait starts with the deletion of the managedCluster. 0 is returned once the timeout
+// expires or if the detach is forced by the annotation of the managedCluster.
+func untilHibernatingDetachTimeout(managedCluster *clusterv1.ManagedCluster, now time.Time) (time.Duration, error) {
+	if value, ok := managedCluster.GetAnnotations()[forceDetachAnnotation]; ok {
+		force, err := strconv.ParseBool(value)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s annotation %q: %v", forceDetachAnnotation, value, err)
+		}
+		if force {
+			return 0, nil
+		}
+	}
+	timeout, err := getHibernatingDetachTimeout()
+	if err != nil {
+		return 0, err
+	}
+	if managedCluster.DeletionTimestamp == nil {
+		return timeout, nil
+	}
+	remaining := managedCluster.DeletionTimestamp.Add(timeout).Sub(now)
+	if remaining < 0 {
+		return 0, nil
+	}
+	return remaining, nil
+}
+
+// getHibernationState returns true if the hive cluster is not running, with the hive reason of its power state:
+// Stopping or Hibernating while the power state is Hibernating, Resuming until the machines are running again.
+func getHibernationState(clusterDeployment *hivev1.ClusterDeployment) (bool, string) {
+	var hibernatingCondition *hivev1.ClusterDeploymentCondition
+	for i := range clusterDeployment.Status.Conditions {
+		if clusterDeployment.Status.Conditions[i].Type == hivev1.ClusterHibernatingCondition {
+			hibernatingCondition = &clusterDeployment.Status.Conditions[i]
+		}
+	}
+	hibernated := hibernatingCondition != nil && hibernatingCondition.Status == corev1.ConditionTrue
+
+	if clusterDeployment.Spec.PowerState == hivev1.HibernatingClusterPowerState {
+		if hibernatingCondition != nil && hibernatingCondition.Reason == hivev1.UnsupportedHibernationReason {
+			// the cluster does not support the hibernation and keeps running
+			return false, hivev1.RunningHibernationReason
+		}
+		if hibernated {
+			return true, hivev1.HibernatingHibernationReason
+		}
+		return true, hivev1.StoppingHibernationReason
+	}
+	if hibernated {
+		return true, hivev1.ResumingHibernationReason
+	}
+	return false, hivev1.RunningHibernationReason
+}
+
+// setHibernatingCondition reports the power state of the hive cluster in the ManagedClusterHibernating
+// condition and returns true if the cluster is not running. The clusters without clusterDeployment are
+// always running and have no condition.
+func setHibernatingCondition(
+	c client.Client,
+	managedCluster *clusterv1.ManagedCluster,
+	clusterDeployment *hivev1.ClusterDeployment) (bool, error) {
+	if clusterDeployment == nil {
+		return false, nil
+	}
+	hibernating, reason := getHibernationState(clusterDeployment)
+	condition := metav1.Condition{
+		Type:   ManagedClusterHibernating,
+		Status: metav1.ConditionFalse,
+		Reason: reason,
+		Message: fmt.Sprintf("The ClusterDeployment %s/%s is running",
+			clusterDeployment.Namespace, clusterDeployment.Name),
+	}
+	if hibernating {
+		condition.Status = metav1.ConditionTrue
+		condition.Message = fmt.Sprintf("The ClusterDeployment %s/%s is %s, the import is skipped until it is running",
+			clusterDeployment.Namespace, clusterDeployment.Name, strings.ToLower(reason))
+	}
+	return hibernating, helpers.SetManagedClusterCondition(c, managedCluster, condition)
+}
+
+// isDetachedClusterHibernating returns true if the hive cluster of the detached managedCluster is not running,
+// a deleted clusterDeployment is deprovisioned and is not hibernating
+func (r *ReconcileManagedCluster) isDetachedClusterHibernating(managedCluster *clusterv1.ManagedCluster) (bool, error) {
+	var clusterDeployment *hivev1.ClusterDeployment
+	claimNsN, ok, err := clusterClaimNsN(managedCluster)
+	switch {
+	case err != nil:
+		return false, err
+	case ok:
+		status, err := getClaimedClusterDeployment(r.client, managedCluster.Name, claimNsN)
+		if err != nil {
+			return false, err
+		}
+		clusterDeployment = status.clusterDeployment
+	default:
+		clusterDeployment = &hivev1.ClusterDeployment{}
+		err = r.client.Get(context.TODO(), types.NamespacedName{
+			Name:      managedCluster.Name,
+			Namespace: managedCluster.Name,
+		}, clusterDeployment)
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+	if clusterDeployment == nil || clusterDeployment.DeletionTimestamp != nil {
+		return false, nil
+	}
+	return setHibernatingCondition(r.client, managedCluster, clusterDeployment)
+}
@@ -0,0 +1,252 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	workv1 "github.com/open-cluster-management/api/work/v1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newHibernationClusterDeployment(powerState hivev1.ClusterPowerState, status corev1.ConditionStatus, reason string) *hivev1.ClusterDeployment {
+	clusterDeployment := &hivev1.ClusterDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "cluster1",
+			Namespace: "cluster1",
+		},
+		Spec: hivev1.ClusterDeploymentSpec{
+			Installed:  true,
+			PowerState: powerState,
+		},
+	}
+	if status != "" {
+		clusterDeployment.Status.Conditions = []hivev1.ClusterDeploymentCondition{
+			{
+				Type:   hivev1.ClusterHibernatingCondition,
+				Status: status,
+				Reason: reason,
+			},
+		}
+	}
+	return clusterDeployment
+}
+
+func Test_getHibernationState(t *testing.T) {
+	tests := []struct {
+		name              string
+		clusterDeployment *hivev1.ClusterDeployment
+		wantHibernating   bool
+		wantReason        string
+	}{
+		{
+			name:              "running",
+			clusterDeployment: newHibernationClusterDeployment("", "", ""),
+			wantReason:        hivev1.RunningHibernationReason,
+		},
+		{
+			name:              "stopping",
+			clusterDeployment: newHibernationClusterDeployment(hivev1.HibernatingClusterPowerState, corev1.ConditionFalse, hivev1.StoppingHibernationReason),
+			wantHibernating:   true,
+			wantReason:        hivev1.StoppingHibernationReason,
+		},
+		{
+			name:              "hibernating",
+			clusterDeployment: newHibernationClusterDeployment(hivev1.HibernatingClusterPowerState, corev1.ConditionTrue, hivev1.HibernatingHibernationReason),
+			wantHibernating:   true,
+			wantReason:        hivev1.HibernatingHibernationReason,
+		},
+		{
+			name:              "unsupported",
+			clusterDeployment: newHibernationClusterDeployment(hivev1.HibernatingClusterPowerState, corev1.ConditionFalse, hivev1.UnsupportedHibernationReason),
+			wantReason:        hivev1.RunningHibernationReason,
+		},
+		{
+			name:              "resuming",
+			clusterDeployment: newHibernationClusterDeployment(hivev1.RunningClusterPowerState, corev1.ConditionTrue, hivev1.ResumingHibernationReason),
+			wantHibernating:   true,
+			wantReason:        hivev1.ResumingHibernationReason,
+		},
+		{
+			name:              "resumed",
+			clusterDeployment: newHibernationClusterDeployment(hivev1.RunningClusterPowerState, corev1.ConditionFalse, hivev1.RunningHibernationReason),
+			wantReason:        hivev1.RunningHibernationReason,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hibernating, reason := getHibernationState(tt.clusterDeployment)
+			if hibernating != tt.wantHibernating || reason != tt.wantReason {
+				t.Errorf("getHibernationState() = %v, %s, want %v, %s", hibernating, reason, tt.wantHibernating, tt.wantReason)
+			}
+		})
+	}
+}
+
+func Test_setHibernatingCondition(t *testing.T) {
+	testscheme := newClusterClaimTestScheme()
+	managedCluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}}
+	c := fake.NewFakeClientWithScheme(testscheme, managedCluster)
+
+	// no condition without clusterdeployment
+	hibernating, err := setHibernatingCondition(c, managedCluster, nil)
+	if err != nil || hibernating {
+		t.Errorf("setHibernatingCondition() = %v, %v, want false", hibernating, err)
+	}
+	if meta.FindStatusCondition(managedCluster.Status.Conditions, ManagedClusterHibernating) != nil {
+		t.Errorf("expected no %s condition", ManagedClusterHibernating)
+	}
+
+	hibernating, err = setHibernatingCondition(c, managedCluster,
+		newHibernationClusterDeployment(hivev1.HibernatingClusterPowerState, corev1.ConditionTrue, hivev1.HibernatingHibernationReason))
+	if err != nil || !hibernating {
+		t.Errorf("setHibernatingCondition() = %v, %v, want true", hibernating, err)
+	}
+	updated := &clusterv1.ManagedCluster{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, updated); err != nil {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+	condition := meta.FindStatusCondition(updated.Status.Conditions, ManagedClusterHibernating)
+	if condition == nil || condition.Status != metav1.ConditionTrue || condition.Reason != hivev1.HibernatingHibernationReason {
+		t.Errorf("unexpected %s condition %v", ManagedClusterHibernating, condition)
+	}
+}
+
+func TestReconcileManagedCluster_managedClusterDeletion_hibernating(t *testing.T) {
+	deleting := newHibernationClusterDeployment(hivev1.HibernatingClusterPowerState, corev1.ConditionTrue, hivev1.HibernatingHibernationReason)
+	now := metav1.NewTime(time.Now())
+	deleting.DeletionTimestamp = &now
+	tests := []struct {
+		name              string
+		clusterDeployment *hivev1.ClusterDeployment
+		deletedSince      time.Duration
+		annotations       map[string]string
+		wantReason        string
+		wantErr           bool
+	}{
+		{
+			name:              "hibernating",
+			clusterDeployment: newHibernationClusterDeployment(hivev1.HibernatingClusterPowerState, corev1.ConditionTrue, hivev1.HibernatingHibernationReason),
+			wantReason:        "ClusterHibernating",
+		},
+		{
+			name:              "deprovisioned",
+			clusterDeployment: deleting,
+			wantReason:        "ManagedClusterDetached",
+		},
+		{
+			name:              "hibernating after the timeout",
+			clusterDeployment: newHibernationClusterDeployment(hivev1.HibernatingClusterPowerState, corev1.ConditionTrue, hivev1.HibernatingHibernationReason),
+			deletedSince:      defaultHibernatingDetachTimeout + time.Minute,
+			wantReason:        "ManagedClusterDetached",
+		},
+		{
+			name:              "hibernating and forced",
+			clusterDeployment: newHibernationClusterDeployment(hivev1.HibernatingClusterPowerState, corev1.ConditionTrue, hivev1.HibernatingHibernationReason),
+			annotations:       map[string]string{forceDetachAnnotation: "true"},
+			wantReason:        "ManagedClusterDetached",
+		},
+		{
+			name:              "hibernating and not forced",
+			clusterDeployment: newHibernationClusterDeployment(hivev1.HibernatingClusterPowerState, corev1.ConditionTrue, hivev1.HibernatingHibernationReason),
+			annotations:       map[string]string{forceDetachAnnotation: "false"},
+			wantReason:        "ClusterHibernating",
+		},
+		{
+			name:              "invalid force annotation",
+			clusterDeployment: newHibernationClusterDeployment(hivev1.HibernatingClusterPowerState, corev1.ConditionTrue, hivev1.HibernatingHibernationReason),
+			annotations:       map[string]string{forceDetachAnnotation: "please"},
+			wantReason:        "HibernatingDetachConfigInvalid",
+			wantErr:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testscheme := newClusterClaimTestScheme()
+			testscheme.AddKnownTypes(workv1.SchemeGroupVersion, &workv1.ManifestWork{}, &workv1.ManifestWorkList{})
+			deletionTimestamp := metav1.NewTime(now.Add(-tt.deletedSince))
+			managedCluster := &clusterv1.ManagedCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "cluster1",
+					DeletionTimestamp: &deletionTimestamp,
+					Annotations:       tt.annotations,
+				},
+				Status: clusterv1.ManagedClusterStatus{
+					Conditions: []metav1.Condition{
+						{Type: clusterv1.ManagedClusterConditionAvailable, Status: metav1.ConditionUnknown},
+					},
+				},
+			}
+			r := &ReconcileManagedCluster{
+				client:   fake.NewFakeClientWithScheme(testscheme, managedCluster, tt.clusterDeployment),
+				scheme:   testscheme,
+				recorder: record.NewFakeRecorder(10),
+			}
+			result, err := r.managedClusterDeletion(managedCluster)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("managedClusterDeletion() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if result.RequeueAfter > time.Minute {
+				t.Errorf("managedClusterDeletion() requeue after %s, want at most 1m", result.RequeueAfter)
+			}
+			condition := meta.FindStatusCondition(managedCluster.Status.Conditions, ManagedClusterDetaching)
+			if condition == nil || condition.Reason != tt.wantReason {
+				t.Errorf("expected the %s reason, got %v", tt.wantReason, condition)
+			}
+		})
+	}
+}
+
+func Test_untilHibernatingDetachTimeout(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name         string
+		env          string
+		annotation   string
+		deletedSince time.Duration
+		want         time.Duration
+		wantErr      bool
+	}{
+		{name: "default", deletedSince: time.Hour, want: defaultHibernatingDetachTimeout - time.Hour},
+		{name: "overridden", env: "2h", deletedSince: time.Hour, want: time.Hour},
+		{name: "expired", env: "2h", deletedSince: 3 * time.Hour},
+		{name: "forced", annotation: "true", deletedSince: time.Hour},
+		{name: "invalid timeout", env: "-1h", wantErr: true},
+		{name: "invalid annotation", annotation: "yes please", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv(hibernatingDetachTimeoutEnvVarName, tt.env)
+			defer os.Unsetenv(hibernatingDetachTimeoutEnvVarName)
+			deletionTimestamp := metav1.NewTime(now.Add(-tt.deletedSince))
+			managedCluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{
+				Name:              "cluster1",
+				DeletionTimestamp: &deletionTimestamp,
+			}}
+			if tt.annotation != "" {
+				managedCluster.Annotations = map[string]string{forceDetachAnnotation: tt.annotation}
+			}
+			got, err := untilHibernatingDetachTimeout(managedCluster, now)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("untilHibernatingDetachTimeout() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("untilHibernatingDetachTimeout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	ocinfrav1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// clusterInfoConfigMapName is the configmap published by kubeadm with the kubeconfig of the cluster
+	clusterInfoConfigMapName      = "cluster-info"
+	clusterInfoConfigMapNamespace = "kube-public"
+)
+
+// hubInfoProvider provides the information of the hub used to render the bootstrap kubeconfig
+type hubInfoProvider interface {
+	// KubeAPIServerURL returns the URL of the hub kube apiserver
+	KubeAPIServerURL(client client.Client) (string, error)
+	// KubeAPIServerCA returns the CA bundle of the hub kube apiserver kubeAPIServer, defaultCA is the ca.crt of the
+	// bootstrap service account token. A nil CA bundle is returned if the certificate of the hub kube apiserver
+	// is signed by a trusted CA.
+	KubeAPIServerCA(client client.Client, kubeAPIServer string, defaultCA []byte) ([]byte, error)
+}
+
+// hubInfo is the hubInfoProvider selected when the controller is added to the manager
+var hubInfo hubInfoProvider
+
+// getHubInfoProvider returns the selected hubInfoProvider, the OpenShift one is used if none is selected
+func getHubInfoProvider() hubInfoProvider {
+	if hubInfo == nil {
+		return &openshiftHubInfoProvider{}
+	}
+	return hubInfo
+}
+
+// newHubInfoProvider selects the hubInfoProvider:
+// - static if the HUB_KUBE_API_SERVER_URL is set
+// - OpenShift if the hub serves the config.openshift.io/v1 APIs, i.e. they are not in the missingGVS
+// - Kubernetes otherwise
+func newHubInfoProvider(cfg *rest.Config, apiReader client.Reader, missingGVS []schema.GroupVersion) hubInfoProvider {
+	if kubeAPIServer := os.Getenv(hubKubeAPIServerURLEnvVarName); kubeAPIServer != "" {
+		log.Info("Use the static hub info provider", "kubeAPIServer", kubeAPIServer)
+		return &staticHubInfoProvider{
+			kubeAPIServer: kubeAPIServer,
+			caSecret: types.NamespacedName{
+				Name:      os.Getenv(hubKubeAPIServerCASecretEnvVarName),
+				Namespace: os.Getenv("POD_NAMESPACE"),
+			},
+		}
+	}
+
+	for _, gv := range missingGVS {
+		if gv == ocinfrav1.GroupVersion {
+			log.Info("Use the Kubernetes hub info provider")
+			return &kubernetesHubInfoProvider{restConfig: cfg, apiReader: apiReader}
+		}
+	}
+	log.Info("Use the OpenShift hub info provider")
+	return &openshiftHubInfoProvider{}
+}
+
+// openshiftHubInfoProvider reads the hub information from the config.openshift.io APIs
+type openshiftHubInfoProvider struct{}
+
+func (p *openshiftHubInfoProvider) KubeAPIServerURL(client client.Client) (string, error) {
+	return getKubeAPIServerAddress(client)
+}
+
+func (p *openshiftHubInfoProvider) KubeAPIServerCA(
+	client client.Client,
+	kubeAPIServer string,
+	defaultCA []byte,
+) ([]byte, error) {
+	certData, err := getKubeAPIServerNamedCertificate(client, kubeAPIServer)
+	if err != nil || len(certData) > 0 {
+		return certData, err
+	}
+
+	// fallback to service account token ca.crt
+	certData = defaultCA
+	// check if it's roks
+	// if it's ocp && it's on ibm cloud, we treat it as roks
+	isROKS, err := checkIsIBMCloud(client)
+	if err != nil {
+		return nil, err
+	}
+	if isROKS {
+		// ROKS should have a certificate that is signed by trusted CA
+		if certs, err := getValidCertificatesFromURL(kubeAPIServer, nil); err != nil {
+			// should retry if failed to connect to apiserver
+			log.Error(err, fmt.Sprintf("failed to connect to %s", kubeAPIServer))
+			return nil, err
+		} else if len(certs) > 0 {
+			// simply don't give any certs as the apiserver is using certs signed by known CAs
+			certData = nil
+		} else {
+			log.Info("No additional valid certificate found for APIserver. Skipping.")
+		}
+	}
+	return certData, nil
+}
+
+// kubernetesHubInfoProvider reads the hub information from the kube-public/cluster-info configmap
+// and falls back to the rest config of the controller. The configmap is read with the apiReader
+// to not cache all the configmaps of the hub for a single object.
+type kubernetesHubInfoProvider struct {
+	restConfig *rest.Config
+	apiReader  client.Reader
+}
+
+func (p *kubernetesHubInfoProvider) KubeAPIServerURL(client client.Client) (string, error) {
+	cluster, err := getClusterInfo(p.apiReader)
+	if err != nil {
+		return "", err
+	}
+	if cluster != nil && cluster.Server != "" {
+		return cluster.Server, nil
+	}
+	if p.restConfig == nil || p.restConfig.Host == "" {
+		return "", fmt.Errorf("failed to find the hub kube apiserver URL, it must be set with %s",
+			hubKubeAPIServerURLEnvVarName)
+	}
+	return p.restConfig.Host, nil
+}
+
+func (p *kubernetesHubInfoProvider) KubeAPIServerCA(
+	client client.Client,
+	kubeAPIServer string,
+	defaultCA []byte,
+) ([]byte, error) {
+	cluster, err := getClusterInfo(p.apiReader)
+	if err != nil {
+		return nil, err
+	}
+	if cluster != nil && cluster.Server == kubeAPIServer && len(cluster.CertificateAuthorityData) > 0 {
+		return cluster.CertificateAuthorityData, nil
+	}
+	if p.restConfig != nil && p.restConfig.Host == kubeAPIServer {
+		restConfig := rest.CopyConfig(p.restConfig)
+		if err := rest.LoadTLSFiles(restConfig); err != nil {
+			return nil, err
+		}
+		if len(restConfig.CAData) > 0 {
+			return restConfig.CAData, nil
+		}
+	}
+	return defaultCA, nil
+}
+
+// getClusterInfo returns the cluster of the kubeconfig published in the kube-public/cluster-info configmap,
+// nil is returned if the configmap doesn't exist
+func getClusterInfo(reader client.Reader) (*clientcmdapi.Cluster, error) {
+	cm := &corev1.ConfigMap{}
+	if err := reader.Get(
+		context.TODO(),
+		types.NamespacedName{Name: clusterInfoConfigMapName, Namespace: clusterInfoConfigMapNamespace},
+		cm,
+	); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	config, err := clientcmd.Load([]byte(cm.Data["kubeconfig"]))
+	if err != nil {
+		return nil, err
+	}
+	for _, cluster := range config.Clusters {
+		return cluster, nil
+	}
+	return nil, nil
+}
+
+// staticHubInfoProvider uses the hub information set in the controller configuration
+type staticHubInfoProvider struct {
+	kubeAPIServer string
+	// caSecret is the secret holding the CA bundle (ca.crt) of the hub kube apiserver
+	caSecret types.NamespacedName
+}
+
+func (p *staticHubInfoProvider) KubeAPIServerURL(client client.Client) (string, error) {
+	return p.kubeAPIServer, nil
+}
+
+func (p *staticHubInfoProvider) KubeAPIServerCA(
+	client client.Client,
+	kubeAPIServer string,
+	defaultCA []byte,
+) ([]byte, error) {
+	if p.caSecret.Name == "" {
+		return defaultCA, nil
+	}
+	return getCABundle(client, p.caSecret)
+}
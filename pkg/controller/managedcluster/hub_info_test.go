@@ -0,0 +1,195 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	ocinfrav1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func Test_newHubInfoProvider(t *testing.T) {
+	restConfig := &rest.Config{Host: "https://10.96.0.1:443"}
+	apiReader := fake.NewFakeClientWithScheme(scheme.Scheme)
+
+	tests := []struct {
+		name          string
+		kubeAPIServer string
+		missingGVS    []schema.GroupVersion
+		want          hubInfoProvider
+	}{
+		{
+			name:          "static",
+			kubeAPIServer: "https://api.hub.example.com:6443",
+			missingGVS:    []schema.GroupVersion{ocinfrav1.GroupVersion},
+			want:          &staticHubInfoProvider{kubeAPIServer: "https://api.hub.example.com:6443"},
+		},
+		{
+			name:       "openshift",
+			missingGVS: []schema.GroupVersion{{Group: "cluster.x-k8s.io", Version: "v1beta1"}},
+			want:       &openshiftHubInfoProvider{},
+		},
+		{
+			name:       "kubernetes",
+			missingGVS: []schema.GroupVersion{ocinfrav1.GroupVersion},
+			want:       &kubernetesHubInfoProvider{restConfig: restConfig, apiReader: apiReader},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.kubeAPIServer != "" {
+				os.Setenv(hubKubeAPIServerURLEnvVarName, tt.kubeAPIServer)
+				defer os.Unsetenv(hubKubeAPIServerURLEnvVarName)
+			}
+			got := newHubInfoProvider(restConfig, apiReader, tt.missingGVS)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("newHubInfoProvider() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_kubernetesHubInfoProvider(t *testing.T) {
+	kubeconfig, err := clientcmd.Write(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{"": {
+			Server:                   "https://api.kind.example.com:6443",
+			CertificateAuthorityData: []byte("cluster-info-ca-data"),
+		}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	clusterInfo := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      clusterInfoConfigMapName,
+			Namespace: clusterInfoConfigMapNamespace,
+		},
+		Data: map[string]string{"kubeconfig": string(kubeconfig)},
+	}
+	restConfig := &rest.Config{
+		Host: "https://10.96.0.1:443",
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: []byte("rest-config-ca-data"),
+		},
+	}
+
+	tests := []struct {
+		name    string
+		objs    []runtime.Object
+		wantURL string
+		wantCA  []byte
+	}{
+		{
+			name:    "from cluster-info",
+			objs:    []runtime.Object{clusterInfo},
+			wantURL: "https://api.kind.example.com:6443",
+			wantCA:  []byte("cluster-info-ca-data"),
+		},
+		{
+			name:    "from rest config",
+			wantURL: "https://10.96.0.1:443",
+			wantCA:  []byte("rest-config-ca-data"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the cluster-info configmap is read with the api reader, not the cached client
+			c := fake.NewFakeClientWithScheme(scheme.Scheme)
+			provider := &kubernetesHubInfoProvider{
+				restConfig: restConfig,
+				apiReader:  fake.NewFakeClientWithScheme(scheme.Scheme, tt.objs...),
+			}
+			gotURL, err := provider.KubeAPIServerURL(c)
+			if err != nil {
+				t.Errorf("KubeAPIServerURL() unexpected error %v", err)
+				return
+			}
+			if gotURL != tt.wantURL {
+				t.Errorf("KubeAPIServerURL() = %v, want %v", gotURL, tt.wantURL)
+			}
+			gotCA, err := provider.KubeAPIServerCA(c, gotURL, []byte("default-ca-data"))
+			if err != nil {
+				t.Errorf("KubeAPIServerCA() unexpected error %v", err)
+				return
+			}
+			if !reflect.DeepEqual(gotCA, tt.wantCA) {
+				t.Errorf("KubeAPIServerCA() = %s, want %s", gotCA, tt.wantCA)
+			}
+		})
+	}
+}
+
+func Test_staticHubInfoProvider(t *testing.T) {
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hub-ca",
+			Namespace: "open-cluster-management",
+		},
+		Data: map[string][]byte{
+			"ca.crt": []byte("static-ca-data"),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		provider *staticHubInfoProvider
+		wantCA   []byte
+		wantErr  bool
+	}{
+		{
+			name:     "default ca",
+			provider: &staticHubInfoProvider{kubeAPIServer: "https://api.hub.example.com:6443"},
+			wantCA:   []byte("default-ca-data"),
+		},
+		{
+			name: "ca secret",
+			provider: &staticHubInfoProvider{
+				kubeAPIServer: "https://api.hub.example.com:6443",
+				caSecret:      types.NamespacedName{Name: "hub-ca", Namespace: "open-cluster-management"},
+			},
+			wantCA: []byte("static-ca-data"),
+		},
+		{
+			name: "ca secret not found",
+			provider: &staticHubInfoProvider{
+				kubeAPIServer: "https://api.hub.example.com:6443",
+				caSecret:      types.NamespacedName{Name: "missing", Namespace: "open-cluster-management"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, caSecret)
+			gotURL, err := tt.provider.KubeAPIServerURL(c)
+			if err != nil {
+				t.Errorf("KubeAPIServerURL() unexpected error %v", err)
+				return
+			}
+			if gotURL != "https://api.hub.example.com:6443" {
+				t.Errorf("KubeAPIServerURL() = %v", gotURL)
+			}
+			gotCA, err := tt.provider.KubeAPIServerCA(c, gotURL, []byte("default-ca-data"))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("KubeAPIServerCA() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(gotCA, tt.wantCA) {
+				t.Errorf("KubeAPIServerCA() = %s, want %s", gotCA, tt.wantCA)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	workv1 "github.com/open-cluster-management/api/work/v1"
+	"github.com/open-cluster-management/managedcluster-import-controller/pkg/helpers"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// condition types reporting the phases of the import of a managed cluster
+const (
+	// ManagedClusterImportSecretGenerated reports if the <cluster>-import secret is generated
+	ManagedClusterImportSecretGenerated string = "ManagedClusterImportSecretGenerated"
+	// ManagedClusterManifestWorkApplied reports if the klusterlet manifestworks are applied by the work agent
+	ManagedClusterManifestWorkApplied string = "ManagedClusterManifestWorkApplied"
+	// ManagedClusterAutoImportAttempted reports the result of the last auto-import of the managed cluster
+	ManagedClusterAutoImportAttempted string = "ManagedClusterAutoImportAttempted"
+	// ManagedClusterImportDryRun reports the result of the dry-run of the auto-import of the managed cluster
+	ManagedClusterImportDryRun string = "ManagedClusterImportDryRun"
+	// ManagedClusterHibernating reports if the hive cluster of the managed cluster is hibernating
+	ManagedClusterHibernating string = "ManagedClusterHibernating"
+	// ManagedClusterKlusterletAvailable reports if the klusterlet has joined the hub and is available
+	ManagedClusterKlusterletAvailable string = "ManagedClusterKlusterletAvailable"
+	// ManagedClusterDetaching reports the progress of the detach of the managed cluster
+	ManagedClusterDetaching string = "ManagedClusterDetaching"
+)
+
+// setFailedCondition sets a False condition with the reason and the error errIn as message,
+// errIn is returned so the caller can return it as is.
+func setFailedCondition(
+	c client.Client,
+	managedCluster *clusterv1.ManagedCluster,
+	conditionType, reason string,
+	errIn error,
+) error {
+	if err := helpers.SetManagedClusterCondition(c, managedCluster, metav1.Condition{
+		Type:    conditionType,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: errIn.Error(),
+	}); err != nil {
+		klog.Error(err)
+	}
+	return errIn
+}
+
+// newKlusterletAvailableCondition derives the KlusterletAvailable condition from
+// the ManagedClusterConditionAvailable condition set by the registration controller
+func newKlusterletAvailableCondition(managedCluster *clusterv1.ManagedCluster) metav1.Condition {
+	available := meta.FindStatusCondition(managedCluster.Status.Conditions, clusterv1.ManagedClusterConditionAvailable)
+	switch {
+	case available == nil:
+		return metav1.Condition{
+			Type:    ManagedClusterKlusterletAvailable,
+			Status:  metav1.ConditionFalse,
+			Reason:  "KlusterletNotJoined",
+			Message: "The klusterlet has not joined the hub yet",
+		}
+	case available.Status == metav1.ConditionTrue:
+		return metav1.Condition{
+			Type:    ManagedClusterKlusterletAvailable,
+			Status:  metav1.ConditionTrue,
+			Reason:  "KlusterletAvailable",
+			Message: "The klusterlet is available",
+		}
+	default:
+		return metav1.Condition{
+			Type:    ManagedClusterKlusterletAvailable,
+			Status:  metav1.ConditionFalse,
+			Reason:  "KlusterletUnavailable",
+			Message: fmt.Sprintf("The klusterlet is not available: %s", available.Message),
+		}
+	}
+}
+
+// getManifestWorkAppliedCondition returns the ManifestWorkApplied condition according to
+// the Applied condition of the klusterlet manifestworks
+func getManifestWorkAppliedCondition(c client.Client, managedCluster *clusterv1.ManagedCluster) (metav1.Condition, error) {
+	mwNsN, err := manifestWorkNsN(managedCluster)
+	if err != nil {
+		return metav1.Condition{}, err
+	}
+	for _, name := range []string{mwNsN.Name + manifestWorkCRDSPostfix, mwNsN.Name} {
+		mw := &workv1.ManifestWork{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Name: name, Namespace: mwNsN.Namespace}, mw); err != nil {
+			if !errors.IsNotFound(err) {
+				return metav1.Condition{}, err
+			}
+		}
+		if !meta.IsStatusConditionTrue(mw.Status.Conditions, workv1.WorkApplied) {
+			return metav1.Condition{
+				Type:    ManagedClusterManifestWorkApplied,
+				Status:  metav1.ConditionFalse,
+				Reason:  "ManifestWorkPending",
+				Message: fmt.Sprintf("Waiting for the work agent to apply the manifestwork %s/%s", mwNsN.Namespace, name),
+			}, nil
+		}
+	}
+	return metav1.Condition{
+		Type:    ManagedClusterManifestWorkApplied,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ManifestWorkApplied",
+		Message: "The klusterlet manifestworks are applied",
+	}, nil
+}
+
+// setDetachingCondition sets the Detaching condition with the reason of the current detach step
+func setDetachingCondition(c client.Client, managedCluster *clusterv1.ManagedCluster, reason, message string) error {
+	return helpers.SetManagedClusterCondition(c, managedCluster, metav1.Condition{
+		Type:    ManagedClusterDetaching,
+		Status:  metav1.ConditionTrue,
+		Reason:  reason,
+		Message: message,
+	})
+}
@@ -0,0 +1,166 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	workv1 "github.com/open-cluster-management/api/work/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newManifestWork(name string, applied metav1.ConditionStatus) *workv1.ManifestWork {
+	return &workv1.ManifestWork{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "cluster1",
+		},
+		Status: workv1.ManifestWorkStatus{
+			Conditions: []metav1.Condition{
+				{Type: workv1.WorkApplied, Status: applied},
+			},
+		},
+	}
+}
+
+func Test_newKlusterletAvailableCondition(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []metav1.Condition
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "not joined",
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "KlusterletNotJoined",
+		},
+		{
+			name: "available",
+			conditions: []metav1.Condition{
+				{Type: clusterv1.ManagedClusterConditionAvailable, Status: metav1.ConditionTrue},
+			},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "KlusterletAvailable",
+		},
+		{
+			name: "unavailable",
+			conditions: []metav1.Condition{
+				{Type: clusterv1.ManagedClusterConditionAvailable, Status: metav1.ConditionUnknown},
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "KlusterletUnavailable",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedCluster := &clusterv1.ManagedCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster1",
+				},
+				Status: clusterv1.ManagedClusterStatus{
+					Conditions: tt.conditions,
+				},
+			}
+			got := newKlusterletAvailableCondition(managedCluster)
+			if got.Type != ManagedClusterKlusterletAvailable || got.Status != tt.wantStatus || got.Reason != tt.wantReason {
+				t.Errorf("newKlusterletAvailableCondition() = %v, want %v %v", got, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
+
+func Test_getManifestWorkAppliedCondition(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(workv1.SchemeGroupVersion, &workv1.ManifestWork{}, &workv1.ManifestWorkList{})
+
+	managedCluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster1",
+		},
+	}
+
+	tests := []struct {
+		name       string
+		objs       []runtime.Object
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name:       "no manifestworks",
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "ManifestWorkPending",
+		},
+		{
+			name: "crds not applied",
+			objs: []runtime.Object{
+				newManifestWork("cluster1-klusterlet-crds", metav1.ConditionFalse),
+				newManifestWork("cluster1-klusterlet", metav1.ConditionTrue),
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: "ManifestWorkPending",
+		},
+		{
+			name: "applied",
+			objs: []runtime.Object{
+				newManifestWork("cluster1-klusterlet-crds", metav1.ConditionTrue),
+				newManifestWork("cluster1-klusterlet", metav1.ConditionTrue),
+			},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: "ManifestWorkApplied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(testscheme, tt.objs...)
+			got, err := getManifestWorkAppliedCondition(c, managedCluster)
+			if err != nil {
+				t.Errorf("getManifestWorkAppliedCondition() unexpected error %v", err)
+				return
+			}
+			if got.Type != ManagedClusterManifestWorkApplied || got.Status != tt.wantStatus || got.Reason != tt.wantReason {
+				t.Errorf("getManifestWorkAppliedCondition() = %v, want %v %v", got, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
+
+func Test_setFailedCondition(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{})
+
+	managedCluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster1",
+		},
+	}
+	c := fake.NewFakeClientWithScheme(testscheme, managedCluster.DeepCopy())
+
+	errIn := fmt.Errorf("failed to create the import secret")
+	err := setFailedCondition(c, managedCluster, ManagedClusterImportSecretGenerated, "ImportSecretGenerationFailed", errIn)
+	if err != errIn {
+		t.Errorf("setFailedCondition() error = %v, want %v", err, errIn)
+	}
+
+	got := &clusterv1.ManagedCluster{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, got); err != nil {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+	condition := meta.FindStatusCondition(got.Status.Conditions, ManagedClusterImportSecretGenerated)
+	if condition == nil ||
+		condition.Status != metav1.ConditionFalse ||
+		condition.Reason != "ImportSecretGenerationFailed" ||
+		condition.Message != errIn.Error() {
+		t.Errorf("setFailedCondition() condition = %v", condition)
+	}
+}
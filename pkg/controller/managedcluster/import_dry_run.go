@@ -0,0 +1,322 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	"github.com/open-cluster-management/managedcluster-import-controller/pkg/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+const (
+	// importDryRunAnnotation on the ManagedCluster or the auto-import-secret replaces the auto-import
+	// by a server-side dry-run of the import manifests on the managed cluster
+	importDryRunAnnotation = "import.open-cluster-management.io/dry-run"
+	// importDryRunConfigMapPostfix is the postfix of the configmap holding the result of the dry-run
+	importDryRunConfigMapPostfix = "-import-dry-run"
+	// importDryRunMaxReportSize is the maximum size of the diff stored in the configmap
+	importDryRunMaxReportSize = 512 * 1024
+)
+
+// the operations reported by the dry-run for each import manifest
+const (
+	dryRunOperationCreate    = "create"
+	dryRunOperationUpdate    = "update"
+	dryRunOperationUnchanged = "unchanged"
+)
+
+// dryRunIgnoredMetadata are the metadata fields changed by the server which are not reported in the diff
+var dryRunIgnoredMetadata = []string{"managedFields", "resourceVersion", "generation", "uid", "creationTimestamp", "selfLink"}
+
+// secretDataFields are the fields of a Secret holding its values, the values are never reported in the diff
+var secretDataFields = []string{"data", "stringData"}
+
+// dryRunResult is the result of the dry-run of an import manifest
+type dryRunResult struct {
+	operation string
+	object    string
+	diff      string
+}
+
+// isImportDryRun returns true if the dry-run annotation is set to true on the managedCluster or the autoImportSecret
+func isImportDryRun(managedCluster *clusterv1.ManagedCluster, autoImportSecret *corev1.Secret) (bool, error) {
+	annotated := []metav1.Object{managedCluster}
+	if autoImportSecret != nil {
+		annotated = append(annotated, autoImportSecret)
+	}
+	for _, o := range annotated {
+		value, ok := o.GetAnnotations()[importDryRunAnnotation]
+		if !ok {
+			continue
+		}
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			return false, fmt.Errorf("invalid %s annotation %q on %s: %v", importDryRunAnnotation, value, o.GetName(), err)
+		}
+		if dryRun {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// objectReference returns the kind, namespace and name of u
+func objectReference(u *unstructured.Unstructured) string {
+	if u.GetNamespace() == "" {
+		return fmt.Sprintf("%s %s", u.GetKind(), u.GetName())
+	}
+	return fmt.Sprintf("%s %s/%s", u.GetKind(), u.GetNamespace(), u.GetName())
+}
+
+// normalizeDryRunObject returns a copy of u without the status and the metadata set by the server
+func normalizeDryRunObject(u *unstructured.Unstructured) map[string]interface{} {
+	normalized := u.DeepCopy()
+	unstructured.RemoveNestedField(normalized.Object, "status")
+	for _, field := range dryRunIgnoredMetadata {
+		unstructured.RemoveNestedField(normalized.Object, "metadata", field)
+	}
+	return normalized.Object
+}
+
+// mergeManifest merges the fields of the manifest into the object as a JSON merge patch would,
+// the fields not set by the manifest are kept
+func mergeManifest(object, manifest map[string]interface{}) {
+	for key, value := range manifest {
+		manifestField, isMap := value.(map[string]interface{})
+		objectField, ok := object[key].(map[string]interface{})
+		if isMap && ok {
+			mergeManifest(objectField, manifestField)
+			continue
+		}
+		object[key] = runtime.DeepCopyJSONValue(value)
+	}
+}
+
+// pruneToManifest returns the fields of the object set by the manifest, the other fields are set by the
+// server or by the other clients of the managed cluster and are not changed by the import
+func pruneToManifest(object, manifest map[string]interface{}) map[string]interface{} {
+	pruned := map[string]interface{}{}
+	for key, value := range manifest {
+		objectField, ok := object[key]
+		if !ok {
+			continue
+		}
+		manifestField, isMap := value.(map[string]interface{})
+		objectFieldMap, ok := objectField.(map[string]interface{})
+		if isMap && ok {
+			pruned[key] = pruneToManifest(objectFieldMap, manifestField)
+			continue
+		}
+		pruned[key] = objectField
+	}
+	return pruned
+}
+
+// dryRunApply applies u on the managed cluster with a server-side dry-run, as the applier would, and returns
+// the diff of the fields of u between the current and the resulting object. The objects in the namespaces
+// to create by the import cannot be validated by the server, they are reported as created.
+func dryRunApply(managedClusterClient client.Client, u *unstructured.Unstructured, namespacesToCreate sets.String) (dryRunResult, error) {
+	result := dryRunResult{object: objectReference(u)}
+	if namespacesToCreate.Has(u.GetNamespace()) {
+		result.operation = dryRunOperationCreate
+		result.diff = "not validated by the server, its namespace does not exist yet"
+		return result, nil
+	}
+	current := &unstructured.Unstructured{}
+	current.SetGroupVersionKind(u.GroupVersionKind())
+	err := managedClusterClient.Get(context.TODO(), types.NamespacedName{Name: u.GetName(), Namespace: u.GetNamespace()}, current)
+	switch {
+	case meta.IsNoMatchError(err):
+		// the kind is defined by a crd of the import which is not created by the dry-run
+		result.operation = dryRunOperationCreate
+		result.diff = "not validated by the server, its CustomResourceDefinition does not exist yet"
+		return result, nil
+	case errors.IsNotFound(err):
+		result.operation = dryRunOperationCreate
+		return result, managedClusterClient.Create(context.TODO(), u.DeepCopy(), client.DryRunAll)
+	case err != nil:
+		return result, err
+	}
+
+	// the manifest is the merge patch, the fields it does not set are kept as they are on the managed cluster
+	patch := u.DeepCopy()
+	patch.SetResourceVersion(current.GetResourceVersion())
+	data, err := patch.MarshalJSON()
+	if err != nil {
+		return result, err
+	}
+	future := current.DeepCopy()
+	mergeManifest(future.Object, u.Object)
+	if err := managedClusterClient.Patch(context.TODO(), future,
+		client.RawPatch(types.MergePatchType, data), client.DryRunAll); err != nil {
+		return result, err
+	}
+	currentObject := pruneToManifest(normalizeDryRunObject(current), u.Object)
+	futureObject := pruneToManifest(normalizeDryRunObject(future), u.Object)
+	changedKeys := []string{}
+	if u.GetKind() == "Secret" {
+		// the secrets hold the bootstrap token and the registry credentials, only their changed keys are reported
+		changedKeys = redactSecretData(currentObject, futureObject)
+	}
+	if reflect.DeepEqual(currentObject, futureObject) && len(changedKeys) == 0 {
+		result.operation = dryRunOperationUnchanged
+		return result, nil
+	}
+	result.operation = dryRunOperationUpdate
+	if !reflect.DeepEqual(currentObject, futureObject) {
+		result.diff = cmp.Diff(currentObject, futureObject)
+	}
+	if len(changedKeys) != 0 {
+		result.diff += fmt.Sprintf("\nredacted values of the changed keys: %s", strings.Join(changedKeys, ", "))
+	}
+	return result, nil
+}
+
+// redactSecretData removes the values of the secret from the current and the future objects and returns
+// the keys whose values are added, changed or removed
+func redactSecretData(current, future map[string]interface{}) []string {
+	changedKeys := []string{}
+	for _, field := range secretDataFields {
+		currentData, _ := current[field].(map[string]interface{})
+		futureData, _ := future[field].(map[string]interface{})
+		for _, key := range sets.StringKeySet(currentData).Union(sets.StringKeySet(futureData)).List() {
+			currentValue, inCurrent := currentData[key]
+			futureValue, inFuture := futureData[key]
+			switch {
+			case !inCurrent:
+				changedKeys = append(changedKeys, fmt.Sprintf("%s.%s (added)", field, key))
+			case !inFuture:
+				changedKeys = append(changedKeys, fmt.Sprintf("%s.%s (removed)", field, key))
+			case !reflect.DeepEqual(currentValue, futureValue):
+				changedKeys = append(changedKeys, fmt.Sprintf("%s.%s (changed)", field, key))
+			}
+		}
+		delete(current, field)
+		delete(future, field)
+	}
+	return changedKeys
+}
+
+// dryRunReport returns the summary and the diff of the dry-run results
+func dryRunReport(results []dryRunResult) (string, string) {
+	counts := map[string]int{}
+	var report strings.Builder
+	for _, result := range results {
+		counts[result.operation]++
+		fmt.Fprintf(&report, "%s %s\n", result.operation, result.object)
+		if result.diff != "" {
+			fmt.Fprintf(&report, "%s\n", strings.TrimSpace(result.diff))
+		}
+	}
+	summary := fmt.Sprintf("%d to create, %d to update, %d unchanged",
+		counts[dryRunOperationCreate], counts[dryRunOperationUpdate], counts[dryRunOperationUnchanged])
+	diff := report.String()
+	if len(diff) > importDryRunMaxReportSize {
+		diff = diff[:importDryRunMaxReportSize] + "\n... truncated\n"
+	}
+	return summary, diff
+}
+
+// createOrUpdateDryRunConfigMap stores the result of the dry-run in the <cluster>-import-dry-run configmap
+func createOrUpdateDryRunConfigMap(
+	c client.Client,
+	scheme *runtime.Scheme,
+	managedCluster *clusterv1.ManagedCluster,
+	summary, diff string) (*corev1.ConfigMap, error) {
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      managedCluster.Name + importDryRunConfigMapPostfix,
+			Namespace: managedCluster.Name,
+		},
+	}
+	_, err := controllerutil.CreateOrUpdate(context.TODO(), c, configMap, func() error {
+		configMap.Data = map[string]string{
+			"summary": summary,
+			"diff":    diff,
+		}
+		return controllerutil.SetControllerReference(managedCluster, configMap, scheme)
+	})
+	return configMap, err
+}
+
+// dryRunImport runs a server-side dry-run of the import manifests on the managed cluster, the result is
+// stored in the <cluster>-import-dry-run configmap and the ManagedClusterImportDryRun condition.
+// Nothing is applied on the managed cluster and the auto-import retries are not consumed.
+func (r *ReconcileManagedCluster) dryRunImport(
+	managedCluster *clusterv1.ManagedCluster,
+	clusterDeployment *hivev1.ClusterDeployment,
+	autoImportSecret *corev1.Secret) error {
+	results, err := r.dryRunImportWithClient(managedCluster, clusterDeployment, autoImportSecret)
+	if err != nil {
+		r.recorder.Eventf(managedCluster, corev1.EventTypeWarning, "ImportDryRunFailed",
+			"Failed to dry-run the import: %v", err)
+		return setFailedCondition(r.client, managedCluster, ManagedClusterImportDryRun, "DryRunFailed", err)
+	}
+
+	summary, diff := dryRunReport(results)
+	configMap, err := createOrUpdateDryRunConfigMap(r.client, r.scheme, managedCluster, summary, diff)
+	if err != nil {
+		return err
+	}
+	r.recorder.Eventf(managedCluster, corev1.EventTypeNormal, "ImportDryRun",
+		"The dry-run of the import completed, %s", summary)
+	return helpers.SetManagedClusterCondition(r.client, managedCluster, metav1.Condition{
+		Type:   ManagedClusterImportDryRun,
+		Status: metav1.ConditionTrue,
+		Reason: "DryRunCompleted",
+		Message: fmt.Sprintf("The import would apply %s, see the configmap %s/%s",
+			summary, configMap.Namespace, configMap.Name),
+	})
+}
+
+func (r *ReconcileManagedCluster) dryRunImportWithClient(
+	managedCluster *clusterv1.ManagedCluster,
+	clusterDeployment *hivev1.ClusterDeployment,
+	autoImportSecret *corev1.Secret) ([]dryRunResult, error) {
+	managedClusterClient, rConfig, err := r.getManagedClusterClient(managedCluster, clusterDeployment, autoImportSecret)
+	if err != nil {
+		return nil, err
+	}
+	managedClusterKubeVersion, err := getManagedClusterKubeVersion(rConfig)
+	if err != nil {
+		return nil, err
+	}
+	crds, yamls, _, err := r.getImportObjects(managedCluster, managedClusterClient, managedClusterKubeVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := make([]*unstructured.Unstructured, 0, len(crds)+len(yamls))
+	objects = append(append(objects, crds...), yamls...)
+	results := make([]dryRunResult, 0, len(objects))
+	namespacesToCreate := sets.NewString()
+	for _, u := range objects {
+		result, err := dryRunApply(managedClusterClient, u, namespacesToCreate)
+		if err != nil {
+			return nil, fmt.Errorf("the dry-run of %s failed: %v", result.object, err)
+		}
+		if u.GetKind() == "Namespace" && result.operation == dryRunOperationCreate {
+			namespacesToCreate.Insert(u.GetName())
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
@@ -0,0 +1,249 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newDryRunConfigMap(value string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":      "bootstrap-hub-config",
+			"namespace": "open-cluster-management-agent",
+		},
+		"data": map[string]interface{}{
+			"value": value,
+		},
+	}}
+}
+
+func Test_isImportDryRun(t *testing.T) {
+	tests := []struct {
+		name         string
+		clusterValue string
+		secretValue  string
+		noSecret     bool
+		want         bool
+		wantErr      bool
+	}{
+		{name: "no annotation", noSecret: true},
+		{name: "managed cluster", clusterValue: "true", noSecret: true, want: true},
+		{name: "auto-import-secret", secretValue: "true", want: true},
+		{name: "disabled on the managed cluster", clusterValue: "false", secretValue: "true", want: true},
+		{name: "invalid", clusterValue: "yes please", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedCluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1", Annotations: map[string]string{}}}
+			if tt.clusterValue != "" {
+				managedCluster.Annotations[importDryRunAnnotation] = tt.clusterValue
+			}
+			var autoImportSecret *corev1.Secret
+			if !tt.noSecret {
+				autoImportSecret = &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: autoImportSecretName, Annotations: map[string]string{}}}
+				if tt.secretValue != "" {
+					autoImportSecret.Annotations[importDryRunAnnotation] = tt.secretValue
+				}
+			}
+			got, err := isImportDryRun(managedCluster, autoImportSecret)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("isImportDryRun() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("isImportDryRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_dryRunApply(t *testing.T) {
+	// the fields set by the server or the other clients are not in the import manifest
+	serverFields := newDryRunConfigMap("hub")
+	serverFields.SetLabels(map[string]string{"app": "other"})
+	_ = unstructured.SetNestedField(serverFields.Object, "value", "data", "other")
+
+	tests := []struct {
+		name               string
+		objects            []runtime.Object
+		namespacesToCreate []string
+		wantOperation      string
+		wantDiff           string
+	}{
+		{
+			name:          "create",
+			wantOperation: dryRunOperationCreate,
+		},
+		{
+			name:          "unchanged",
+			objects:       []runtime.Object{newDryRunConfigMap("hub")},
+			wantOperation: dryRunOperationUnchanged,
+		},
+		{
+			name:          "update",
+			objects:       []runtime.Object{newDryRunConfigMap("old-hub")},
+			wantOperation: dryRunOperationUpdate,
+			wantDiff:      "old-hub",
+		},
+		{
+			name:          "fields not in the manifest",
+			objects:       []runtime.Object{serverFields},
+			wantOperation: dryRunOperationUnchanged,
+		},
+		{
+			name:               "namespace to create",
+			namespacesToCreate: []string{"open-cluster-management-agent"},
+			wantOperation:      dryRunOperationCreate,
+			wantDiff:           "its namespace does not exist yet",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, tt.objects...)
+			result, err := dryRunApply(c, newDryRunConfigMap("hub"), sets.NewString(tt.namespacesToCreate...))
+			if err != nil {
+				t.Errorf("dryRunApply() unexpected error %v", err)
+				return
+			}
+			if result.operation != tt.wantOperation {
+				t.Errorf("dryRunApply() operation = %s, want %s", result.operation, tt.wantOperation)
+			}
+			if !strings.Contains(result.diff, tt.wantDiff) || (tt.wantDiff == "" && result.diff != "") {
+				t.Errorf("dryRunApply() diff = %q, want %q", result.diff, tt.wantDiff)
+			}
+			// nothing is applied
+			configMap := &corev1.ConfigMap{}
+			err = c.Get(context.TODO(), types.NamespacedName{Name: "bootstrap-hub-config", Namespace: "open-cluster-management-agent"}, configMap)
+			if len(tt.objects) == 0 && err == nil {
+				t.Errorf("expected the configmap not to be created")
+			}
+			if len(tt.objects) != 0 && configMap.Data["value"] == "hub" && tt.wantOperation == dryRunOperationUpdate {
+				t.Errorf("expected the configmap not to be updated")
+			}
+		})
+	}
+}
+
+func newDryRunSecret(token, label string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]interface{}{
+			"name":      "bootstrap-hub-kubeconfig",
+			"namespace": "open-cluster-management-agent",
+			"labels":    map[string]interface{}{"app": label},
+		},
+		"data": map[string]interface{}{
+			"kubeconfig": "a3ViZWNvbmZpZw==",
+			"token":      token,
+		},
+	}}
+}
+
+func Test_dryRunApply_secret(t *testing.T) {
+	tests := []struct {
+		name          string
+		current       *unstructured.Unstructured
+		wantOperation string
+		wantDiff      []string
+	}{
+		{
+			name:          "unchanged",
+			current:       newDryRunSecret("bmV3LXRva2Vu", "klusterlet"),
+			wantOperation: dryRunOperationUnchanged,
+		},
+		{
+			name:          "token changed",
+			current:       newDryRunSecret("b2xkLXRva2Vu", "klusterlet"),
+			wantOperation: dryRunOperationUpdate,
+			wantDiff:      []string{"data.token (changed)"},
+		},
+		{
+			name:          "token and label changed",
+			current:       newDryRunSecret("b2xkLXRva2Vu", "other"),
+			wantOperation: dryRunOperationUpdate,
+			wantDiff:      []string{"data.token (changed)", "other"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, tt.current)
+			result, err := dryRunApply(c, newDryRunSecret("bmV3LXRva2Vu", "klusterlet"), sets.NewString())
+			if err != nil {
+				t.Errorf("dryRunApply() unexpected error %v", err)
+				return
+			}
+			if result.operation != tt.wantOperation {
+				t.Errorf("dryRunApply() operation = %s, want %s", result.operation, tt.wantOperation)
+			}
+			for _, want := range tt.wantDiff {
+				if !strings.Contains(result.diff, want) {
+					t.Errorf("dryRunApply() diff = %q, want %q", result.diff, want)
+				}
+			}
+			for _, value := range []string{"bmV3LXRva2Vu", "b2xkLXRva2Vu", "a3ViZWNvbmZpZw=="} {
+				if strings.Contains(result.diff, value) {
+					t.Errorf("dryRunApply() diff = %q, the value %q of the secret is not redacted", result.diff, value)
+				}
+			}
+		})
+	}
+}
+
+func Test_dryRunReport(t *testing.T) {
+	summary, diff := dryRunReport([]dryRunResult{
+		{operation: dryRunOperationCreate, object: "Namespace open-cluster-management-agent"},
+		{operation: dryRunOperationUpdate, object: "Deployment open-cluster-management-agent/klusterlet", diff: "-a\n+b\n"},
+		{operation: dryRunOperationUnchanged, object: "ServiceAccount open-cluster-management-agent/klusterlet"},
+	})
+	if summary != "1 to create, 1 to update, 1 unchanged" {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	want := "create Namespace open-cluster-management-agent\n" +
+		"update Deployment open-cluster-management-agent/klusterlet\n-a\n+b\n" +
+		"unchanged ServiceAccount open-cluster-management-agent/klusterlet\n"
+	if diff != want {
+		t.Errorf("unexpected diff %q, want %q", diff, want)
+	}
+}
+
+func Test_createOrUpdateDryRunConfigMap(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{})
+	managedCluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}}
+	c := fake.NewFakeClientWithScheme(testscheme, managedCluster)
+
+	for _, summary := range []string{"1 to create, 0 to update, 0 unchanged", "0 to create, 0 to update, 1 unchanged"} {
+		if _, err := createOrUpdateDryRunConfigMap(c, testscheme, managedCluster, summary, "diff"); err != nil {
+			t.Errorf("createOrUpdateDryRunConfigMap() unexpected error %v", err)
+			return
+		}
+		configMap := &corev1.ConfigMap{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Name: "cluster1-import-dry-run", Namespace: "cluster1"}, configMap); err != nil {
+			t.Errorf("unexpected error %v", err)
+			return
+		}
+		if configMap.Data["summary"] != summary {
+			t.Errorf("expected the summary %q, got %q", summary, configMap.Data["summary"])
+		}
+		if len(configMap.OwnerReferences) != 1 || configMap.OwnerReferences[0].Name != "cluster1" {
+			t.Errorf("expected the managed cluster as owner, got %v", configMap.OwnerReferences)
+		}
+	}
+}
@@ -143,6 +143,7 @@ func createOrUpdateManifestWork(
 			if err != nil {
 				return nil, err
 			}
+			manifestWorkUpdates.WithLabelValues("create").Inc()
 		} else {
 			return nil, err
 		}
@@ -153,6 +154,7 @@ func createOrUpdateManifestWork(
 			if err := client.Update(context.TODO(), oldManifestWork); err != nil {
 				return nil, err
 			}
+			manifestWorkUpdates.WithLabelValues("update").Inc()
 		}
 	}
 	return mw, nil
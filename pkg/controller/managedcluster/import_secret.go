@@ -15,6 +15,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -98,6 +99,7 @@ func newImportSecret(
 
 func createOrUpdateImportSecret(
 	client client.Client,
+	recorder record.EventRecorder,
 	scheme *runtime.Scheme,
 	managedCluster *clusterv1.ManagedCluster,
 	crds map[string][]*unstructured.Unstructured,
@@ -120,6 +122,8 @@ func createOrUpdateImportSecret(
 			if err != nil {
 				return nil, err
 			}
+			recorder.Eventf(managedCluster, corev1.EventTypeNormal, "ImportSecretGenerated",
+				"The import secret %s/%s is generated", secret.Namespace, secret.Name)
 		} else {
 			return nil, err
 		}
@@ -132,6 +136,8 @@ func createOrUpdateImportSecret(
 			if err := client.Update(context.TODO(), oldImportSecret); err != nil {
 				return nil, err
 			}
+			recorder.Eventf(managedCluster, corev1.EventTypeNormal, "ImportSecretUpdated",
+				"The import secret %s/%s is updated", secret.Namespace, secret.Name)
 		}
 	}
 
@@ -25,6 +25,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
@@ -419,6 +420,7 @@ func Test_createOrUpdateImportSecret(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Logf("Test name: %s", tt.name)
 			got, err := createOrUpdateImportSecret(tt.args.client,
+				record.NewFakeRecorder(10),
 				tt.args.scheme,
 				tt.args.managedCluster,
 				tt.args.crds,
@@ -480,6 +482,14 @@ func TestTemplating(t *testing.T) {
 		HubKubeConfigSecretName   string
 		HubKubeConfigSecret       string
 		RegistrationOperatorImage string
+		RegistrationImageName     string
+		WorkImageName             string
+		ManagedClusterNamespace   string
+		NodeSelector              map[string]string
+		Tolerations               []corev1.Toleration
+		HTTPProxy                 string
+		HTTPSProxy                string
+		NoProxy                   string
 	}{
 		ClusterName:               "klusterlet",
 		KlusterletNamespace:       "KlusterletNamespace",
@@ -491,6 +501,19 @@ func TestTemplating(t *testing.T) {
 		HubKubeConfigSecretName:   "HubKubeConfigSecretName",
 		HubKubeConfigSecret:       "HubKubeConfigSecret",
 		RegistrationOperatorImage: "RegistrationOperatorImage",
+		RegistrationImageName:     "RegistrationImageName",
+		WorkImageName:             "WorkImageName",
+		ManagedClusterNamespace:   "ManagedClusterNamespace",
+		NodeSelector:              map[string]string{"kubernetes.io/os": "linux"},
+		Tolerations: []corev1.Toleration{
+			{
+				Key:      "node-role.kubernetes.io/infra",
+				Operator: corev1.TolerationOpExists,
+				Effect:   corev1.TaintEffectNoSchedule,
+			},
+		},
+		HTTPSProxy: "https://proxy.example.com:3128",
+		NoProxy:    "localhost,.cluster.local",
 	}
 
 	tp, err := templateprocessor.NewTemplateProcessor(bindata.NewBindataReader(), &templateprocessor.Options{})
@@ -498,7 +521,7 @@ func TestTemplating(t *testing.T) {
 		t.Error(err)
 	}
 	results, err := tp.TemplateResources([]string{"klusterlet/cluster_role_binding.yaml",
-		"klusterlet/operator.yaml"}, config)
+		"klusterlet/operator.yaml", "klusterlet/klusterlet.yaml"}, config)
 	if err != nil {
 		t.Error(err)
 	}
@@ -517,6 +540,24 @@ func TestTemplating(t *testing.T) {
 		t.Errorf("Errorr %s %s", err.Error(), string(results[1]))
 	}
 	g.Expect(deployment.Namespace).Should(Equal("KlusterletNamespace"))
+	g.Expect(deployment.Spec.Template.Spec.NodeSelector).Should(Equal(config.NodeSelector))
+	g.Expect(deployment.Spec.Template.Spec.Tolerations).Should(Equal(config.Tolerations))
+	g.Expect(deployment.Spec.Template.Spec.Containers[0].Env).Should(Equal([]corev1.EnvVar{
+		{Name: "HTTPS_PROXY", Value: "https://proxy.example.com:3128"},
+		{Name: "NO_PROXY", Value: "localhost,.cluster.local"},
+	}))
+
+	klusterlet := &unstructured.Unstructured{}
+	t.Logf("Klusterlet %s", string(results[2]))
+	err = yaml.Unmarshal(results[2], &klusterlet.Object)
+	if err != nil {
+		t.Errorf("Errorr %s %s", err.Error(), string(results[2]))
+	}
+	// the Klusterlet CRD of the vendored operator has no proxy settings
+	_, found, _ := unstructured.NestedFieldNoCopy(klusterlet.Object, "spec", "proxyConfig")
+	g.Expect(found).Should(BeFalse())
+	_, found, _ = unstructured.NestedFieldNoCopy(klusterlet.Object, "spec", "nodePlacement")
+	g.Expect(found).Should(BeFalse())
 }
 
 // newBootstrapServiceAccount initialize a new bootstrap serviceaccount
@@ -0,0 +1,303 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	"github.com/open-cluster-management/applier/pkg/applier"
+	"github.com/open-cluster-management/applier/pkg/templateprocessor"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// the environment variables configuring the timeouts of the stages of the auto-import
+	crdEstablishedTimeoutEnvVarName  = "AUTO_IMPORT_CRD_ESTABLISHED_TIMEOUT"
+	operatorRolloutTimeoutEnvVarName = "AUTO_IMPORT_OPERATOR_ROLLOUT_TIMEOUT"
+	// importStageAnnotation on the ManagedCluster records the stage of the auto-import waiting for the managed
+	// cluster and the time the wait started, <stage>/<RFC3339 time>, the timeout of the stage is counted
+	// across the reconciles
+	importStageAnnotation = "import.open-cluster-management.io/import-stage"
+)
+
+// the stages of the auto-import, applied in this order
+const (
+	importStageNamespaceAndRBAC = "NamespaceAndRBAC"
+	importStageCRDs             = "CRDs"
+	importStageOperator         = "Operator"
+	importStageKlusterlet       = "Klusterlet"
+)
+
+// importStageRequeueInterval is the interval between the checks of the health of a stage
+var importStageRequeueInterval = 5 * time.Second
+
+// importStageTimeouts are the timeouts of the stages waiting for the managed cluster
+type importStageTimeouts struct {
+	crdEstablished  time.Duration
+	operatorRollout time.Duration
+}
+
+// importStageError is the failure of a stage of the auto-import, its reason is reported
+// in the ManagedClusterAutoImportAttempted condition
+type importStageError struct {
+	stage  string
+	reason string
+	err    error
+}
+
+func (e *importStageError) Error() string {
+	return fmt.Sprintf("the %s stage of the import failed: %v", e.stage, e.err)
+}
+
+func (e *importStageError) Unwrap() error {
+	return e.err
+}
+
+// getImportStageFailureReason returns the reason of the failed stage of err, empty if err is not a stage failure
+func getImportStageFailureReason(err error) string {
+	var stageErr *importStageError
+	if errors.As(err, &stageErr) {
+		return stageErr.reason
+	}
+	return ""
+}
+
+// importStageProgress is the stage of the auto-import waiting for its objects to be healthy
+type importStageProgress struct {
+	stage string
+	since time.Time
+}
+
+// getImportStageProgress returns the stage of the auto-import of the managedCluster waiting for its objects,
+// nil if no stage is waiting
+func getImportStageProgress(managedCluster *clusterv1.ManagedCluster) *importStageProgress {
+	value, ok := managedCluster.GetAnnotations()[importStageAnnotation]
+	if !ok {
+		return nil
+	}
+	i := strings.LastIndex(value, "/")
+	if i < 0 {
+		return nil
+	}
+	since, err := time.Parse(time.RFC3339, value[i+1:])
+	if err != nil {
+		return nil
+	}
+	return &importStageProgress{stage: value[:i], since: since}
+}
+
+// setImportStageProgress records the stage of the auto-import waiting for its objects on the managedCluster,
+// the record is removed if progress is nil
+func setImportStageProgress(c client.Client, managedCluster *clusterv1.ManagedCluster, progress *importStageProgress) error {
+	value := ""
+	if progress != nil {
+		value = fmt.Sprintf("%s/%s", progress.stage, progress.since.UTC().Format(time.RFC3339))
+	}
+	current, ok := managedCluster.GetAnnotations()[importStageAnnotation]
+	if current == value && ok == (progress != nil) {
+		return nil
+	}
+	patch := client.MergeFrom(managedCluster.DeepCopy())
+	annotations := managedCluster.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	if progress == nil {
+		delete(annotations, importStageAnnotation)
+	} else {
+		annotations[importStageAnnotation] = value
+	}
+	managedCluster.SetAnnotations(annotations)
+	return c.Patch(context.TODO(), managedCluster, patch)
+}
+
+// importStage applies its objects then waits until they are healthy
+type importStage struct {
+	name        string
+	objects     []*unstructured.Unstructured
+	applyReason string
+	wait        func(client.Client, []*unstructured.Unstructured) (bool, error)
+	waitReason  string
+	timeout     time.Duration
+}
+
+// getImportStageTimeouts returns the default timeouts overridden by the environment variables
+func getImportStageTimeouts() (*importStageTimeouts, error) {
+	timeouts := &importStageTimeouts{
+		crdEstablished:  time.Minute,
+		operatorRollout: 3 * time.Minute,
+	}
+	var err error
+	if value := os.Getenv(crdEstablishedTimeoutEnvVarName); value != "" {
+		if timeouts.crdEstablished, err = time.ParseDuration(value); err != nil || timeouts.crdEstablished <= 0 {
+			return nil, fmt.Errorf("invalid %s: %q", crdEstablishedTimeoutEnvVarName, value)
+		}
+	}
+	if value := os.Getenv(operatorRolloutTimeoutEnvVarName); value != "" {
+		if timeouts.operatorRollout, err = time.ParseDuration(value); err != nil || timeouts.operatorRollout <= 0 {
+			return nil, fmt.Errorf("invalid %s: %q", operatorRolloutTimeoutEnvVarName, value)
+		}
+	}
+	return timeouts, nil
+}
+
+// newImportStages splits the import manifests into the ordered stages of the auto-import
+func newImportStages(crds, yamls []*unstructured.Unstructured, timeouts *importStageTimeouts) []importStage {
+	var resources, operators, klusterlets []*unstructured.Unstructured
+	for _, u := range yamls {
+		switch u.GetKind() {
+		case "Deployment":
+			operators = append(operators, u)
+		case "Klusterlet":
+			klusterlets = append(klusterlets, u)
+		default:
+			resources = append(resources, u)
+		}
+	}
+	return []importStage{
+		{
+			name:        importStageNamespaceAndRBAC,
+			objects:     resources,
+			applyReason: "NamespaceAndRBACApplyFailed",
+		},
+		{
+			name:        importStageCRDs,
+			objects:     crds,
+			applyReason: "CRDApplyFailed",
+			wait:        crdsEstablished,
+			waitReason:  "CRDNotEstablished",
+			timeout:     timeouts.crdEstablished,
+		},
+		{
+			name:        importStageOperator,
+			objects:     operators,
+			applyReason: "OperatorApplyFailed",
+			wait:        deploymentsRolledOut,
+			waitReason:  "OperatorNotReady",
+			timeout:     timeouts.operatorRollout,
+		},
+		{
+			name:        importStageKlusterlet,
+			objects:     klusterlets,
+			applyReason: "KlusterletApplyFailed",
+		},
+	}
+}
+
+// applyImportStages applies the stages in order on the managed cluster, a stage waiting for its objects to be
+// healthy is checked once without blocking the reconcile. The waiting stage is returned with the time its wait
+// started, kept from progress across the reconciles, and the stage fails once its timeout expires.
+func applyImportStages(
+	managedClusterClient client.Client,
+	stages []importStage,
+	excluded []string,
+	progress *importStageProgress,
+	now time.Time) (*importStageProgress, error) {
+	for _, stage := range stages {
+		if len(stage.objects) == 0 {
+			continue
+		}
+		klog.V(2).Infof("Applying the %s stage of the import", stage.name)
+		if err := applyUnstructureds(managedClusterClient, stage.objects, excluded); err != nil {
+			return nil, &importStageError{stage: stage.name, reason: stage.applyReason, err: err}
+		}
+		if stage.wait == nil {
+			continue
+		}
+		healthy, err := stage.wait(managedClusterClient, stage.objects)
+		if healthy {
+			continue
+		}
+		since := now
+		if progress != nil && progress.stage == stage.name && !progress.since.After(now) {
+			since = progress.since
+		}
+		if now.Sub(since) >= stage.timeout {
+			if err == nil {
+				err = fmt.Errorf("the objects are not healthy")
+			}
+			return nil, &importStageError{stage: stage.name, reason: stage.waitReason,
+				err: fmt.Errorf("not ready after %s: %v", stage.timeout, err)}
+		}
+		klog.V(2).Infof("Waiting for the %s stage of the import since %s: %v", stage.name, since.UTC().Format(time.RFC3339), err)
+		return &importStageProgress{stage: stage.name, since: since}, nil
+	}
+	return nil, nil
+}
+
+// applyUnstructureds creates or updates the objects on the managed cluster with the applier
+func applyUnstructureds(managedClusterClient client.Client, objects []*unstructured.Unstructured, excluded []string) error {
+	bb, err := templateprocessor.ToYAMLsUnstructured(objects)
+	if err != nil {
+		return err
+	}
+	a, err := applier.NewApplier(
+		templateprocessor.NewYamlStringReader(templateprocessor.ConvertArrayOfBytesToString(bb),
+			templateprocessor.KubernetesYamlsDelimiter),
+		nil,
+		managedClusterClient,
+		nil,
+		nil,
+		nil)
+	if err != nil {
+		return err
+	}
+	return a.CreateOrUpdateInPath(".", excluded, false, nil)
+}
+
+// crdsEstablished returns true once all the crds have the Established condition
+func crdsEstablished(managedClusterClient client.Client, crds []*unstructured.Unstructured) (bool, error) {
+	for _, crd := range crds {
+		current := &unstructured.Unstructured{}
+		current.SetGroupVersionKind(crd.GroupVersionKind())
+		if err := managedClusterClient.Get(context.TODO(), types.NamespacedName{Name: crd.GetName()}, current); err != nil {
+			return false, err
+		}
+		conditions, _, _ := unstructured.NestedSlice(current.Object, "status", "conditions")
+		established := false
+		for _, c := range conditions {
+			condition, ok := c.(map[string]interface{})
+			if ok && condition["type"] == "Established" && condition["status"] == "True" {
+				established = true
+			}
+		}
+		if !established {
+			return false, fmt.Errorf("the CustomResourceDefinition %s is not established", crd.GetName())
+		}
+	}
+	return true, nil
+}
+
+// deploymentsRolledOut returns true once all the deployments are rolled out and available
+func deploymentsRolledOut(managedClusterClient client.Client, deployments []*unstructured.Unstructured) (bool, error) {
+	for _, u := range deployments {
+		deployment := &appsv1.Deployment{}
+		if err := managedClusterClient.Get(context.TODO(),
+			types.NamespacedName{Name: u.GetName(), Namespace: u.GetNamespace()}, deployment); err != nil {
+			return false, err
+		}
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		if deployment.Status.ObservedGeneration < deployment.Generation ||
+			deployment.Status.UpdatedReplicas < replicas ||
+			deployment.Status.AvailableReplicas < replicas {
+			return false, fmt.Errorf("the deployment %s/%s has %d/%d updated and %d/%d available replicas",
+				deployment.Namespace, deployment.Name, deployment.Status.UpdatedReplicas, replicas,
+				deployment.Status.AvailableReplicas, replicas)
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,284 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// healthyManagedClusterClient simulates the controllers of the managed cluster,
+// the crds are established and the deployments are rolled out once applied
+type healthyManagedClusterClient struct {
+	client.Client
+}
+
+func (c healthyManagedClusterClient) Get(ctx context.Context, key client.ObjectKey, obj runtime.Object) error {
+	if err := c.Client.Get(ctx, key, obj); err != nil {
+		return err
+	}
+	switch o := obj.(type) {
+	case *appsv1.Deployment:
+		replicas := int32(1)
+		if o.Spec.Replicas != nil {
+			replicas = *o.Spec.Replicas
+		}
+		o.Status.ObservedGeneration = o.Generation
+		o.Status.UpdatedReplicas = replicas
+		o.Status.AvailableReplicas = replicas
+	case *unstructured.Unstructured:
+		if o.GetKind() == "CustomResourceDefinition" {
+			return unstructured.SetNestedSlice(o.Object, []interface{}{
+				map[string]interface{}{"type": "Established", "status": "True"},
+			}, "status", "conditions")
+		}
+	}
+	return nil
+}
+
+func newTestStageObject(apiVersion, kind, namespace, name string) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name": name,
+		},
+	}}
+	if namespace != "" {
+		u.SetNamespace(namespace)
+	}
+	return u
+}
+
+func newTestImportStages() []importStage {
+	crds := []*unstructured.Unstructured{
+		newTestStageObject("apiextensions.k8s.io/v1", "CustomResourceDefinition", "", "klusterlets.operator.open-cluster-management.io"),
+	}
+	yamls := []*unstructured.Unstructured{
+		newTestStageObject("v1", "Namespace", "", klusterletNamespace),
+		newTestStageObject("v1", "ServiceAccount", klusterletNamespace, "klusterlet"),
+		newTestStageObject("apps/v1", "Deployment", klusterletNamespace, "klusterlet"),
+		newTestStageObject("operator.open-cluster-management.io/v1", "Klusterlet", "", "klusterlet"),
+	}
+	return newImportStages(crds, yamls, &importStageTimeouts{
+		crdEstablished:  50 * time.Millisecond,
+		operatorRollout: 50 * time.Millisecond,
+	})
+}
+
+func Test_getImportStageTimeouts(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    importStageTimeouts
+		wantErr bool
+	}{
+		{
+			name: "default",
+			want: importStageTimeouts{crdEstablished: time.Minute, operatorRollout: 3 * time.Minute},
+		},
+		{
+			name: "overridden",
+			env: map[string]string{
+				crdEstablishedTimeoutEnvVarName:  "30s",
+				operatorRolloutTimeoutEnvVarName: "10m",
+			},
+			want: importStageTimeouts{crdEstablished: 30 * time.Second, operatorRollout: 10 * time.Minute},
+		},
+		{
+			name:    "invalid",
+			env:     map[string]string{operatorRolloutTimeoutEnvVarName: "0s"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				os.Setenv(name, value)
+				defer os.Unsetenv(name)
+			}
+			got, err := getImportStageTimeouts()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getImportStageTimeouts() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && *got != tt.want {
+				t.Errorf("getImportStageTimeouts() = %v, want %v", *got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newImportStages(t *testing.T) {
+	want := map[string][]string{
+		importStageNamespaceAndRBAC: {"Namespace", "ServiceAccount"},
+		importStageCRDs:             {"CustomResourceDefinition"},
+		importStageOperator:         {"Deployment"},
+		importStageKlusterlet:       {"Klusterlet"},
+	}
+	stages := newTestImportStages()
+	names := []string{importStageNamespaceAndRBAC, importStageCRDs, importStageOperator, importStageKlusterlet}
+	for i, stage := range stages {
+		if stage.name != names[i] {
+			t.Errorf("expected the stage %s at %d, got %s", names[i], i, stage.name)
+		}
+		kinds := []string{}
+		for _, u := range stage.objects {
+			kinds = append(kinds, u.GetKind())
+		}
+		if fmt.Sprint(kinds) != fmt.Sprint(want[stage.name]) {
+			t.Errorf("expected the kinds %v in the stage %s, got %v", want[stage.name], stage.name, kinds)
+		}
+	}
+}
+
+func Test_applyImportStages(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name           string
+		client         func(client.Client) client.Client
+		progress       *importStageProgress
+		wantProgress   *importStageProgress
+		wantReason     string
+		wantKlusterlet bool
+	}{
+		{
+			name:           "healthy",
+			client:         func(c client.Client) client.Client { return healthyManagedClusterClient{c} },
+			progress:       &importStageProgress{stage: importStageCRDs, since: now.Add(-time.Second)},
+			wantKlusterlet: true,
+		},
+		{
+			name:         "crd not established",
+			client:       func(c client.Client) client.Client { return c },
+			wantProgress: &importStageProgress{stage: importStageCRDs, since: now},
+		},
+		{
+			name:         "crd still not established",
+			client:       func(c client.Client) client.Client { return c },
+			progress:     &importStageProgress{stage: importStageCRDs, since: now.Add(-10 * time.Millisecond)},
+			wantProgress: &importStageProgress{stage: importStageCRDs, since: now.Add(-10 * time.Millisecond)},
+		},
+		{
+			name:         "crd not established after another stage",
+			client:       func(c client.Client) client.Client { return c },
+			progress:     &importStageProgress{stage: importStageOperator, since: now.Add(-time.Hour)},
+			wantProgress: &importStageProgress{stage: importStageCRDs, since: now},
+		},
+		{
+			name:       "crd not established after the timeout",
+			client:     func(c client.Client) client.Client { return c },
+			progress:   &importStageProgress{stage: importStageCRDs, since: now.Add(-time.Second)},
+			wantReason: "CRDNotEstablished",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedClusterClient := tt.client(fake.NewFakeClientWithScheme(scheme.Scheme))
+			progress, err := applyImportStages(managedClusterClient, newTestImportStages(), nil, tt.progress, now)
+			if reason := getImportStageFailureReason(err); reason != tt.wantReason {
+				t.Errorf("applyImportStages() error = %v, want the reason %q", err, tt.wantReason)
+			}
+			if !reflect.DeepEqual(progress, tt.wantProgress) {
+				t.Errorf("applyImportStages() progress = %v, want %v", progress, tt.wantProgress)
+			}
+			klusterlet := newTestStageObject("operator.open-cluster-management.io/v1", "Klusterlet", "", "")
+			err = managedClusterClient.Get(context.TODO(), types.NamespacedName{Name: "klusterlet"}, klusterlet)
+			if (err == nil) != tt.wantKlusterlet {
+				t.Errorf("expected the klusterlet to be applied %v, got %v", tt.wantKlusterlet, err)
+			}
+		})
+	}
+}
+
+func Test_importStageProgress(t *testing.T) {
+	managedCluster := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "cluster1"}}
+	c := fake.NewFakeClientWithScheme(newClusterClaimTestScheme(), managedCluster)
+	if progress := getImportStageProgress(managedCluster); progress != nil {
+		t.Errorf("expected no progress, got %v", progress)
+	}
+
+	want := &importStageProgress{stage: importStageOperator, since: time.Now().UTC().Truncate(time.Second)}
+	if err := setImportStageProgress(c, managedCluster, want); err != nil {
+		t.Fatalf("setImportStageProgress() unexpected error %v", err)
+	}
+	latest := &clusterv1.ManagedCluster{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, latest); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if got := getImportStageProgress(latest); got == nil || got.stage != want.stage || !got.since.Equal(want.since) {
+		t.Errorf("getImportStageProgress() = %v, want %v", got, want)
+	}
+
+	if err := setImportStageProgress(c, latest, nil); err != nil {
+		t.Fatalf("setImportStageProgress() unexpected error %v", err)
+	}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, latest); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if _, ok := latest.Annotations[importStageAnnotation]; ok {
+		t.Errorf("expected the annotation %s to be removed, got %v", importStageAnnotation, latest.Annotations)
+	}
+
+	latest.Annotations = map[string]string{importStageAnnotation: "invalid"}
+	if progress := getImportStageProgress(latest); progress != nil {
+		t.Errorf("expected no progress for an invalid annotation, got %v", progress)
+	}
+}
+
+func Test_deploymentsRolledOut(t *testing.T) {
+	replicas := int32(2)
+	deployment := &appsv1.Deployment{}
+	deployment.Name = "klusterlet"
+	deployment.Namespace = klusterletNamespace
+	deployment.Generation = 2
+	deployment.Spec.Replicas = &replicas
+
+	tests := []struct {
+		name   string
+		status appsv1.DeploymentStatus
+		want   bool
+	}{
+		{
+			name:   "not observed",
+			status: appsv1.DeploymentStatus{ObservedGeneration: 1, UpdatedReplicas: 2, AvailableReplicas: 2},
+		},
+		{
+			name:   "not available",
+			status: appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2, AvailableReplicas: 1},
+		},
+		{
+			name:   "rolled out",
+			status: appsv1.DeploymentStatus{ObservedGeneration: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := deployment.DeepCopy()
+			d.Status = tt.status
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, d)
+			got, err := deploymentsRolledOut(c, []*unstructured.Unstructured{
+				newTestStageObject("apps/v1", "Deployment", klusterletNamespace, "klusterlet"),
+			})
+			if got != tt.want || (err == nil) != tt.want {
+				t.Errorf("deploymentsRolledOut() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
@@ -9,18 +9,23 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"reflect"
 	"testing"
 
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
 	ocinfrav1 "github.com/openshift/api/config/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 	clientcmdlatest "k8s.io/client-go/tools/clientcmd/api/latest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	configv1alpha1 "github.com/open-cluster-management/managedcluster-import-controller/pkg/apis/config/v1alpha1"
 )
 
 func Test_createKubeconfigData(t *testing.T) {
@@ -93,6 +98,15 @@ func Test_createKubeconfigData(t *testing.T) {
 		Data: map[string][]byte{},
 		Type: corev1.SecretTypeTLS,
 	}
+	hubCASecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hub-ca",
+			Namespace: "test-namespace",
+		},
+		Data: map[string][]byte{
+			"ca.crt": []byte("hub-cert-data"),
+		},
+	}
 	serverStopped := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		fmt.Fprintln(w, "Hello, client")
 	}))
@@ -109,14 +123,17 @@ func Test_createKubeconfigData(t *testing.T) {
 	s.AddKnownTypes(ocinfrav1.SchemeGroupVersion, &ocinfrav1.Infrastructure{}, &ocinfrav1.APIServer{})
 
 	type args struct {
-		client client.Client
-		secret *corev1.Secret
+		client           client.Client
+		managedCluster   *clusterv1.ManagedCluster
+		secret           *corev1.Secret
+		klusterletConfig *configv1alpha1.KlusterletConfig
 	}
 	type wantData struct {
 		serverURL   string
 		useInsecure bool
 		certData    []byte
 		token       string
+		proxyURL    string
 	}
 	tests := []struct {
 		name    string
@@ -138,6 +155,79 @@ func Test_createKubeconfigData(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "use hub kube apiserver override",
+			args: args{
+				client: fake.NewFakeClientWithScheme(s, hubCASecret),
+				managedCluster: &clusterv1.ManagedCluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "test-namespace",
+						Annotations: map[string]string{
+							hubKubeAPIServerURLAnnotation:      "https://lb.hub.example.com:6443",
+							hubKubeAPIServerCASecretAnnotation: "hub-ca",
+						},
+					},
+				},
+				secret: testTokenSecret,
+			},
+			want: wantData{
+				serverURL:   "https://lb.hub.example.com:6443",
+				useInsecure: false,
+				certData:    []byte("hub-cert-data"),
+				token:       "fake-token",
+			},
+			wantErr: false,
+		},
+		{
+			name: "use proxy",
+			args: args{
+				client: fake.NewFakeClientWithScheme(s, testInfraConfigDNS),
+				secret: testTokenSecret,
+				klusterletConfig: &configv1alpha1.KlusterletConfig{
+					Spec: configv1alpha1.KlusterletConfigSpec{
+						ProxyConfig: &configv1alpha1.ProxyConfig{
+							HTTPSProxy: "https://proxy.example.com:3128",
+							CABundle:   []byte("proxy-cert-data"),
+						},
+					},
+				},
+			},
+			want: wantData{
+				serverURL:   "https://my-dns-name.com:6443",
+				useInsecure: false,
+				certData:    []byte("default-cert-data\nproxy-cert-data"),
+				token:       "fake-token",
+				proxyURL:    "https://proxy.example.com:3128",
+			},
+			wantErr: false,
+		},
+		{
+			name: "use proxy without hub CA",
+			args: args{
+				client: fake.NewFakeClientWithScheme(s, testInfraConfigDNS),
+				secret: &corev1.Secret{
+					ObjectMeta: testTokenSecret.ObjectMeta,
+					Data:       map[string][]byte{"token": []byte("fake-token")},
+					Type:       corev1.SecretTypeServiceAccountToken,
+				},
+				klusterletConfig: &configv1alpha1.KlusterletConfig{
+					Spec: configv1alpha1.KlusterletConfigSpec{
+						ProxyConfig: &configv1alpha1.ProxyConfig{
+							HTTPSProxy: "https://proxy.example.com:3128",
+							CABundle:   []byte("proxy-cert-data"),
+						},
+					},
+				},
+			},
+			want: wantData{
+				serverURL:   "https://my-dns-name.com:6443",
+				useInsecure: false,
+				certData:    nil,
+				token:       "fake-token",
+				proxyURL:    "https://proxy.example.com:3128",
+			},
+			wantErr: false,
+		},
 		{
 			name: "use named certificate",
 			args: args{
@@ -220,7 +310,11 @@ func Test_createKubeconfigData(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Logf("Test name: %s", tt.name)
-			kubeconfigData, err := createKubeconfigData(tt.args.client, tt.args.secret)
+			managedCluster := tt.args.managedCluster
+			if managedCluster == nil {
+				managedCluster = &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "test-namespace"}}
+			}
+			kubeconfigData, err := createKubeconfigData(tt.args.client, managedCluster, tt.args.secret, tt.args.klusterletConfig)
 
 			if (err != nil) != tt.wantErr {
 				t.Errorf("createKubeconfigData() error = %v, wantErr %v", err, tt.wantErr)
@@ -268,6 +362,14 @@ func Test_createKubeconfigData(t *testing.T) {
 				)
 			}
 
+			if clusterConfig.ProxyURL != tt.want.proxyURL {
+				t.Errorf(
+					"createKubeconfigData() returns wrong proxy url. want %v, got %v",
+					tt.want.proxyURL,
+					clusterConfig.ProxyURL,
+				)
+			}
+
 			if authInfo.Token != tt.want.token {
 				t.Errorf(
 					"createKubeconfigData() returns wrong token. want %v, got %v",
@@ -350,3 +452,69 @@ func Test_getValidCertificatesFromURL(t *testing.T) {
 		})
 	}
 }
+
+func Test_getImagePullSecret(t *testing.T) {
+	podNamespace, pullSecret := os.Getenv("POD_NAMESPACE"), os.Getenv("DEFAULT_IMAGE_PULL_SECRET")
+	os.Setenv("POD_NAMESPACE", "open-cluster-management")
+	os.Setenv("DEFAULT_IMAGE_PULL_SECRET", "default-pull-secret")
+	defer func() {
+		os.Setenv("POD_NAMESPACE", podNamespace)
+		os.Setenv("DEFAULT_IMAGE_PULL_SECRET", pullSecret)
+	}()
+	newPullSecret := func(namespace, name string) *corev1.Secret {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace}}
+	}
+	newConfig := func(namespace, name string) *configv1alpha1.KlusterletConfig {
+		return &configv1alpha1.KlusterletConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "config", Namespace: "tenant"},
+			Spec: configv1alpha1.KlusterletConfigSpec{
+				PullSecret: corev1.ObjectReference{Name: name, Namespace: namespace},
+			},
+		}
+	}
+	objects := []runtime.Object{
+		newPullSecret("open-cluster-management", "default-pull-secret"),
+		newPullSecret("open-cluster-management", "pull-secret"),
+		newPullSecret("tenant", "pull-secret"),
+		newPullSecret("other-tenant", "pull-secret"),
+	}
+	tests := []struct {
+		name             string
+		klusterletConfig *configv1alpha1.KlusterletConfig
+		want             types.NamespacedName
+		wantErr          bool
+	}{
+		{
+			name: "default",
+			want: types.NamespacedName{Namespace: "open-cluster-management", Name: "default-pull-secret"},
+		},
+		{
+			name:             "namespace of the klusterlet config",
+			klusterletConfig: newConfig("", "pull-secret"),
+			want:             types.NamespacedName{Namespace: "tenant", Name: "pull-secret"},
+		},
+		{
+			name:             "namespace of the controller",
+			klusterletConfig: newConfig("open-cluster-management", "pull-secret"),
+			want:             types.NamespacedName{Namespace: "open-cluster-management", Name: "pull-secret"},
+		},
+		{
+			name:             "another namespace",
+			klusterletConfig: newConfig("other-tenant", "pull-secret"),
+			wantErr:          true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, objects...)
+			got, err := getImagePullSecret(c, tt.klusterletConfig)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getImagePullSecret() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && (got.Namespace != tt.want.Namespace || got.Name != tt.want.Name) {
+				t.Errorf("getImagePullSecret() = %s/%s, want %s", got.Namespace, got.Name, tt.want)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@
 package managedcluster
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
@@ -20,6 +21,7 @@ import (
 
 	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
 	"github.com/open-cluster-management/applier/pkg/templateprocessor"
+	configv1alpha1 "github.com/open-cluster-management/managedcluster-import-controller/pkg/apis/config/v1alpha1"
 	"github.com/open-cluster-management/managedcluster-import-controller/pkg/bindata"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -68,15 +70,20 @@ func generateImportYAMLs(
 		return nil, nil, err
 	}
 
+	klusterletConfig, err := getKlusterletConfig(client, managedCluster)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	klog.V(4).Infof("createKubeconfigData for bootsrapSecret %s", bootStrapSecret.Name)
-	bootstrapKubeconfigData, err := createKubeconfigData(client, bootStrapSecret)
+	bootstrapKubeconfigData, err := createKubeconfigData(client, managedCluster, bootStrapSecret, klusterletConfig)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	useImagePullSecret := false
 	imagePullSecretDataBase64 := ""
-	imagePullSecret, err := getImagePullSecret(client)
+	imagePullSecret, err := getImagePullSecret(client, klusterletConfig)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -100,6 +107,25 @@ func generateImportYAMLs(
 		return nil, nil, fmt.Errorf(envVarNotDefined, workImageEnvVarName)
 	}
 
+	var nodeSelector map[string]string
+	var tolerations []corev1.Toleration
+	proxyConfig := configv1alpha1.ProxyConfig{}
+	if klusterletConfig != nil {
+		registrationOperatorImageName = overrideImage(klusterletConfig,
+			registrationOperatorImageName, klusterletConfig.Spec.RegistrationOperatorImagePullSpec)
+		registrationImageName = overrideImage(klusterletConfig,
+			registrationImageName, klusterletConfig.Spec.RegistrationImagePullSpec)
+		workImageName = overrideImage(klusterletConfig,
+			workImageName, klusterletConfig.Spec.WorkImagePullSpec)
+		if klusterletConfig.Spec.NodePlacement != nil {
+			nodeSelector = klusterletConfig.Spec.NodePlacement.NodeSelector
+			tolerations = klusterletConfig.Spec.NodePlacement.Tolerations
+		}
+		if klusterletConfig.Spec.ProxyConfig != nil {
+			proxyConfig = *klusterletConfig.Spec.ProxyConfig
+		}
+	}
+
 	config := struct {
 		KlusterletNamespace       string
 		ManagedClusterNamespace   string
@@ -111,9 +137,14 @@ func generateImportYAMLs(
 		RegistrationOperatorImage string
 		RegistrationImageName     string
 		WorkImageName             string
+		NodeSelector              map[string]string
+		Tolerations               []corev1.Toleration
+		HTTPProxy                 string
+		HTTPSProxy                string
+		NoProxy                   string
 	}{
 		ManagedClusterNamespace:   managedCluster.Name,
-		KlusterletNamespace:       klusterletNamespace,
+		KlusterletNamespace:       getKlusterletNamespace(klusterletConfig),
 		BootstrapKubeconfig:       base64.StdEncoding.EncodeToString(bootstrapKubeconfigData),
 		UseImagePullSecret:        useImagePullSecret,
 		ImagePullSecretName:       managedClusterImagePullSecretName,
@@ -122,6 +153,11 @@ func generateImportYAMLs(
 		RegistrationOperatorImage: registrationOperatorImageName,
 		RegistrationImageName:     registrationImageName,
 		WorkImageName:             workImageName,
+		NodeSelector:              nodeSelector,
+		Tolerations:               tolerations,
+		HTTPProxy:                 proxyConfig.HTTPProxy,
+		HTTPSProxy:                proxyConfig.HTTPSProxy,
+		NoProxy:                   proxyConfig.NoProxy,
 	}
 
 	tp, err = templateprocessor.NewTemplateProcessor(bindata.NewBindataReader(), &templateprocessor.Options{})
@@ -147,15 +183,34 @@ func generateImportYAMLs(
 	return crds, yamls, nil
 }
 
-func getImagePullSecret(client client.Client) (*corev1.Secret, error) {
-	if os.Getenv("DEFAULT_IMAGE_PULL_SECRET") == "" {
+// getImagePullSecret returns the pull secret defined in the KlusterletConfig if any,
+// otherwise the DEFAULT_IMAGE_PULL_SECRET of the controller namespace.
+// The pull secret of a KlusterletConfig is only read from the namespace of the KlusterletConfig or
+// of the controller, the other secrets of the hub are not copied to the managed cluster.
+func getImagePullSecret(
+	client client.Client,
+	klusterletConfig *configv1alpha1.KlusterletConfig,
+) (*corev1.Secret, error) {
+	secretNsN := types.NamespacedName{
+		Name:      os.Getenv("DEFAULT_IMAGE_PULL_SECRET"),
+		Namespace: os.Getenv("POD_NAMESPACE"),
+	}
+	if klusterletConfig != nil && klusterletConfig.Spec.PullSecret.Name != "" {
+		secretNsN.Name = klusterletConfig.Spec.PullSecret.Name
+		secretNsN.Namespace = klusterletConfig.Spec.PullSecret.Namespace
+		if secretNsN.Namespace == "" {
+			secretNsN.Namespace = klusterletConfig.Namespace
+		}
+		if secretNsN.Namespace != klusterletConfig.Namespace && secretNsN.Namespace != os.Getenv("POD_NAMESPACE") {
+			return nil, fmt.Errorf("the pull secret %s of the KlusterletConfig %s/%s is not in the namespace of the KlusterletConfig",
+				secretNsN, klusterletConfig.Namespace, klusterletConfig.Name)
+		}
+	}
+	if secretNsN.Name == "" {
 		return nil, nil
 	}
 	secret := &corev1.Secret{}
-	err := client.Get(context.TODO(), types.NamespacedName{
-		Name:      os.Getenv("DEFAULT_IMAGE_PULL_SECRET"),
-		Namespace: os.Getenv("POD_NAMESPACE"),
-	}, secret)
+	err := client.Get(context.TODO(), secretNsN, secret)
 	if err != nil {
 		return nil, err
 	}
@@ -208,52 +263,48 @@ func getValidCertificatesFromURL(serverURL string, rootCAs *x509.CertPool) ([]*x
 	return retCerts, nil
 }
 
-func createKubeconfigData(client client.Client, bootStrapSecret *corev1.Secret) ([]byte, error) {
+func createKubeconfigData(
+	client client.Client,
+	managedCluster *clusterv1.ManagedCluster,
+	bootStrapSecret *corev1.Secret,
+	klusterletConfig *configv1alpha1.KlusterletConfig,
+) ([]byte, error) {
 	saToken := bootStrapSecret.Data["token"]
 
-	kubeAPIServer, err := getKubeAPIServerAddress(client)
+	// the URL and CA configured for the managed cluster take precedence over the hub info
+	kubeAPIServer, certData, err := getKubeAPIServerOverride(client, managedCluster)
 	if err != nil {
 		return nil, err
 	}
-
-	var certData []byte
-	if u, err := url.Parse(kubeAPIServer); err == nil {
-		apiServerCertSecretName, err := getKubeAPIServerSecretName(client, u.Hostname())
+	hubInfo := getHubInfoProvider()
+	if kubeAPIServer == "" {
+		kubeAPIServer, err = hubInfo.KubeAPIServerURL(client)
 		if err != nil {
 			return nil, err
 		}
-		if len(apiServerCertSecretName) > 0 {
-			apiServerCert, err := getKubeAPIServerCertificate(client, apiServerCertSecretName)
-			if err != nil {
-				return nil, err
-			}
-			certData = apiServerCert
-		}
 	}
 	if len(certData) == 0 {
-		// fallback to service account token ca.crt
-		if _, ok := bootStrapSecret.Data["ca.crt"]; ok {
-			certData = bootStrapSecret.Data["ca.crt"]
-		}
-		// check if it's roks
-		// if it's ocp && it's on ibm cloud, we treat it as roks
-		isROKS, err := checkIsIBMCloud(client)
+		certData, err = hubInfo.KubeAPIServerCA(client, kubeAPIServer, bootStrapSecret.Data["ca.crt"])
 		if err != nil {
 			return nil, err
 		}
-		if isROKS {
-			// ROKS should have a certificate that is signed by trusted CA
-			if certs, err := getValidCertificatesFromURL(kubeAPIServer, nil); err != nil {
-				// should retry if failed to connect to apiserver
-				log.Error(err, fmt.Sprintf("failed to connect to %s", kubeAPIServer))
-				return nil, err
-			} else if len(certs) > 0 {
-				// simply don't give any certs as the apiserver is using certs signed by known CAs
-				certData = nil
-			} else {
-				log.Info("No additional valid certificate found for APIserver. Skipping.")
-			}
+	}
+
+	proxyURL, err := getProxyURL(klusterletConfig, kubeAPIServer)
+	if err != nil {
+		return nil, err
+	}
+	if proxyCABundle := getProxyCABundle(klusterletConfig); len(proxyCABundle) > 0 && len(certData) == 0 {
+		// without a kube apiserver CA the system roots are trusted, a CA bundle would replace them
+		klog.Infof("The kube apiserver %s has no CA, the proxy CA bundle is not used", kubeAPIServer)
+	} else if len(proxyCABundle) > 0 {
+		// the proxy CA bundle is trusted in addition to the kube apiserver CA,
+		// the bundles are separated by a new line to keep the PEM blocks valid
+		bundle := append([]byte{}, certData...)
+		if len(bundle) > 0 && !bytes.HasSuffix(bundle, []byte("\n")) {
+			bundle = append(bundle, '\n')
 		}
+		certData = append(bundle, proxyCABundle...)
 	}
 
 	bootstrapConfig := clientcmdapi.Config{
@@ -262,6 +313,7 @@ func createKubeconfigData(client client.Client, bootStrapSecret *corev1.Secret)
 			Server:                   kubeAPIServer,
 			InsecureSkipTLSVerify:    false,
 			CertificateAuthorityData: certData,
+			ProxyURL:                 proxyURL,
 		}},
 		// Define auth based on the obtained client cert.
 		AuthInfos: map[string]*clientcmdapi.AuthInfo{"default-auth": {
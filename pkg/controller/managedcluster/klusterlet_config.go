@@ -0,0 +1,154 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	"golang.org/x/net/http/httpproxy"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configv1alpha1 "github.com/open-cluster-management/managedcluster-import-controller/pkg/apis/config/v1alpha1"
+)
+
+// klusterletConfigAnnotation references the KlusterletConfig used to render the klusterlet manifests of a
+// ManagedCluster. The value is either "<name>", for a KlusterletConfig in the cluster namespace,
+// or "<namespace>/<name>".
+const klusterletConfigAnnotation = "agent.open-cluster-management.io/klusterlet-config"
+
+const klusterletNamespacePrefix = "open-cluster-management-"
+
+// klusterletConfigNsN returns the namespace/name of the KlusterletConfig referenced by the managedCluster,
+// the returned bool is false if the managedCluster doesn't reference any KlusterletConfig
+func klusterletConfigNsN(managedCluster *clusterv1.ManagedCluster) (types.NamespacedName, bool, error) {
+	ref := managedCluster.GetAnnotations()[klusterletConfigAnnotation]
+	if ref != "" && !strings.Contains(ref, "/") {
+		return types.NamespacedName{Namespace: managedCluster.Name, Name: ref}, true, nil
+	}
+	return namespacedNameAnnotation(managedCluster, klusterletConfigAnnotation)
+}
+
+// getKlusterletConfig returns the KlusterletConfig referenced by the managedCluster,
+// nil is returned if the managedCluster doesn't reference any KlusterletConfig.
+func getKlusterletConfig(
+	c client.Client,
+	managedCluster *clusterv1.ManagedCluster,
+) (*configv1alpha1.KlusterletConfig, error) {
+	nsN, ok, err := klusterletConfigNsN(managedCluster)
+	if err != nil || !ok {
+		return nil, err
+	}
+	klusterletConfig := &configv1alpha1.KlusterletConfig{}
+	if err := c.Get(context.TODO(), nsN, klusterletConfig); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("the KlusterletConfig %s is referenced by %s but the KlusterletConfig CRD is not installed",
+				nsN, managedCluster.Name)
+		}
+		if errors.IsNotFound(err) {
+			return nil, fmt.Errorf("the KlusterletConfig %s referenced by %s is not found", nsN, managedCluster.Name)
+		}
+		return nil, err
+	}
+	if ns := klusterletConfig.Spec.KlusterletNamespace; ns != "" && !strings.HasPrefix(ns, klusterletNamespacePrefix) {
+		return nil, fmt.Errorf("the klusterletNamespace %s of the KlusterletConfig %s must have the prefix %s",
+			ns, nsN, klusterletNamespacePrefix)
+	}
+	return klusterletConfig, nil
+}
+
+// getKlusterletNamespace returns the namespace in which the klusterlet is deployed on the managed cluster
+func getKlusterletNamespace(klusterletConfig *configv1alpha1.KlusterletConfig) string {
+	if klusterletConfig == nil || klusterletConfig.Spec.KlusterletNamespace == "" {
+		return klusterletNamespace
+	}
+	return klusterletConfig.Spec.KlusterletNamespace
+}
+
+// overrideImage replaces the image by the image defined in the KlusterletConfig if any,
+// then replaces the registry of the image by its mirror.
+func overrideImage(klusterletConfig *configv1alpha1.KlusterletConfig, image, override string) string {
+	if klusterletConfig == nil {
+		return image
+	}
+	if override != "" {
+		image = override
+	}
+	for _, registry := range klusterletConfig.Spec.Registries {
+		if registry.Mirror == "" {
+			continue
+		}
+		mirror := strings.TrimSuffix(registry.Mirror, "/")
+		if registry.Source == "" {
+			// replace the registry, the image name and tag are the part after the last "/"
+			return mirror + "/" + image[strings.LastIndex(image, "/")+1:]
+		}
+		// the source matches whole path segments of the image
+		source := strings.TrimSuffix(registry.Source, "/")
+		if strings.HasPrefix(image, source+"/") {
+			return mirror + strings.TrimPrefix(image, source)
+		}
+	}
+	return image
+}
+
+// getProxyURL returns the URL of the proxy used by the klusterlet to reach the serverURL,
+// an empty string is returned if no proxy is required
+func getProxyURL(klusterletConfig *configv1alpha1.KlusterletConfig, serverURL string) (string, error) {
+	if klusterletConfig == nil || klusterletConfig.Spec.ProxyConfig == nil {
+		return "", nil
+	}
+	u, err := url.Parse(serverURL)
+	if err != nil {
+		return "", err
+	}
+	proxyConfig := &httpproxy.Config{
+		HTTPProxy:  klusterletConfig.Spec.ProxyConfig.HTTPProxy,
+		HTTPSProxy: klusterletConfig.Spec.ProxyConfig.HTTPSProxy,
+		NoProxy:    klusterletConfig.Spec.ProxyConfig.NoProxy,
+	}
+	proxyURL, err := proxyConfig.ProxyFunc()(u)
+	if err != nil || proxyURL == nil {
+		return "", err
+	}
+	return proxyURL.String(), nil
+}
+
+// getProxyCABundle returns the CA bundle of the proxy defined in the KlusterletConfig if any
+func getProxyCABundle(klusterletConfig *configv1alpha1.KlusterletConfig) []byte {
+	if klusterletConfig == nil || klusterletConfig.Spec.ProxyConfig == nil {
+		return nil
+	}
+	return klusterletConfig.Spec.ProxyConfig.CABundle
+}
+
+// newKlusterletConfigMapper returns a mapper which enqueues the ManagedClusters referencing a KlusterletConfig
+func newKlusterletConfigMapper(c client.Client) handler.ToRequestsFunc {
+	return handler.ToRequestsFunc(func(obj handler.MapObject) []reconcile.Request {
+		managedClusters := &clusterv1.ManagedClusterList{}
+		if err := c.List(context.TODO(), managedClusters); err != nil {
+			log.Error(err, "Failed to list managedclusters", "KlusterletConfig", obj.Meta.GetName())
+			return nil
+		}
+		requests := []reconcile.Request{}
+		for i := range managedClusters.Items {
+			nsN, ok, err := klusterletConfigNsN(&managedClusters.Items[i])
+			if err != nil || !ok || nsN.Name != obj.Meta.GetName() || nsN.Namespace != obj.Meta.GetNamespace() {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: managedClusters.Items[i].Name},
+			})
+		}
+		return requests
+	})
+}
@@ -0,0 +1,335 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"reflect"
+	"testing"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	configv1alpha1 "github.com/open-cluster-management/managedcluster-import-controller/pkg/apis/config/v1alpha1"
+)
+
+func Test_klusterletConfigNsN(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        types.NamespacedName
+		wantOK      bool
+		wantErr     bool
+	}{
+		{
+			name:   "no annotation",
+			wantOK: false,
+		},
+		{
+			name:        "name only",
+			annotations: map[string]string{klusterletConfigAnnotation: "config"},
+			want:        types.NamespacedName{Name: "config", Namespace: "cluster1"},
+			wantOK:      true,
+		},
+		{
+			name:        "namespace and name",
+			annotations: map[string]string{klusterletConfigAnnotation: "configs/config"},
+			want:        types.NamespacedName{Name: "config", Namespace: "configs"},
+			wantOK:      true,
+		},
+		{
+			name:        "no name",
+			annotations: map[string]string{klusterletConfigAnnotation: "configs/"},
+			wantErr:     true,
+		},
+		{
+			name:        "no namespace",
+			annotations: map[string]string{klusterletConfigAnnotation: "/config"},
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedCluster := &clusterv1.ManagedCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "cluster1",
+					Annotations: tt.annotations,
+				},
+			}
+			got, ok, err := klusterletConfigNsN(managedCluster)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("klusterletConfigNsN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if ok != tt.wantOK {
+				t.Errorf("klusterletConfigNsN() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if got != tt.want {
+				t.Errorf("klusterletConfigNsN() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getKlusterletConfig(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(configv1alpha1.SchemeGroupVersion, &configv1alpha1.KlusterletConfig{})
+
+	klusterletConfig := &configv1alpha1.KlusterletConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "config",
+			Namespace: "cluster1",
+		},
+		Spec: configv1alpha1.KlusterletConfigSpec{
+			KlusterletNamespace: "open-cluster-management-agent-edge",
+		},
+	}
+	wrongNamespaceConfig := &configv1alpha1.KlusterletConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "wrong",
+			Namespace: "cluster1",
+		},
+		Spec: configv1alpha1.KlusterletConfigSpec{
+			KlusterletNamespace: "agent",
+		},
+	}
+
+	tests := []struct {
+		name          string
+		reference     string
+		want          *configv1alpha1.KlusterletConfig
+		wantNamespace string
+		wantErr       bool
+	}{
+		{
+			name:          "no reference",
+			want:          nil,
+			wantNamespace: klusterletNamespace,
+		},
+		{
+			name:          "reference found",
+			reference:     "config",
+			want:          klusterletConfig,
+			wantNamespace: "open-cluster-management-agent-edge",
+		},
+		{
+			name:      "reference not found",
+			reference: "missing",
+			wantErr:   true,
+		},
+		{
+			name:      "wrong klusterlet namespace",
+			reference: "wrong",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedCluster := &clusterv1.ManagedCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster1",
+				},
+			}
+			if tt.reference != "" {
+				managedCluster.Annotations = map[string]string{klusterletConfigAnnotation: tt.reference}
+			}
+			c := fake.NewFakeClientWithScheme(testscheme, klusterletConfig, wrongNamespaceConfig)
+			got, err := getKlusterletConfig(c, managedCluster)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getKlusterletConfig() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if (got == nil) != (tt.want == nil) ||
+				(got != nil && !reflect.DeepEqual(got.Spec, tt.want.Spec)) {
+				t.Errorf("getKlusterletConfig() = %v, want %v", got, tt.want)
+			}
+			if ns := getKlusterletNamespace(got); ns != tt.wantNamespace {
+				t.Errorf("getKlusterletNamespace() = %v, want %v", ns, tt.wantNamespace)
+			}
+		})
+	}
+}
+
+func Test_overrideImage(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     *configv1alpha1.KlusterletConfigSpec
+		image    string
+		override string
+		want     string
+	}{
+		{
+			name:  "no klusterletconfig",
+			image: "quay.io/open-cluster-management/registration:latest",
+			want:  "quay.io/open-cluster-management/registration:latest",
+		},
+		{
+			name:     "override image",
+			spec:     &configv1alpha1.KlusterletConfigSpec{},
+			image:    "quay.io/open-cluster-management/registration:latest",
+			override: "quay.io/open-cluster-management/registration:2.3.0",
+			want:     "quay.io/open-cluster-management/registration:2.3.0",
+		},
+		{
+			name: "mirror with source",
+			spec: &configv1alpha1.KlusterletConfigSpec{
+				Registries: []configv1alpha1.Registries{
+					{Source: "quay.io/open-cluster-management", Mirror: "registry.local:5000/ocm"},
+				},
+			},
+			image: "quay.io/open-cluster-management/registration:latest",
+			want:  "registry.local:5000/ocm/registration:latest",
+		},
+		{
+			name: "mirror without source",
+			spec: &configv1alpha1.KlusterletConfigSpec{
+				Registries: []configv1alpha1.Registries{
+					{Mirror: "registry.local:5000/ocm/"},
+				},
+			},
+			image: "quay.io/open-cluster-management/work:latest",
+			want:  "registry.local:5000/ocm/work:latest",
+		},
+		{
+			name: "mirror without source and image without registry",
+			spec: &configv1alpha1.KlusterletConfigSpec{
+				Registries: []configv1alpha1.Registries{
+					{Mirror: "registry.local:5000/ocm"},
+				},
+			},
+			image: "registration:latest",
+			want:  "registry.local:5000/ocm/registration:latest",
+		},
+		{
+			name: "source matching a partial path segment",
+			spec: &configv1alpha1.KlusterletConfigSpec{
+				Registries: []configv1alpha1.Registries{
+					{Source: "quay.io/open", Mirror: "registry.local:5000/ocm"},
+				},
+			},
+			image: "quay.io/open-cluster-management/registration:latest",
+			want:  "quay.io/open-cluster-management/registration:latest",
+		},
+		{
+			name: "mirror not matching",
+			spec: &configv1alpha1.KlusterletConfigSpec{
+				Registries: []configv1alpha1.Registries{
+					{Source: "docker.io/ocm", Mirror: "registry.local:5000/ocm"},
+				},
+			},
+			image: "quay.io/open-cluster-management/work:latest",
+			want:  "quay.io/open-cluster-management/work:latest",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var klusterletConfig *configv1alpha1.KlusterletConfig
+			if tt.spec != nil {
+				klusterletConfig = &configv1alpha1.KlusterletConfig{Spec: *tt.spec}
+			}
+			if got := overrideImage(klusterletConfig, tt.image, tt.override); got != tt.want {
+				t.Errorf("overrideImage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getProxyURL(t *testing.T) {
+	proxyConfig := &configv1alpha1.ProxyConfig{
+		HTTPProxy:  "http://proxy.example.com:3128",
+		HTTPSProxy: "https://proxy.example.com:3129",
+		NoProxy:    "localhost,.internal.example.com",
+	}
+	tests := []struct {
+		name        string
+		proxyConfig *configv1alpha1.ProxyConfig
+		serverURL   string
+		want        string
+	}{
+		{
+			name:      "no proxy config",
+			serverURL: "https://api.hub.example.com:6443",
+			want:      "",
+		},
+		{
+			name:        "https proxy",
+			proxyConfig: proxyConfig,
+			serverURL:   "https://api.hub.example.com:6443",
+			want:        "https://proxy.example.com:3129",
+		},
+		{
+			name:        "http proxy",
+			proxyConfig: proxyConfig,
+			serverURL:   "http://api.hub.example.com:6443",
+			want:        "http://proxy.example.com:3128",
+		},
+		{
+			name:        "no proxy",
+			proxyConfig: proxyConfig,
+			serverURL:   "https://api.internal.example.com:6443",
+			want:        "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			klusterletConfig := &configv1alpha1.KlusterletConfig{
+				Spec: configv1alpha1.KlusterletConfigSpec{ProxyConfig: tt.proxyConfig},
+			}
+			got, err := getProxyURL(klusterletConfig, tt.serverURL)
+			if err != nil {
+				t.Errorf("getProxyURL() unexpected error %v", err)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("getProxyURL() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_newKlusterletConfigMapper(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{}, &clusterv1.ManagedClusterList{})
+
+	newManagedCluster := func(name, reference string) *clusterv1.ManagedCluster {
+		managedCluster := &clusterv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+			},
+		}
+		if reference != "" {
+			managedCluster.Annotations = map[string]string{klusterletConfigAnnotation: reference}
+		}
+		return managedCluster
+	}
+
+	c := fake.NewFakeClientWithScheme(testscheme,
+		newManagedCluster("cluster1", "config"),
+		newManagedCluster("cluster2", "cluster1/config"),
+		newManagedCluster("cluster3", "configs/config"),
+		newManagedCluster("cluster4", ""),
+	)
+
+	klusterletConfig := &configv1alpha1.KlusterletConfig{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "config",
+			Namespace: "cluster1",
+		},
+	}
+
+	got := newKlusterletConfigMapper(c).Map(handler.MapObject{Meta: klusterletConfig, Object: klusterletConfig})
+	want := []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Name: "cluster1"}},
+		{NamespacedName: types.NamespacedName{Name: "cluster2"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("newKlusterletConfigMapper() = %v, want %v", got, want)
+	}
+}
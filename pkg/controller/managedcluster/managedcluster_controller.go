@@ -11,12 +11,15 @@ import (
 	"strings"
 	"time"
 
+	"github.com/open-cluster-management/managedcluster-import-controller/pkg/helpers"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/klog"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/event"
@@ -48,6 +51,9 @@ const autoImportRetryName string = "autoImportRetry"
 const autoImportSecretName string = "auto-import-secret"
 const ManagedClusterImportSucceeded string = "ManagedClusterImportSucceeded"
 
+// ManagedClusterBootstrapTokenReady is the condition type of the bootstrap token of the managed cluster
+const ManagedClusterBootstrapTokenReady string = "ManagedClusterBootstrapTokenReady"
+
 const (
 	curatorJobPrefix  string = "curator-job"
 	postHookJobPrefix string = "posthookjob"
@@ -55,13 +61,19 @@ const (
 )
 
 const (
-	createdViaAnnotation          = "open-cluster-management/created-via"
-	createdViaAnnotationDiscovery = "discovery"
-	createdViaAnnotationAI        = "assisted-installer"
-	createdViaAnnotationHive      = "hive"
-	createdViaAnnotationOther     = "other"
+	createdViaAnnotation           = "open-cluster-management/created-via"
+	createdViaAnnotationDiscovery  = "discovery"
+	createdViaAnnotationAI         = "assisted-installer"
+	createdViaAnnotationHive       = "hive"
+	createdViaAnnotationClusterAPI = "cluster-api"
+	createdViaAnnotationOther      = "other"
 )
 
+// managedClusterReferenceAnnotation on a Cluster API Cluster or a hive ClusterClaim names the ManagedCluster allowed
+// to be imported with the kubeconfig of the cluster. The reference of the ManagedCluster alone never grants access
+// to a cluster owned by another namespace.
+const managedClusterReferenceAnnotation = "import.open-cluster-management.io/managed-cluster"
+
 var log = logf.Log.WithName("controller_managedcluster")
 
 /**
@@ -69,7 +81,7 @@ var log = logf.Log.WithName("controller_managedcluster")
 * business logic.  Delete these comments after modifying this file.*
  */
 
-// customClient will do get secret without cache, other operations are like normal cache client
+// customClient will do get and list secret without cache, other operations are like normal cache client
 type customClient struct {
 	client.Client
 	APIReader client.Reader
@@ -90,6 +102,21 @@ func (cc customClient) Get(ctx context.Context, key client.ObjectKey, obj runtim
 	return cc.Client.Get(ctx, key, obj)
 }
 
+func (cc customClient) List(ctx context.Context, list runtime.Object, opts ...client.ListOption) error {
+	if _, ok := list.(*corev1.SecretList); ok {
+		return cc.APIReader.List(ctx, list, opts...)
+	}
+	return cc.Client.List(ctx, list, opts...)
+}
+
+// apiReader returns the reader of the client without cache, the client itself if it has no cache
+func apiReader(c client.Client) client.Reader {
+	if cc, ok := c.(customClient); ok {
+		return cc.APIReader
+	}
+	return c
+}
+
 func newManagedClusterSpecPredicate() predicate.Predicate {
 	return predicate.Predicate(predicate.Funcs{
 		GenericFunc: func(e event.GenericEvent) bool { return false },
@@ -117,6 +144,9 @@ func newManagedClusterSpecPredicate() predicate.Predicate {
 			if okNew && okOld {
 				return !reflect.DeepEqual(newManagedCluster.Spec, oldManagedCluster.Spec) ||
 					checkOffLine(newManagedCluster) != checkOffLine(oldManagedCluster) ||
+					annotationsChanged(oldManagedCluster, newManagedCluster, importConfigAnnotations...) ||
+					annotationsChanged(oldManagedCluster, newManagedCluster, importDryRunAnnotation) ||
+					annotationsChanged(oldManagedCluster, newManagedCluster, clusterAPIClusterAnnotation) ||
 					newManagedCluster.DeletionTimestamp != nil
 				// !reflect.DeepEqual(newManagedCluster.Status.Conditions, oldManagedCluster.Status.Conditions)
 			}
@@ -125,6 +155,38 @@ func newManagedClusterSpecPredicate() predicate.Predicate {
 	})
 }
 
+// importConfigAnnotations are the annotations of the ManagedCluster changing the generated import manifests
+var importConfigAnnotations = []string{
+	klusterletConfigAnnotation,
+	hubKubeAPIServerURLAnnotation,
+	hubKubeAPIServerCASecretAnnotation,
+}
+
+// annotationsChanged returns true if the value of one of the annotations differs between old and new
+func annotationsChanged(old, new metav1.Object, annotations ...string) bool {
+	for _, annotation := range annotations {
+		if old.GetAnnotations()[annotation] != new.GetAnnotations()[annotation] {
+			return true
+		}
+	}
+	return false
+}
+
+// namespacedNameAnnotation returns the "<namespace>/<name>" reference of the annotation of the managedCluster,
+// the returned bool is false if the managedCluster doesn't have the annotation
+func namespacedNameAnnotation(managedCluster *clusterv1.ManagedCluster, annotation string) (types.NamespacedName, bool, error) {
+	ref, ok := managedCluster.GetAnnotations()[annotation]
+	if !ok || ref == "" {
+		return types.NamespacedName{}, false, nil
+	}
+	i := strings.Index(ref, "/")
+	if i <= 0 || i == len(ref)-1 {
+		return types.NamespacedName{}, false,
+			fmt.Errorf("invalid %s annotation %q, the format is <namespace>/<name>", annotation, ref)
+	}
+	return types.NamespacedName{Namespace: ref[:i], Name: ref[i+1:]}, true, nil
+}
+
 func newManifestWorkSpecPredicate() predicate.Predicate {
 	return predicate.Predicate(predicate.Funcs{
 		GenericFunc: func(e event.GenericEvent) bool { return false },
@@ -150,7 +212,9 @@ func newManifestWorkSpecPredicate() predicate.Predicate {
 			newManifestWork, okNew := e.ObjectNew.(*workv1.ManifestWork)
 			oldManifestWork, okOld := e.ObjectOld.(*workv1.ManifestWork)
 			if okNew && okOld {
-				return !reflect.DeepEqual(newManifestWork.Spec, oldManifestWork.Spec)
+				return !reflect.DeepEqual(newManifestWork.Spec, oldManifestWork.Spec) ||
+					meta.IsStatusConditionTrue(newManifestWork.Status.Conditions, workv1.WorkApplied) !=
+						meta.IsStatusConditionTrue(oldManifestWork.Status.Conditions, workv1.WorkApplied)
 			}
 			return false
 		},
@@ -164,8 +228,10 @@ var _ reconcile.Reconciler = &ReconcileManagedCluster{}
 type ReconcileManagedCluster struct {
 	// This client, initialized using mgr.Client() above, is a split client
 	// that reads objects from the cache and writes to the apiserver
-	client client.Client
-	scheme *runtime.Scheme
+	client     client.Client
+	kubeClient kubernetes.Interface
+	recorder   record.EventRecorder
+	scheme     *runtime.Scheme
 }
 
 // Reconcile reads that state of the cluster for a ManagedCluster object and makes changes based on the state read
@@ -314,24 +380,113 @@ func (r *ReconcileManagedCluster) Reconcile(request reconcile.Request) (reconcil
 		return reconcile.Result{}, err
 	}
 
-	crds, yamls, err := generateImportYAMLs(r.client, instance, []string{})
+	tokenConfig, err := getBootstrapTokenConfig()
 	if err != nil {
 		return reconcile.Result{}, err
 	}
+	var tokenSecret *corev1.Secret
+	var untilTokenRotation time.Duration
+	if tokenConfig != nil {
+		tokenSecret, untilTokenRotation, err = ensureBootstrapToken(r.client, r.kubeClient, r.scheme, instance, tokenConfig)
+		if err != nil {
+			reqLogger.Error(err, "Error while requesting the bootstrap token")
+			if errCond := helpers.SetManagedClusterCondition(r.client, instance, metav1.Condition{
+				Type:    ManagedClusterBootstrapTokenReady,
+				Status:  metav1.ConditionFalse,
+				Reason:  "BootstrapTokenRequestFailed",
+				Message: fmt.Sprintf("Failed to request the bootstrap token: %v", err),
+			}); errCond != nil {
+				klog.Error(errCond)
+			}
+			return reconcile.Result{}, err
+		}
+	} else {
+		saTokenSecret, err := ensureBootstrapSATokenSecret(r.client, r.scheme, instance)
+		if err != nil {
+			reqLogger.Error(err, "Error while creating the bootstrap service account token secret")
+			return reconcile.Result{}, err
+		}
+		if len(saTokenSecret.Data["token"]) == 0 {
+			reqLogger.Info("Waiting for the token of the bootstrap service account", "secret", saTokenSecret.Name)
+			if err := helpers.SetManagedClusterCondition(r.client, instance, metav1.Condition{
+				Type:   ManagedClusterBootstrapTokenReady,
+				Status: metav1.ConditionFalse,
+				Reason: "BootstrapTokenPending",
+				Message: fmt.Sprintf("Waiting for the token controller to populate the secret %s/%s",
+					saTokenSecret.Namespace, saTokenSecret.Name),
+			}); err != nil {
+				return reconcile.Result{}, err
+			}
+			return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
+		}
+	}
+	if err := helpers.SetManagedClusterCondition(r.client, instance, metav1.Condition{
+		Type:    ManagedClusterBootstrapTokenReady,
+		Status:  metav1.ConditionTrue,
+		Reason:  "BootstrapTokenReady",
+		Message: "The bootstrap token is ready",
+	}); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	crds, yamls, err := generateImportYAMLs(r.client, instance, []string{})
+	if err != nil {
+		r.recorder.Eventf(instance, corev1.EventTypeWarning, "ImportSecretGenerationFailed",
+			"Failed to generate the import manifests: %v", err)
+		return reconcile.Result{}, setFailedCondition(r.client, instance,
+			ManagedClusterImportSecretGenerated, "ImportYAMLsGenerationFailed", err)
+	}
 
 	reqLogger.Info(fmt.Sprintf("createOrUpdateImportSecret: %s", instance.Name))
-	_, err = createOrUpdateImportSecret(r.client, r.scheme, instance, crds, yamls)
+	importSecret, err := createOrUpdateImportSecret(r.client, r.recorder, r.scheme, instance, crds, yamls)
 	if err != nil {
 		reqLogger.Error(err, "create ManagedCluster Import Secret")
+		r.recorder.Eventf(instance, corev1.EventTypeWarning, "ImportSecretGenerationFailed",
+			"Failed to create or update the import secret: %v", err)
+		return reconcile.Result{}, setFailedCondition(r.client, instance,
+			ManagedClusterImportSecretGenerated, "ImportSecretGenerationFailed", err)
+	}
+	if err := helpers.SetManagedClusterCondition(r.client, instance, metav1.Condition{
+		Type:    ManagedClusterImportSecretGenerated,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ImportSecretGenerated",
+		Message: fmt.Sprintf("The import secret %s/%s is generated", importSecret.Namespace, importSecret.Name),
+	}); err != nil {
 		return reconcile.Result{}, err
 	}
 
+	var klusterletAvailable *metav1.Condition
+	if condition := meta.FindStatusCondition(instance.Status.Conditions, ManagedClusterKlusterletAvailable); condition != nil {
+		klusterletAvailable = condition.DeepCopy()
+	}
+	if err := helpers.SetManagedClusterCondition(r.client, instance, newKlusterletAvailableCondition(instance)); err != nil {
+		return reconcile.Result{}, err
+	}
+	if err := observeFirstJoin(r.client, instance, clusterDeployment, klusterletAvailable); err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if tokenSecret != nil {
+		if err := setImportSecretTokenExpiration(r.client, instance, tokenSecret); err != nil {
+			return reconcile.Result{}, err
+		}
+		if err := revokeBootstrapTokens(r.client, instance, tokenSecret); err != nil {
+			reqLogger.Error(err, "Error while revoking the bootstrap tokens")
+			return reconcile.Result{}, err
+		}
+	}
+
 	//Remove syncset if exists as we are now using manifestworks
-	result, err := deleteKlusterletSyncSets(r.client, instance)
+	result, err := deleteKlusterletSyncSets(r.client, r.recorder, instance)
 	if err != nil {
 		return result, err
 	}
 
+	hibernating, err := setHibernatingCondition(r.client, instance, clusterDeployment)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
 	if !checkOffLine(instance) {
 		reqLogger.Info(fmt.Sprintf("createOrUpdateManifestWorks: %s", instance.Name))
 		isV1, err := isAPIExtensionV1(nil, instance, "")
@@ -349,9 +504,30 @@ func (r *ReconcileManagedCluster) Reconcile(request reconcile.Request) (reconcil
 		}
 		if err != nil {
 			reqLogger.Error(err, "Error while creating mw")
+			return reconcile.Result{}, setFailedCondition(r.client, instance,
+				ManagedClusterManifestWorkApplied, "ManifestWorkApplyFailed", err)
+		}
+		condition, err := getManifestWorkAppliedCondition(r.client, instance)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		if err := helpers.SetManagedClusterCondition(r.client, instance, condition); err != nil {
+			return reconcile.Result{}, err
+		}
+		if err := r.cleanupAvailableAutoImportSecret(instance); err != nil {
+			return reconcile.Result{}, err
+		}
+		//The klusterlet joined, the import does not wait for its stages anymore
+		if err := setImportStageProgress(r.client, instance, nil); err != nil {
 			return reconcile.Result{}, err
 		}
 	} else {
+		//Skip the import attempts while the hive cluster is hibernating, it is imported once running again
+		if hibernating {
+			klog.Infof("Not importing the hibernating cluster: %s", instance.Name)
+			return reconcile.Result{RequeueAfter: untilTokenRotation}, nil
+		}
+
 		autoImportSecret, toImport, err := r.toBeImported(instance, clusterDeployment)
 		if err != nil {
 			return reconcile.Result{}, err
@@ -360,28 +536,126 @@ func (r *ReconcileManagedCluster) Reconcile(request reconcile.Request) (reconcil
 		//Stop here if no auto-import
 		if !toImport {
 			klog.Infof("Not importing auto-import cluster: %s", instance.Name)
-			return reconcile.Result{}, nil
+			if err := setImportStageProgress(r.client, instance, nil); err != nil {
+				return reconcile.Result{}, err
+			}
+			// keep the result of a previous auto-import, its auto-import-secret is deleted once succeeded
+			if !meta.IsStatusConditionTrue(instance.Status.Conditions, ManagedClusterAutoImportAttempted) {
+				if err := helpers.SetManagedClusterCondition(r.client, instance, metav1.Condition{
+					Type:   ManagedClusterAutoImportAttempted,
+					Status: metav1.ConditionFalse,
+					Reason: "ManualImport",
+					Message: fmt.Sprintf("No %s found, apply the %s/%s%s secret on the managed cluster to import it",
+						autoImportSecretName, instance.Name, instance.Name, importSecretNamePostfix),
+				}); err != nil {
+					return reconcile.Result{}, err
+				}
+			}
+			return reconcile.Result{RequeueAfter: untilTokenRotation}, nil
+		}
+
+		if autoImportSecret != nil {
+			if _, err := getAutoImportCleanupPolicy(autoImportSecret); err != nil {
+				return reconcile.Result{}, setFailedCondition(r.client, instance,
+					ManagedClusterAutoImportAttempted, "InvalidCleanupPolicy", err)
+			}
+		}
+
+		//Compute the changes of the import on the managed cluster without applying them
+		dryRun, err := isImportDryRun(instance, autoImportSecret)
+		if err != nil {
+			return reconcile.Result{}, setFailedCondition(r.client, instance,
+				ManagedClusterImportDryRun, "InvalidDryRun", err)
+		}
+		if dryRun {
+			return reconcile.Result{RequeueAfter: untilTokenRotation}, r.dryRunImport(instance, clusterDeployment, autoImportSecret)
+		}
+
+		//Self-heal an imported cluster offline for too long with the hive, retained or hub credentials
+		if autoImportSecret == nil || getAutoImportSucceededTime(autoImportSecret) != nil {
+			offlineDuration, err := getSelfHealingOfflineDuration()
+			if err != nil {
+				return reconcile.Result{}, err
+			}
+			if offlineSince := getSelfHealingOfflineSince(instance); offlineDuration > 0 && offlineSince != nil {
+				result, err := r.selfHeal(instance, clusterDeployment, autoImportSecret, *offlineSince, offlineDuration)
+				return requeueForTokenRotation(result, untilTokenRotation), err
+			}
 		}
 
-		//Import the cluster
+		//Import again with a retained auto-import-secret only if the cluster went offline
+		if getAutoImportSucceededTime(autoImportSecret) != nil {
+			reimport, err := r.reimportWithRetainedSecret(instance, autoImportSecret)
+			if err != nil || !reimport {
+				return reconcile.Result{RequeueAfter: untilTokenRotation}, err
+			}
+		}
+
+		//Wait for the next attempt after a failed auto-import
+		if wait := untilNextAutoImportAttempt(autoImportSecret, time.Now()); wait > 0 {
+			klog.Infof("Next auto-import attempt of %s in %s", instance.Name, wait)
+			return requeueForTokenRotation(reconcile.Result{RequeueAfter: wait}, untilTokenRotation), nil
+		}
+
+		//Import the cluster, an attempt waiting for its stages is not counted again
+		if getImportStageProgress(instance) == nil {
+			autoImportAttempts.WithLabelValues(getCreatedVia(instance)).Inc()
+		}
 		result, err := r.importCluster(instance, clusterDeployment, autoImportSecret)
-		if result.Requeue || err != nil {
-			return result, err
+		if err != nil {
+			autoImportFailures.WithLabelValues(getCreatedVia(instance), autoImportFailureReason(err)).Inc()
+			reason := "AutoImportFailed"
+			if isTLSVerificationError(err) {
+				reason = "TLSVerificationFailed"
+			} else if stageReason := getImportStageFailureReason(err); stageReason != "" {
+				reason = stageReason
+			}
+			return result, setFailedCondition(r.client, instance,
+				ManagedClusterAutoImportAttempted, reason, err)
+		}
+		if result.Requeue {
+			return requeueForTokenRotation(result, untilTokenRotation), err
+		}
+		if err := helpers.SetManagedClusterCondition(r.client, instance, metav1.Condition{
+			Type:    ManagedClusterAutoImportAttempted,
+			Status:  metav1.ConditionTrue,
+			Reason:  "AutoImportSucceeded",
+			Message: "The klusterlet is applied on the managed cluster",
+		}); err != nil {
+			return reconcile.Result{}, err
 		}
 		errCond := r.setConditionImport(instance, err, fmt.Sprintf("Unable to import %s", instance.Name))
 		if errCond != nil {
 			klog.Error(errCond)
 			return reconcile.Result{}, errCond
 		}
-		return result, err
+		return requeueForTokenRotation(result, untilTokenRotation), err
 	}
-	return reconcile.Result{}, nil
+	// requeue to rotate the bootstrap token
+	return reconcile.Result{RequeueAfter: untilTokenRotation}, nil
 }
 
 func (r *ReconcileManagedCluster) isReadyToReconcile(managedCluster *clusterv1.ManagedCluster) (*hivev1.ClusterDeployment, bool, error) {
+	//Check if the cluster is claimed from a hive ClusterPool
+	claimNsN, ok, err := clusterClaimNsN(managedCluster)
+	if err != nil {
+		return nil, false, err
+	}
+	if ok {
+		return r.isClaimedClusterReadyToReconcile(managedCluster, claimNsN)
+	}
+	//Check if the cluster is provisioned by Cluster API
+	clusterNsN, ok, err := clusterAPIClusterNsN(managedCluster)
+	if err != nil {
+		return nil, false, err
+	}
+	if ok {
+		ready, err := r.isClusterAPIClusterReadyToReconcile(managedCluster, clusterNsN)
+		return nil, ready, err
+	}
 	//Check if hive cluster and get client from clusterDeployment
 	clusterDeployment := &hivev1.ClusterDeployment{}
-	err := r.client.Get(
+	err = r.client.Get(
 		context.TODO(),
 		types.NamespacedName{
 			Name:      managedCluster.Name,
@@ -397,6 +671,11 @@ func (r *ReconcileManagedCluster) isReadyToReconcile(managedCluster *clusterv1.M
 		return nil, false, err
 	}
 	if !clusterDeployment.Spec.Installed {
+		//An Assisted Installer cluster can be imported as soon as its kubeconfig is available
+		ready, err := r.isAgentClusterInstallReadyToReconcile(managedCluster, clusterDeployment)
+		if err != nil || ready {
+			return clusterDeployment, ready, err
+		}
 		klog.Infof("not ready to reconcile, cluster %s not yet installed", clusterDeployment.Name)
 		return clusterDeployment, false, nil
 	}
@@ -417,6 +696,10 @@ func (r *ReconcileManagedCluster) setCreatedViaAnnotation(managedCluster *cluste
 		updateCreatedViaAnnotation(managedCluster, createdViaAnnotationHive)
 		return
 	}
+	if isClusterAPI(managedCluster) {
+		updateCreatedViaAnnotation(managedCluster, createdViaAnnotationClusterAPI)
+		return
+	}
 	updateCreatedViaAnnotation(managedCluster, createdViaAnnotationOther)
 }
 
@@ -457,6 +740,10 @@ func (r *ReconcileManagedCluster) toBeImported(managedCluster *clusterv1.Managed
 		//clusterDeployment found and so need to be imported
 		return nil, true, nil
 	}
+	//Check if Cluster API cluster, imported with the kubeconfig of its Cluster
+	if isClusterAPI(managedCluster) {
+		return nil, true, nil
+	}
 	//Check auto-import
 	klog.V(2).Info("Check autoImportRetry")
 	autoImportSecret := &corev1.Secret{}
@@ -492,9 +779,7 @@ func (r *ReconcileManagedCluster) setConditionImport(managedCluster *clusterv1.M
 			newCondition.Message += ": " + reason
 		}
 	}
-	patch := client.MergeFrom(managedCluster.DeepCopy())
-	meta.SetStatusCondition(&managedCluster.Status.Conditions, newCondition)
-	err := r.client.Status().Patch(context.TODO(), managedCluster, patch)
+	err := helpers.SetManagedClusterCondition(r.client, managedCluster, newCondition)
 	if err != nil {
 		return err
 	}
@@ -573,6 +858,9 @@ func (r *ReconcileManagedCluster) deleteNamespace(namespaceName string) error {
 		if err != nil {
 			return err
 		}
+		r.recorder.Eventf(ns, corev1.EventTypeWarning, "NamespaceDeletionBlocked",
+			"The namespace %s is not deleted as the ClusterDeployment %s/%s still exists",
+			namespaceName, namespaceName, namespaceName)
 		return fmt.Errorf(
 			"can not delete namespace %s as ClusterDeployment %s still exist",
 			namespaceName,
@@ -591,6 +879,8 @@ func (r *ReconcileManagedCluster) deleteNamespace(namespaceName string) error {
 				!strings.HasPrefix(pod.Name, postHookJobPrefix) &&
 				!strings.HasPrefix(pod.Name, preHookJobPrefix) {
 				log.Info("Detected non curator pods, the namespace will be not deleted")
+				r.recorder.Eventf(ns, corev1.EventTypeWarning, "NamespaceDeletionBlocked",
+					"The namespace %s is not deleted as it contains the pod %s", namespaceName, pod.Name)
 				tobeDeleted = false
 				break
 			}
@@ -22,6 +22,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
@@ -289,8 +290,9 @@ func TestReconcileManagedCluster_Reconcile(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Logf("Test name: %s", tt.name)
 			r := &ReconcileManagedCluster{
-				client: tt.fields.client,
-				scheme: tt.fields.scheme,
+				client:   tt.fields.client,
+				recorder: record.NewFakeRecorder(10),
+				scheme:   tt.fields.scheme,
 			}
 			var got reconcile.Result
 			var err error
@@ -472,8 +474,9 @@ func TestReconcileManagedCluster_Reconcile(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Logf("Test name: %s", tt.name)
 			r := &ReconcileManagedCluster{
-				client: tt.fields.client,
-				scheme: tt.fields.scheme,
+				client:   tt.fields.client,
+				recorder: record.NewFakeRecorder(10),
+				scheme:   tt.fields.scheme,
 			}
 			got, err := r.Reconcile(tt.args.request)
 			if (err != nil) != tt.wantErr {
@@ -682,8 +685,9 @@ func TestReconcileManagedCluster_deleteNamespace(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &ReconcileManagedCluster{
-				client: tt.fields.client,
-				scheme: tt.fields.scheme,
+				client:   tt.fields.client,
+				recorder: record.NewFakeRecorder(10),
+				scheme:   tt.fields.scheme,
 			}
 			if err := r.deleteNamespace(tt.args.namespaceName); (err != nil) != tt.wantErr {
 				t.Errorf("ReconcileManagedCluster.deleteNamespace() error = %v, wantErr %v", err, tt.wantErr)
@@ -4,11 +4,14 @@ package managedcluster
 
 import (
 	"context"
+	"crypto/x509"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -24,9 +27,13 @@ import (
 
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
 
-	"github.com/open-cluster-management/applier/pkg/applier"
-	"github.com/open-cluster-management/applier/pkg/templateprocessor"
+const (
+	//the CA bundle verifying the certificate of the server of the auto-import-secret
+	autoImportCAKey = "ca.crt"
+	//the opt-in to skip the verification of the certificate of the server of the auto-import-secret
+	autoImportInsecureSkipTLSVerifyKey = "insecure-skip-tls-verify"
 )
 
 func (r *ReconcileManagedCluster) importCluster(
@@ -46,12 +53,17 @@ func (r *ReconcileManagedCluster) importCluster(
 	//A clusterDeployment exist then get the client
 	if clusterDeployment != nil {
 		klog.Infof("Use hive client to import cluster %s", managedCluster.Name)
+		r.recorder.Eventf(managedCluster, corev1.EventTypeNormal, "AutoImportAttempt",
+			"Importing the cluster with the credentials of the ClusterDeployment %s/%s",
+			clusterDeployment.Namespace, clusterDeployment.Name)
 		managedClusterClient, rConfig, err = r.getManagedClusterClientFromHive(clusterDeployment, managedCluster)
 		if err != nil {
 			return reconcile.Result{}, err
 		}
 		//Testing to avoid update which will generate roundtrip as the clusterDeployment is watched
-		if !libgometav1.HasFinalizer(clusterDeployment, managedClusterFinalizer) {
+		//The clusterDeployment of a claimed cluster belongs to its pool and is not blocked
+		if clusterDeployment.Namespace == managedCluster.Name &&
+			!libgometav1.HasFinalizer(clusterDeployment, managedClusterFinalizer) {
 			klog.Info("Add finalizer in clusterDeployment")
 			libgometav1.AddFinalizer(clusterDeployment, managedClusterFinalizer)
 			// patchValue, err := json.Marshal(clusterDeployment.Finalizers)
@@ -67,9 +79,24 @@ func (r *ReconcileManagedCluster) importCluster(
 		}
 	}
 
+	//A Cluster API cluster then get the client from its kubeconfig secret
+	if clusterDeployment == nil && autoImportSecret == nil && isClusterAPI(managedCluster) {
+		var clusterNsN types.NamespacedName
+		clusterNsN, _, err = clusterAPIClusterNsN(managedCluster)
+		if err != nil {
+			return reconcile.Result{}, err
+		}
+		klog.Infof("Use Cluster API kubeconfig to import cluster %s", managedCluster.Name)
+		r.recorder.Eventf(managedCluster, corev1.EventTypeNormal, "AutoImportAttempt",
+			"Importing the cluster with the kubeconfig of the Cluster API Cluster %s", clusterNsN)
+		managedClusterClient, rConfig, err = r.getManagedClusterClientFromClusterAPI(managedCluster, clusterNsN)
+	}
+
 	//Check if auto-import and get client from the importSecret
 	if autoImportSecret != nil {
 		klog.Infof("Use autoImportSecret to import cluster %s", managedCluster.Name)
+		r.recorder.Eventf(managedCluster, corev1.EventTypeNormal, "AutoImportAttempt",
+			"Importing the cluster with the %s/%s secret", autoImportSecret.Namespace, autoImportSecret.Name)
 		managedClusterClient, rConfig, err = r.getManagedClusterClientFromAutoImportSecret(autoImportSecret)
 	}
 
@@ -81,6 +108,14 @@ func (r *ReconcileManagedCluster) importCluster(
 		}
 		res, err = r.importClusterWithClient(managedCluster, autoImportSecret, managedClusterClient, managedClusterKubeVersion)
 	}
+	if err != nil {
+		r.recorder.Eventf(managedCluster, corev1.EventTypeWarning, "AutoImportFailed",
+			"Failed to import the cluster: %v", err)
+		//The next attempt applies the stages from the start
+		if errProgress := setImportStageProgress(r.client, managedCluster, nil); errProgress != nil {
+			klog.Error(errProgress)
+		}
+	}
 	if err != nil && autoImportSecret != nil {
 		errUpdate := r.updateAutoImportRetry(managedCluster, autoImportSecret)
 		if errUpdate != nil {
@@ -92,14 +127,41 @@ func (r *ReconcileManagedCluster) importCluster(
 
 }
 
+// getManagedClusterClient returns the client of the managed cluster from the admin kubeconfig of the
+// clusterDeployment, the retained autoImportSecret, the Cluster API kubeconfig or the hub for a self managed cluster
+func (r *ReconcileManagedCluster) getManagedClusterClient(
+	managedCluster *clusterv1.ManagedCluster,
+	clusterDeployment *hivev1.ClusterDeployment,
+	autoImportSecret *corev1.Secret) (client.Client, *rest.Config, error) {
+	if clusterDeployment != nil {
+		return r.getManagedClusterClientFromHive(clusterDeployment, managedCluster)
+	}
+	if autoImportSecret != nil {
+		return r.getManagedClusterClientFromAutoImportSecret(autoImportSecret)
+	}
+	clusterNsN, ok, err := clusterAPIClusterNsN(managedCluster)
+	if err != nil {
+		return nil, nil, err
+	}
+	if ok {
+		return r.getManagedClusterClientFromClusterAPI(managedCluster, clusterNsN)
+	}
+	rConfig, err := libgoconfig.LoadConfig("", "", "")
+	return r.client, rConfig, err
+}
+
 //get the client from hive clusterDeployment credentials secret
 func (r *ReconcileManagedCluster) getManagedClusterClientFromHive(
 	clusterDeployment *hivev1.ClusterDeployment,
 	managedCluster *clusterv1.ManagedCluster) (client.Client, *rest.Config, error) {
+	secretName, err := getAdminKubeconfigSecretName(r.client, clusterDeployment)
+	if err != nil {
+		return nil, nil, err
+	}
 	managedClusterKubeSecret := &corev1.Secret{}
-	err := r.client.Get(context.TODO(), types.NamespacedName{
-		Name:      clusterDeployment.Spec.ClusterMetadata.AdminKubeconfigSecretRef.Name,
-		Namespace: managedCluster.Name,
+	err = r.client.Get(context.TODO(), types.NamespacedName{
+		Name:      secretName,
+		Namespace: clusterDeployment.Namespace,
 	},
 		managedClusterKubeSecret)
 	if err != nil {
@@ -112,6 +174,10 @@ func (r *ReconcileManagedCluster) getManagedClusterClientFromHive(
 //Get the client from the auto-import-secret
 func (r *ReconcileManagedCluster) getManagedClusterClientFromAutoImportSecret(
 	autoImportSecret *corev1.Secret) (client.Client, *rest.Config, error) {
+	//generate client using the cloud credentials
+	if isCloudAutoImportSecret(autoImportSecret) {
+		return getClientFromCloudCredentials(autoImportSecret)
+	}
 	//generate client using kubeconfig
 	if k, ok := autoImportSecret.Data["kubeconfig"]; ok {
 		return getClientFromKubeConfig(k)
@@ -119,7 +185,12 @@ func (r *ReconcileManagedCluster) getManagedClusterClientFromAutoImportSecret(
 	token, tok := autoImportSecret.Data["token"]
 	server, sok := autoImportSecret.Data["server"]
 	if tok && sok {
-		return getClientFromToken(string(token), string(server))
+		insecureSkipTLSVerify, err := getInsecureSkipTLSVerify(autoImportSecret)
+		if err != nil {
+			return nil, nil, err
+		}
+		return getClientFromToken(string(token), string(server),
+			autoImportSecret.Data[autoImportCAKey], insecureSkipTLSVerify)
 	}
 
 	return nil, nil, fmt.Errorf("kubeconfig or token and server are missing")
@@ -148,12 +219,33 @@ func getClientFromKubeConfig(kubeconfig []byte) (client.Client, *rest.Config, er
 }
 
 //Create client from token and server
-func getClientFromToken(token, server string) (client.Client, *rest.Config, error) {
+func getClientFromToken(token, server string, ca []byte, insecureSkipTLSVerify bool) (client.Client, *rest.Config, error) {
+	restConfig, err := getRestConfigFromToken(token, server, ca, insecureSkipTLSVerify)
+	if err != nil {
+		return nil, nil, err
+	}
+	clientClient, err := client.New(restConfig, client.Options{})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return clientClient, restConfig, nil
+}
+
+//Create the rest config from token and server, the certificate of the server is verified with the ca
+//or the system CAs if the ca is not set, unless insecureSkipTLSVerify is set
+func getRestConfigFromToken(token, server string, ca []byte, insecureSkipTLSVerify bool) (*rest.Config, error) {
+	if insecureSkipTLSVerify && len(ca) > 0 {
+		return nil, fmt.Errorf("%s and %s can not be both set in the auto-import-secret",
+			autoImportCAKey, autoImportInsecureSkipTLSVerifyKey)
+	}
+
 	//Create config
 	config := clientcmdapi.NewConfig()
 	config.Clusters["default"] = &clientcmdapi.Cluster{
-		Server:                server,
-		InsecureSkipTLSVerify: true,
+		Server:                   server,
+		CertificateAuthorityData: ca,
+		InsecureSkipTLSVerify:    insecureSkipTLSVerify,
 	}
 	config.AuthInfos["default"] = &clientcmdapi.AuthInfo{
 		Token: token,
@@ -165,16 +257,30 @@ func getClientFromToken(token, server string) (client.Client, *rest.Config, erro
 	config.CurrentContext = "default"
 
 	clientConfig := clientcmd.NewDefaultClientConfig(*config, &clientcmd.ConfigOverrides{})
-	restConfig, err := clientConfig.ClientConfig()
-	if err != nil {
-		return nil, nil, err
+	return clientConfig.ClientConfig()
+}
+
+//Get the insecure-skip-tls-verify opt-in of the auto-import-secret
+func getInsecureSkipTLSVerify(autoImportSecret *corev1.Secret) (bool, error) {
+	value, ok := autoImportSecret.Data[autoImportInsecureSkipTLSVerifyKey]
+	if !ok {
+		return false, nil
 	}
-	clientClient, err := client.New(restConfig, client.Options{})
+	insecureSkipTLSVerify, err := strconv.ParseBool(string(value))
 	if err != nil {
-		return nil, nil, err
+		return false, fmt.Errorf("invalid %s in the auto-import-secret: %v", autoImportInsecureSkipTLSVerifyKey, err)
 	}
+	return insecureSkipTLSVerify, nil
+}
 
-	return clientClient, restConfig, nil
+//isTLSVerificationError returns true if the certificate of the managed cluster kube apiserver is not verified
+func isTLSVerificationError(err error) bool {
+	var unknownAuthorityErr x509.UnknownAuthorityError
+	var hostnameErr x509.HostnameError
+	var certificateInvalidErr x509.CertificateInvalidError
+	return errors.As(err, &unknownAuthorityErr) ||
+		errors.As(err, &hostnameErr) ||
+		errors.As(err, &certificateInvalidErr)
 }
 
 func getManagedClusterKubeVersion(rConfig *rest.Config) (string, error) {
@@ -195,6 +301,32 @@ func (r *ReconcileManagedCluster) updateAutoImportRetry(
 	managedCluster *clusterv1.ManagedCluster,
 	autoImportSecret *corev1.Secret) error {
 	if autoImportSecret != nil {
+		backoff, err := getAutoImportBackoff()
+		if err != nil {
+			return err
+		}
+		deadline, err := getAutoImportRetryUntil(autoImportSecret)
+		if err != nil {
+			return err
+		}
+		now := time.Now()
+		next := recordAutoImportAttempt(autoImportSecret, backoff, now)
+
+		//Retry until the deadline instead of counting the retries
+		if deadline != nil {
+			if next.After(*deadline) {
+				return r.deleteExhaustedAutoImportSecret(managedCluster, autoImportSecret,
+					fmt.Sprintf("The retry deadline %s is reached", deadline.UTC().Format(time.RFC3339)))
+			}
+			if err := r.client.Update(context.TODO(), autoImportSecret); err != nil {
+				return err
+			}
+			r.recorder.Eventf(managedCluster, corev1.EventTypeNormal, "AutoImportRetry",
+				"The import is retried at %s until %s",
+				next.UTC().Format(time.RFC3339), deadline.UTC().Format(time.RFC3339))
+			return nil
+		}
+
 		//Decrement the autoImportRetry
 		autoImportRetry, err := strconv.Atoi(string(autoImportSecret.Data[autoImportRetryName]))
 		if err != nil {
@@ -204,152 +336,203 @@ func (r *ReconcileManagedCluster) updateAutoImportRetry(
 		autoImportRetry--
 		//Remove if negatif as a label can not start with "-", should start by a char
 		if autoImportRetry < 0 {
-			err = r.client.Delete(context.TODO(), autoImportSecret)
-			if err != nil {
-				return err
-			}
-			autoImportSecret = nil
-		} else {
-			v := []byte(strconv.Itoa(autoImportRetry))
-			autoImportSecret.Data[autoImportRetryName] = v
-			err := r.client.Update(context.TODO(), autoImportSecret)
-			if err != nil {
-				return err
-			}
+			return r.deleteExhaustedAutoImportSecret(managedCluster, autoImportSecret, "No retry left")
 		}
+		v := []byte(strconv.Itoa(autoImportRetry))
+		autoImportSecret.Data[autoImportRetryName] = v
+		err = r.client.Update(context.TODO(), autoImportSecret)
+		if err != nil {
+			return err
+		}
+		r.recorder.Eventf(managedCluster, corev1.EventTypeNormal, "AutoImportRetry",
+			"%d retries left to import the cluster, the next retry is at %s",
+			autoImportRetry, next.UTC().Format(time.RFC3339))
 	}
 	return nil
 }
 
-//importCluster import a cluster if autoImportRetry > 0
-func (r *ReconcileManagedCluster) importClusterWithClient(
+//deleteExhaustedAutoImportSecret deletes the auto-import-secret once its retries are exhausted
+func (r *ReconcileManagedCluster) deleteExhaustedAutoImportSecret(
 	managedCluster *clusterv1.ManagedCluster,
 	autoImportSecret *corev1.Secret,
-	managedClusterClient client.Client,
-	managedClusterKubeVersion string) (reconcile.Result, error) {
+	reason string) error {
+	if err := r.client.Delete(context.TODO(), autoImportSecret); err != nil {
+		return err
+	}
+	r.recorder.Eventf(managedCluster, corev1.EventTypeWarning, "AutoImportRetriesExhausted",
+		"%s to import the cluster, the %s/%s secret is deleted",
+		reason, autoImportSecret.Namespace, autoImportSecret.Name)
+	return nil
+}
 
-	klog.Infof("Importing cluster: %s", managedCluster.Name)
+// getImportObjects returns the crds and the yamls to apply on the managed cluster and the excluded yamls
+func (r *ReconcileManagedCluster) getImportObjects(
+	managedCluster *clusterv1.ManagedCluster,
+	managedClusterClient client.Client,
+	managedClusterKubeVersion string) (crds, yamls []*unstructured.Unstructured, excluded []string, err error) {
+	klusterletConfig, err := getKlusterletConfig(r.client, managedCluster)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 
 	//Do not create SA if already exists
-	excluded := make([]string, 0)
+	excluded = make([]string, 0)
 	sa := &corev1.ServiceAccount{}
 	if err := managedClusterClient.Get(context.TODO(),
 		types.NamespacedName{
 			Name:      "klusterlet",
-			Namespace: klusterletNamespace,
+			Namespace: getKlusterletNamespace(klusterletConfig),
 		}, sa); err == nil {
 		excluded = append(excluded, "klusterlet/service_account.yaml")
 	}
 	//Generate crds and yamls
-	crds, yamls, err := generateImportYAMLs(r.client, managedCluster, excluded)
+	allCRDs, yamls, err := generateImportYAMLs(r.client, managedCluster, excluded)
 	if err != nil {
-		return reconcile.Result{Requeue: true, RequeueAfter: 30 * time.Second}, err
+		return nil, nil, nil, err
 	}
 
-	var bb [][]byte
-	//Convert crds to Yaml
 	isV1, err := isAPIExtensionV1(managedClusterClient, managedCluster, managedClusterKubeVersion)
 	if err != nil {
-		return reconcile.Result{Requeue: true, RequeueAfter: 30 * time.Second}, err
+		return nil, nil, nil, err
 	}
 	if isV1 {
-		bb, err = templateprocessor.ToYAMLsUnstructured(crds["v1"])
-	} else {
-		bb, err = templateprocessor.ToYAMLsUnstructured(crds["v1beta1"])
-	}
-	if err != nil {
-		return reconcile.Result{}, err
-	}
-	//create applier for crds
-	a, err := applier.NewApplier(
-		templateprocessor.NewYamlStringReader(templateprocessor.ConvertArrayOfBytesToString(bb),
-			templateprocessor.KubernetesYamlsDelimiter),
-		nil,
-		managedClusterClient,
-		nil,
-		nil,
-		nil)
-	if err != nil {
-		return reconcile.Result{}, err
+		return allCRDs["v1"], yamls, excluded, nil
 	}
+	return allCRDs["v1beta1"], yamls, excluded, nil
+}
 
-	//Create the crds resources
-	err = a.CreateOrUpdateInPath(".", nil, false, nil)
+//importCluster import a cluster if autoImportRetry > 0
+func (r *ReconcileManagedCluster) importClusterWithClient(
+	managedCluster *clusterv1.ManagedCluster,
+	autoImportSecret *corev1.Secret,
+	managedClusterClient client.Client,
+	managedClusterKubeVersion string) (reconcile.Result, error) {
+
+	klog.Infof("Importing cluster: %s", managedCluster.Name)
+
+	crds, yamls, excluded, err := r.getImportObjects(managedCluster, managedClusterClient, managedClusterKubeVersion)
 	if err != nil {
 		return reconcile.Result{Requeue: true, RequeueAfter: 30 * time.Second}, err
 	}
 
-	//Convert yamls to yaml
-	bb, err = templateprocessor.ToYAMLsUnstructured(yamls)
+	//Apply the stages in order, the klusterlet operator must be ready before the import succeeds
+	timeouts, err := getImportStageTimeouts()
 	if err != nil {
 		return reconcile.Result{}, err
 	}
-	//Create applier for yamls
-	a, err = applier.NewApplier(
-		templateprocessor.NewYamlStringReader(templateprocessor.ConvertArrayOfBytesToString(bb),
-			templateprocessor.KubernetesYamlsDelimiter),
-		nil,
-		managedClusterClient,
-		nil,
-		nil,
-		nil)
-	if err != nil {
-		return reconcile.Result{}, err
+	progress, err := applyImportStages(managedClusterClient, newImportStages(crds, yamls, timeouts), excluded,
+		getImportStageProgress(managedCluster), time.Now())
+	if errProgress := setImportStageProgress(r.client, managedCluster, progress); errProgress != nil {
+		return reconcile.Result{}, errProgress
 	}
-
-	//Create the yamls resources
-	err = a.CreateOrUpdateInPath(".", excluded, false, nil)
 	if err != nil {
 		return reconcile.Result{Requeue: true, RequeueAfter: 30 * time.Second}, err
 	}
+	if progress != nil {
+		//Check the stage again without blocking the reconcile of the other clusters
+		return reconcile.Result{Requeue: true, RequeueAfter: importStageRequeueInterval}, nil
+	}
 
-	//Succeeded do not retry, then remove the autoImportRetryLabel
+	//Succeeded do not retry, then apply the cleanup policy of the auto-import-secret
 	if autoImportSecret != nil {
-		if err := r.client.Delete(context.TODO(), autoImportSecret); err != nil {
+		if err := r.cleanupAutoImportSecret(managedCluster, autoImportSecret); err != nil {
 			return reconcile.Result{}, err
 		}
 	}
 	klog.Infof("Successfully imported %s", managedCluster.Name)
+	r.recorder.Event(managedCluster, corev1.EventTypeNormal, "AutoImportSucceeded",
+		"The klusterlet is applied and its operator is ready on the managed cluster")
 	return reconcile.Result{}, nil
 }
 
 func (r *ReconcileManagedCluster) managedClusterDeletion(instance *clusterv1.ManagedCluster) (reconcile.Result, error) {
 	reqLogger := log.WithValues("Instance.Namespace", instance.Namespace, "Instance.Name", instance.Name)
 	reqLogger.Info(fmt.Sprintf("Instance in Terminating: %s", instance.Name))
-	if len(filterFinalizers(instance, []string{managedClusterFinalizer, registrationFinalizer})) != 0 {
-		return reconcile.Result{Requeue: true, RequeueAfter: 1 * time.Minute}, nil
+	if finalizers := filterFinalizers(instance, []string{managedClusterFinalizer, registrationFinalizer}); len(finalizers) != 0 {
+		return reconcile.Result{Requeue: true, RequeueAfter: 1 * time.Minute},
+			setDetachingCondition(r.client, instance, "WaitingForFinalizers",
+				fmt.Sprintf("Waiting for the removal of the finalizers %v", finalizers))
 	}
 
 	offLine := checkOffLine(instance)
+	//The manifestworks of a hibernating cluster are not evicted, the klusterlet is removed once it is running again
+	hibernating := false
+	if offLine {
+		var err error
+		if hibernating, err = r.isDetachedClusterHibernating(instance); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+	//The wait for a hibernating cluster is bounded, then its manifestworks are evicted as for an offline cluster
+	hibernatingWait := time.Duration(0)
+	if hibernating {
+		var err error
+		if hibernatingWait, err = untilHibernatingDetachTimeout(instance, time.Now()); err != nil {
+			return reconcile.Result{}, setFailedCondition(r.client, instance,
+				ManagedClusterDetaching, "HibernatingDetachConfigInvalid", err)
+		}
+		if hibernatingWait == 0 {
+			r.recorder.Event(instance, corev1.EventTypeWarning, "HibernatingClusterEvicted",
+				"The cluster is still hibernating after the detach timeout or the detach is forced, its manifestworks are evicted")
+			hibernating = false
+		}
+	}
+
 	reqLogger.Info(fmt.Sprintf("deleteAllOtherManifestWork: %s", instance.Name))
 	err := deleteAllOtherManifestWork(r.client, instance)
 	if err != nil {
 		if !offLine {
-			return reconcile.Result{}, err
+			return reconcile.Result{}, setFailedCondition(r.client, instance,
+				ManagedClusterDetaching, "ManifestWorksDeletionFailed", err)
 		}
 	}
 
-	if offLine {
+	if offLine && !hibernating {
 		reqLogger.Info(fmt.Sprintf("evictAllOtherManifestWork: %s", instance.Name))
 		err = evictAllOtherManifestWork(r.client, instance)
 		if err != nil {
-			return reconcile.Result{}, err
+			return reconcile.Result{}, setFailedCondition(r.client, instance,
+				ManagedClusterDetaching, "ManifestWorksEvictionFailed", err)
 		}
+		r.recorder.Event(instance, corev1.EventTypeNormal, "ManifestWorksEvicted",
+			"The cluster is offline, the finalizers of its manifestworks are removed")
 	}
 
 	reqLogger.Info(fmt.Sprintf("deleteKlusterletManifestWorks: %s", instance.Name))
 	err = deleteKlusterletManifestWorks(r.client, instance)
 	if err != nil {
-		return reconcile.Result{}, err
+		return reconcile.Result{}, setFailedCondition(r.client, instance,
+			ManagedClusterDetaching, "ManifestWorksDeletionFailed", err)
+	}
+
+	if hibernating {
+		requeueAfter := 1 * time.Minute
+		if hibernatingWait < requeueAfter {
+			requeueAfter = hibernatingWait
+		}
+		return reconcile.Result{Requeue: true, RequeueAfter: requeueAfter},
+			setDetachingCondition(r.client, instance, "ClusterHibernating",
+				fmt.Sprintf("The cluster is hibernating, waiting until %s for the cluster to run again to remove the klusterlet",
+					time.Now().Add(hibernatingWait).UTC().Format(time.RFC3339)))
 	}
 
 	if !offLine {
-		return reconcile.Result{Requeue: true, RequeueAfter: 1 * time.Minute}, nil
+		return reconcile.Result{Requeue: true, RequeueAfter: 1 * time.Minute},
+			setDetachingCondition(r.client, instance, "KlusterletRemoving",
+				"Waiting for the work agent to remove the klusterlet from the managed cluster")
 	}
 
 	reqLogger.Info(fmt.Sprintf("evictKlusterletManifestWorks: %s", instance.Name))
 	err = evictKlusterletManifestWorks(r.client, instance)
 	if err != nil {
+		return reconcile.Result{}, setFailedCondition(r.client, instance,
+			ManagedClusterDetaching, "ManifestWorksEvictionFailed", err)
+	}
+	r.recorder.Event(instance, corev1.EventTypeNormal, "KlusterletManifestWorksEvicted",
+		"The cluster is offline, the finalizers of the klusterlet manifestworks are removed")
+
+	if err := setDetachingCondition(r.client, instance, "ManagedClusterDetached",
+		"The managed cluster is offline, the manifestworks are evicted"); err != nil {
 		return reconcile.Result{}, err
 	}
 
@@ -358,6 +541,7 @@ func (r *ReconcileManagedCluster) managedClusterDeletion(instance *clusterv1.Man
 	if err := r.client.Update(context.TODO(), instance); err != nil {
 		return reconcile.Result{}, err
 	}
+	observeDetachDuration(instance)
 
 	return reconcile.Result{Requeue: true, RequeueAfter: 5 * time.Second}, nil
 }
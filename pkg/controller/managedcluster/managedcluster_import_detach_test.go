@@ -4,7 +4,11 @@ package managedcluster
 
 import (
 	"context"
+	"encoding/pem"
+	"fmt"
 	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
@@ -19,6 +23,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -102,7 +107,7 @@ func TestReconcileManagedCluster_importClusterWithClient(t *testing.T) {
 		managedCluster,
 		serviceAccount,
 		autoImportSecret)
-	clientManaged := fake.NewFakeClientWithScheme(schemeHub)
+	clientManaged := healthyManagedClusterClient{fake.NewFakeClientWithScheme(schemeHub)}
 
 	type fields struct {
 		client client.Client
@@ -155,8 +160,9 @@ func TestReconcileManagedCluster_importClusterWithClient(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &ReconcileManagedCluster{
-				client: tt.fields.client,
-				scheme: tt.fields.scheme,
+				client:   tt.fields.client,
+				recorder: record.NewFakeRecorder(10),
+				scheme:   tt.fields.scheme,
 			}
 			got, errTest := r.importClusterWithClient(
 				tt.args.managedCluster,
@@ -271,7 +277,7 @@ func Test_getClientFromToken(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, config, err := getClientFromToken(tt.args.token, tt.args.server)
+			_, config, err := getClientFromToken(tt.args.token, tt.args.server, envTest.Config.CAData, false)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("getClientFromToken() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -414,8 +420,9 @@ func TestReconcileManagedCluster_getManagedClusterClientFromAutoImportSecret(t *
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &ReconcileManagedCluster{
-				client: tt.fields.client,
-				scheme: tt.fields.scheme,
+				client:   tt.fields.client,
+				recorder: record.NewFakeRecorder(10),
+				scheme:   tt.fields.scheme,
 			}
 			_, config, err := r.getManagedClusterClientFromAutoImportSecret(tt.args.autoImportSecret)
 			if (err != nil) != tt.wantErr {
@@ -508,8 +515,9 @@ func TestReconcileManagedCluster_getManagedClusterClientFromHive(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &ReconcileManagedCluster{
-				client: tt.fields.client,
-				scheme: tt.fields.scheme,
+				client:   tt.fields.client,
+				recorder: record.NewFakeRecorder(10),
+				scheme:   tt.fields.scheme,
 			}
 			_, config, err := r.getManagedClusterClientFromHive(tt.args.clusterDeployment, tt.args.managedCluster)
 			if (err != nil) != tt.wantErr {
@@ -613,8 +621,9 @@ func TestReconcileManagedCluster_updateAutoImportRetry(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			r := &ReconcileManagedCluster{
-				client: tt.fields.client,
-				scheme: tt.fields.scheme,
+				client:   tt.fields.client,
+				recorder: record.NewFakeRecorder(10),
+				scheme:   tt.fields.scheme,
 			}
 			if err := r.updateAutoImportRetry(tt.args.managedCluster, tt.args.autoImportSecret); (err != nil) != tt.wantErr {
 				t.Errorf("ReconcileManagedCluster.updateAutoImportRetry() error = %v, wantErr %v", err, tt.wantErr)
@@ -641,3 +650,93 @@ func TestReconcileManagedCluster_updateAutoImportRetry(t *testing.T) {
 		})
 	}
 }
+
+func Test_getRestConfigFromToken(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"major":"1","minor":"20","gitVersion":"v1.20.0"}`)
+	}))
+	defer server.Close()
+	ca := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	tests := []struct {
+		name                  string
+		ca                    []byte
+		insecureSkipTLSVerify bool
+		wantTLSErr            bool
+	}{
+		{
+			name:       "unknown authority",
+			wantTLSErr: true,
+		},
+		{
+			name: "verified with ca.crt",
+			ca:   ca,
+		},
+		{
+			name:                  "insecure",
+			insecureSkipTLSVerify: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, err := getRestConfigFromToken("token", server.URL, tt.ca, tt.insecureSkipTLSVerify)
+			if err != nil {
+				t.Fatalf("getRestConfigFromToken() unexpected error %v", err)
+			}
+			_, err = getManagedClusterKubeVersion(config)
+			if tt.wantTLSErr {
+				if !isTLSVerificationError(err) {
+					t.Errorf("getManagedClusterKubeVersion() error = %v, want a TLS verification error", err)
+				}
+				if got := autoImportFailureReason(err); got != "TLSVerificationFailed" {
+					t.Errorf("autoImportFailureReason() = %s, want TLSVerificationFailed", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("getManagedClusterKubeVersion() unexpected error %v", err)
+			}
+		})
+	}
+
+	if _, err := getRestConfigFromToken("token", server.URL, ca, true); err == nil {
+		t.Error("getRestConfigFromToken() expected an error with both ca.crt and insecure-skip-tls-verify")
+	}
+}
+
+func Test_getInsecureSkipTLSVerify(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    map[string][]byte
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "not set",
+			data: map[string][]byte{},
+		},
+		{
+			name: "opt-in",
+			data: map[string][]byte{autoImportInsecureSkipTLSVerifyKey: []byte("true")},
+			want: true,
+		},
+		{
+			name:    "invalid",
+			data:    map[string][]byte{autoImportInsecureSkipTLSVerifyKey: []byte("yes please")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := getInsecureSkipTLSVerify(&corev1.Secret{Data: tt.data})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getInsecureSkipTLSVerify() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("getInsecureSkipTLSVerify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -10,14 +10,18 @@ import (
 
 	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
 	workv1 "github.com/open-cluster-management/api/work/v1"
+	configv1alpha1 "github.com/open-cluster-management/managedcluster-import-controller/pkg/apis/config/v1alpha1"
 	hivev1 "github.com/openshift/hive/apis/hive/v1"
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
-	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/version"
+	"k8s.io/client-go/kubernetes"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
@@ -29,15 +33,24 @@ const (
 var v1APIExtensionMinVersion = version.MustParseGeneric(_v1APIExtensionKubeMinVersion)
 
 // Add creates a new ManagedCluster Controller and adds it to the Manager. The Manager will set fields on the Controller
-// and Start it when the Manager is Started.
-func Add(mgr manager.Manager) error {
+// and Start it when the Manager is Started. The missingGVS are the GVs not served by the hub.
+func Add(mgr manager.Manager, missingGVS []schema.GroupVersion) error {
+	hubInfo = newHubInfoProvider(mgr.GetConfig(), mgr.GetAPIReader(), missingGVS)
+	if err := metrics.Registry.Register(newImportPhaseCollector(mgr.GetClient())); err != nil {
+		return err
+	}
 	return add(mgr, newReconciler(mgr))
 }
 
 // newReconciler returns a new reconcile.Reconciler
 func newReconciler(mgr manager.Manager) reconcile.Reconciler {
 	client := newCustomClient(mgr.GetClient(), mgr.GetAPIReader())
-	return &ReconcileManagedCluster{client: client, scheme: mgr.GetScheme()}
+	return &ReconcileManagedCluster{
+		client:     client,
+		kubeClient: kubernetes.NewForConfigOrDie(mgr.GetConfig()),
+		recorder:   mgr.GetEventRecorderFor("managedcluster-import-controller"),
+		scheme:     mgr.GetScheme(),
+	}
 }
 
 // add adds a new Controller to mgr with r as the reconcile.Reconciler
@@ -100,16 +113,7 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 	err = c.Watch(
 		&source.Kind{Type: &hivev1.ClusterDeployment{}},
 		&handler.EnqueueRequestsFromMapFunc{
-			ToRequests: handler.ToRequestsFunc(func(obj handler.MapObject) []reconcile.Request {
-				return []reconcile.Request{
-					{
-						NamespacedName: types.NamespacedName{
-							Name:      obj.Meta.GetName(),
-							Namespace: obj.Meta.GetNamespace(),
-						},
-					},
-				}
-			}),
+			ToRequests: newClusterDeploymentMapper(mgr.GetClient()),
 		},
 	)
 	if err != nil {
@@ -117,6 +121,52 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		return err
 	}
 
+	// The ClusterClaim CRD is installed with the recent versions of hive, watch it only if it is installed
+	_, err = mgr.GetRESTMapper().RESTMapping(schema.GroupKind{
+		Group: hivev1.HiveAPIGroup,
+		Kind:  "ClusterClaim",
+	}, hivev1.SchemeGroupVersion.Version)
+	switch {
+	case meta.IsNoMatchError(err):
+		log.Info("ClusterClaim CRD not installed, skip the watch of ClusterClaim")
+	case err != nil:
+		return err
+	default:
+		err = c.Watch(
+			&source.Kind{Type: &hivev1.ClusterClaim{}},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: newClusterClaimMapper(mgr.GetClient()),
+			},
+		)
+		if err != nil {
+			log.Error(err, "Fail to add Watch for ClusterClaim to controller")
+			return err
+		}
+	}
+
+	// The AgentClusterInstall CRD is installed with the Assisted Installer, watch it only if it is installed
+	_, err = mgr.GetRESTMapper().RESTMapping(schema.GroupKind{
+		Group: AgentClusterInstallGroupVersion.Group,
+		Kind:  agentClusterInstallKind,
+	}, AgentClusterInstallGroupVersion.Version)
+	switch {
+	case meta.IsNoMatchError(err):
+		log.Info("AgentClusterInstall CRD not installed, skip the watch of AgentClusterInstall")
+	case err != nil:
+		return err
+	default:
+		err = c.Watch(
+			&source.Kind{Type: newAgentClusterInstall()},
+			&handler.EnqueueRequestsFromMapFunc{
+				ToRequests: newAgentClusterInstallMapper(),
+			},
+		)
+		if err != nil {
+			log.Error(err, "Fail to add Watch for AgentClusterInstall to controller")
+			return err
+		}
+	}
+
 	err = c.Watch(
 		&source.Kind{Type: &rbacv1.ClusterRoleBinding{}},
 		&handler.EnqueueRequestForOwner{
@@ -141,5 +191,28 @@ func add(mgr manager.Manager, r reconcile.Reconciler) error {
 		log.Error(err, "Fail to add Watch for ManifestWork to controller")
 		return err
 	}
+
+	// The KlusterletConfig CRD is optional, watch it only if it is installed
+	_, err = mgr.GetRESTMapper().RESTMapping(schema.GroupKind{
+		Group: configv1alpha1.GroupName,
+		Kind:  "KlusterletConfig",
+	}, configv1alpha1.GroupVersion.Version)
+	if err != nil {
+		if meta.IsNoMatchError(err) {
+			log.Info("KlusterletConfig CRD not installed, skip the watch of KlusterletConfig")
+			return nil
+		}
+		return err
+	}
+	err = c.Watch(
+		&source.Kind{Type: &configv1alpha1.KlusterletConfig{}},
+		&handler.EnqueueRequestsFromMapFunc{
+			ToRequests: newKlusterletConfigMapper(mgr.GetClient()),
+		},
+	)
+	if err != nil {
+		log.Error(err, "Fail to add Watch for KlusterletConfig to controller")
+		return err
+	}
 	return nil
 }
@@ -0,0 +1,264 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricsNamespace = "managedcluster_import"
+
+// importDurationObservedAnnotation records on the ManagedCluster that its import durations are observed, they are
+// only observed when the cluster joins the hub for the first time, not when it recovers after being offline
+const importDurationObservedAnnotation = "import.open-cluster-management.io/import-duration-observed"
+
+// import phases reported by the phase gauge
+const (
+	importPhaseBootstrapToken = "BootstrapToken"
+	importPhaseImportSecret   = "ImportSecret"
+	importPhaseAutoImport     = "AutoImport"
+	importPhaseManualImport   = "ManualImport"
+	importPhaseJoining        = "Joining"
+	importPhaseAvailable      = "Available"
+	importPhaseDetaching      = "Detaching"
+)
+
+var importPhases = []string{
+	importPhaseBootstrapToken,
+	importPhaseImportSecret,
+	importPhaseAutoImport,
+	importPhaseManualImport,
+	importPhaseJoining,
+	importPhaseAvailable,
+	importPhaseDetaching,
+}
+
+var (
+	importDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "duration_seconds",
+		Help:      "Duration from the creation of a managed cluster to its availability.",
+		Buckets:   []float64{30, 60, 120, 300, 600, 1200, 1800, 3600, 7200},
+	}, []string{"created_via"})
+
+	autoImportAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "auto_import_attempts_total",
+		Help:      "Number of auto-import attempts.",
+	}, []string{"created_via"})
+
+	autoImportFailures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "auto_import_failures_total",
+		Help:      "Number of failed auto-import attempts.",
+	}, []string{"created_via", "reason"})
+
+	selfHealingAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "self_healing_attempts_total",
+		Help:      "Number of self-healing attempts of the offline managed clusters.",
+	}, []string{"created_via", "result"})
+
+	manifestWorkUpdates = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: metricsNamespace,
+		Name:      "manifestwork_updates_total",
+		Help:      "Number of klusterlet manifestworks created or updated.",
+	}, []string{"operation"})
+
+	installToImportDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "install_to_import_duration_seconds",
+		Help:      "Duration from the start of the installation of a hive cluster to its availability.",
+		Buckets:   []float64{300, 600, 1200, 1800, 2700, 3600, 5400, 7200, 10800},
+	}, []string{"created_via", "installed"})
+
+	detachDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Namespace: metricsNamespace,
+		Name:      "detach_duration_seconds",
+		Help:      "Duration from the deletion of a managed cluster to the removal of its finalizers.",
+		Buckets:   []float64{10, 30, 60, 120, 300, 600, 1800, 3600},
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		importDuration,
+		autoImportAttempts,
+		autoImportFailures,
+		selfHealingAttempts,
+		manifestWorkUpdates,
+		installToImportDuration,
+		detachDuration,
+	)
+}
+
+// getCreatedVia returns the created-via annotation of the managedCluster, used to label the metrics
+func getCreatedVia(managedCluster *clusterv1.ManagedCluster) string {
+	if createdVia := managedCluster.GetAnnotations()[createdViaAnnotation]; createdVia != "" {
+		return createdVia
+	}
+	return createdViaAnnotationOther
+}
+
+// autoImportFailureReason returns a low cardinality reason of an auto-import error
+func autoImportFailureReason(err error) string {
+	if reason := errors.ReasonForError(err); reason != metav1.StatusReasonUnknown {
+		return string(reason)
+	}
+	if isTLSVerificationError(err) {
+		return "TLSVerificationFailed"
+	}
+	if reason := getImportStageFailureReason(err); reason != "" {
+		return reason
+	}
+	if _, ok := err.(net.Error); ok {
+		return "Unreachable"
+	}
+	return "Error"
+}
+
+// observeImportDuration observes the import duration of the managedCluster once it becomes available,
+// wasJoining reports if the KlusterletAvailable condition was set and not True before the reconcile,
+// so the clusters imported before the controller reported this condition are not observed.
+func observeImportDuration(managedCluster *clusterv1.ManagedCluster, wasJoining bool) {
+	if !wasJoining {
+		return
+	}
+	available := meta.FindStatusCondition(managedCluster.Status.Conditions, clusterv1.ManagedClusterConditionAvailable)
+	if available == nil || available.Status != metav1.ConditionTrue {
+		return
+	}
+	duration := available.LastTransitionTime.Sub(managedCluster.CreationTimestamp.Time)
+	if duration < 0 {
+		return
+	}
+	importDuration.WithLabelValues(getCreatedVia(managedCluster)).Observe(duration.Seconds())
+}
+
+// observeInstallToImportDuration observes the duration from the start of the installation of the hive cluster
+// to its availability, the installed label reports if the ClusterDeployment was installed when it became available
+func observeInstallToImportDuration(managedCluster *clusterv1.ManagedCluster,
+	clusterDeployment *hivev1.ClusterDeployment, wasJoining bool) {
+	if !wasJoining || clusterDeployment == nil || clusterDeployment.Status.InstallStartedTimestamp == nil {
+		return
+	}
+	available := meta.FindStatusCondition(managedCluster.Status.Conditions, clusterv1.ManagedClusterConditionAvailable)
+	if available == nil || available.Status != metav1.ConditionTrue {
+		return
+	}
+	duration := available.LastTransitionTime.Sub(clusterDeployment.Status.InstallStartedTimestamp.Time)
+	if duration < 0 {
+		return
+	}
+	installToImportDuration.WithLabelValues(getCreatedVia(managedCluster),
+		strconv.FormatBool(clusterDeployment.Spec.Installed)).Observe(duration.Seconds())
+}
+
+// observeFirstJoin observes the import durations of the managedCluster the first time it becomes available,
+// klusterletAvailable is the KlusterletAvailable condition before the reconcile. The observation is recorded with
+// an annotation, the clusters already available when the annotation was introduced are recorded without observing.
+func observeFirstJoin(c client.Client, managedCluster *clusterv1.ManagedCluster,
+	clusterDeployment *hivev1.ClusterDeployment, klusterletAvailable *metav1.Condition) error {
+	if _, ok := managedCluster.GetAnnotations()[importDurationObservedAnnotation]; ok || klusterletAvailable == nil {
+		return nil
+	}
+	wasJoining := klusterletAvailable.Status != metav1.ConditionTrue
+	if wasJoining && !meta.IsStatusConditionTrue(managedCluster.Status.Conditions, clusterv1.ManagedClusterConditionAvailable) {
+		return nil
+	}
+	patch := client.MergeFrom(managedCluster.DeepCopy())
+	annotations := managedCluster.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[importDurationObservedAnnotation] = "true"
+	managedCluster.SetAnnotations(annotations)
+	if err := c.Patch(context.TODO(), managedCluster, patch); err != nil {
+		return err
+	}
+	observeImportDuration(managedCluster, wasJoining)
+	observeInstallToImportDuration(managedCluster, clusterDeployment, wasJoining)
+	return nil
+}
+
+// observeDetachDuration observes the detach duration of the managedCluster when its finalizers are removed
+func observeDetachDuration(managedCluster *clusterv1.ManagedCluster) {
+	if managedCluster.DeletionTimestamp == nil {
+		return
+	}
+	detachDuration.Observe(time.Since(managedCluster.DeletionTimestamp.Time).Seconds())
+}
+
+// getImportPhase returns the import phase of the managedCluster according to its conditions
+func getImportPhase(managedCluster *clusterv1.ManagedCluster) string {
+	conditions := managedCluster.Status.Conditions
+	switch {
+	case managedCluster.DeletionTimestamp != nil:
+		return importPhaseDetaching
+	case !checkOffLine(managedCluster):
+		return importPhaseAvailable
+	case meta.IsStatusConditionFalse(conditions, ManagedClusterBootstrapTokenReady):
+		return importPhaseBootstrapToken
+	case !meta.IsStatusConditionTrue(conditions, ManagedClusterImportSecretGenerated):
+		return importPhaseImportSecret
+	}
+	if autoImport := meta.FindStatusCondition(conditions, ManagedClusterAutoImportAttempted); autoImport != nil &&
+		autoImport.Status == metav1.ConditionFalse {
+		if autoImport.Reason == "ManualImport" {
+			return importPhaseManualImport
+		}
+		return importPhaseAutoImport
+	}
+	return importPhaseJoining
+}
+
+// importPhaseCollector reports the number of managed clusters in each import phase,
+// the managed clusters are listed from the cache when the metrics are collected
+type importPhaseCollector struct {
+	client client.Client
+	desc   *prometheus.Desc
+}
+
+func newImportPhaseCollector(client client.Client) *importPhaseCollector {
+	return &importPhaseCollector{
+		client: client,
+		desc: prometheus.NewDesc(
+			prometheus.BuildFQName(metricsNamespace, "", "phase_clusters"),
+			"Number of managed clusters in each import phase.",
+			[]string{"phase"}, nil,
+		),
+	}
+}
+
+func (c *importPhaseCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+func (c *importPhaseCollector) Collect(ch chan<- prometheus.Metric) {
+	managedClusters := &clusterv1.ManagedClusterList{}
+	if err := c.client.List(context.TODO(), managedClusters); err != nil {
+		log.Error(err, "Failed to list the managed clusters to collect the import phases")
+		ch <- prometheus.NewInvalidMetric(c.desc, err)
+		return
+	}
+	counts := map[string]int{}
+	for i := range managedClusters.Items {
+		counts[getImportPhase(&managedClusters.Items[i])]++
+	}
+	for _, phase := range importPhases {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, float64(counts[phase]), phase)
+	}
+}
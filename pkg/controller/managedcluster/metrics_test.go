@@ -0,0 +1,293 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newPhaseManagedCluster(name string, conditions ...metav1.Condition) *clusterv1.ManagedCluster {
+	return &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Status: clusterv1.ManagedClusterStatus{
+			Conditions: conditions,
+		},
+	}
+}
+
+func Test_getImportPhase(t *testing.T) {
+	now := metav1.Now()
+	detaching := newPhaseManagedCluster("detaching")
+	detaching.DeletionTimestamp = &now
+
+	tests := []struct {
+		name           string
+		managedCluster *clusterv1.ManagedCluster
+		want           string
+	}{
+		{
+			name:           "detaching",
+			managedCluster: detaching,
+			want:           importPhaseDetaching,
+		},
+		{
+			name: "available",
+			managedCluster: newPhaseManagedCluster("available",
+				metav1.Condition{Type: clusterv1.ManagedClusterConditionAvailable, Status: metav1.ConditionTrue}),
+			want: importPhaseAvailable,
+		},
+		{
+			name: "bootstrap token pending",
+			managedCluster: newPhaseManagedCluster("token",
+				metav1.Condition{Type: ManagedClusterBootstrapTokenReady, Status: metav1.ConditionFalse}),
+			want: importPhaseBootstrapToken,
+		},
+		{
+			name:           "import secret not generated",
+			managedCluster: newPhaseManagedCluster("secret"),
+			want:           importPhaseImportSecret,
+		},
+		{
+			name: "auto-import failed",
+			managedCluster: newPhaseManagedCluster("autoimport",
+				metav1.Condition{Type: ManagedClusterImportSecretGenerated, Status: metav1.ConditionTrue},
+				metav1.Condition{Type: ManagedClusterAutoImportAttempted, Status: metav1.ConditionFalse, Reason: "AutoImportFailed"}),
+			want: importPhaseAutoImport,
+		},
+		{
+			name: "manual import",
+			managedCluster: newPhaseManagedCluster("manual",
+				metav1.Condition{Type: ManagedClusterImportSecretGenerated, Status: metav1.ConditionTrue},
+				metav1.Condition{Type: ManagedClusterAutoImportAttempted, Status: metav1.ConditionFalse, Reason: "ManualImport"}),
+			want: importPhaseManualImport,
+		},
+		{
+			name: "joining",
+			managedCluster: newPhaseManagedCluster("joining",
+				metav1.Condition{Type: ManagedClusterImportSecretGenerated, Status: metav1.ConditionTrue},
+				metav1.Condition{Type: ManagedClusterAutoImportAttempted, Status: metav1.ConditionTrue}),
+			want: importPhaseJoining,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := getImportPhase(tt.managedCluster); got != tt.want {
+				t.Errorf("getImportPhase() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_autoImportFailureReason(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "api error",
+			err:  errors.NewUnauthorized("invalid token"),
+			want: "Unauthorized",
+		},
+		{
+			name: "network error",
+			err:  &net.OpError{Op: "dial", Err: fmt.Errorf("connection refused")},
+			want: "Unreachable",
+		},
+		{
+			name: "other error",
+			err:  fmt.Errorf("kubeconfig or token and server are missing"),
+			want: "Error",
+		},
+		{
+			name: "not found",
+			err:  errors.NewNotFound(schema.GroupResource{Resource: "secrets"}, "admin-kubeconfig"),
+			want: "NotFound",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := autoImportFailureReason(tt.err); got != tt.want {
+				t.Errorf("autoImportFailureReason() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_observeImportDuration(t *testing.T) {
+	importDuration.Reset()
+	created := time.Now().Add(-10 * time.Minute)
+	managedCluster := newPhaseManagedCluster("cluster1", metav1.Condition{
+		Type:               clusterv1.ManagedClusterConditionAvailable,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+	})
+	managedCluster.CreationTimestamp = metav1.NewTime(created)
+	managedCluster.Annotations = map[string]string{createdViaAnnotation: createdViaAnnotationHive}
+
+	// a cluster imported before the condition was reported is not observed
+	observeImportDuration(managedCluster, false)
+	if got := testutil.CollectAndCount(importDuration); got != 0 {
+		t.Errorf("observeImportDuration() collected %d metrics, want 0", got)
+	}
+
+	observeImportDuration(managedCluster, true)
+	if got := testutil.CollectAndCount(importDuration); got != 1 {
+		t.Errorf("observeImportDuration() collected %d metrics, want 1", got)
+	}
+}
+
+func Test_observeInstallToImportDuration(t *testing.T) {
+	installToImportDuration.Reset()
+	managedCluster := newPhaseManagedCluster("cluster1", metav1.Condition{
+		Type:               clusterv1.ManagedClusterConditionAvailable,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+	})
+	managedCluster.Annotations = map[string]string{createdViaAnnotation: createdViaAnnotationAI}
+	clusterDeployment := &hivev1.ClusterDeployment{}
+
+	// a cluster whose installation start is unknown is not observed
+	observeInstallToImportDuration(managedCluster, clusterDeployment, true)
+	if got := testutil.CollectAndCount(installToImportDuration); got != 0 {
+		t.Errorf("observeInstallToImportDuration() collected %d metrics, want 0", got)
+	}
+
+	started := metav1.NewTime(time.Now().Add(-30 * time.Minute))
+	clusterDeployment.Status.InstallStartedTimestamp = &started
+	observeInstallToImportDuration(managedCluster, clusterDeployment, true)
+	if got := testutil.CollectAndCount(installToImportDuration); got != 1 {
+		t.Errorf("observeInstallToImportDuration() collected %d metrics, want 1", got)
+	}
+}
+
+func Test_observeFirstJoin(t *testing.T) {
+	testscheme := runtime.NewScheme()
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{})
+	available := metav1.Condition{
+		Type:               clusterv1.ManagedClusterConditionAvailable,
+		Status:             metav1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+	}
+	unavailable := metav1.Condition{Type: clusterv1.ManagedClusterConditionAvailable, Status: metav1.ConditionUnknown}
+	notJoined := &metav1.Condition{Type: ManagedClusterKlusterletAvailable, Status: metav1.ConditionFalse, Reason: "KlusterletNotJoined"}
+	offline := &metav1.Condition{Type: ManagedClusterKlusterletAvailable, Status: metav1.ConditionFalse, Reason: "KlusterletUnavailable"}
+	online := &metav1.Condition{Type: ManagedClusterKlusterletAvailable, Status: metav1.ConditionTrue, Reason: "KlusterletAvailable"}
+
+	// each case is labelled by its own created-via annotation, the observed cases are the collected series
+	tests := []struct {
+		name                string
+		observed            bool
+		condition           metav1.Condition
+		klusterletAvailable *metav1.Condition
+		wantObserved        bool
+		wantAnnotated       bool
+	}{
+		{
+			name:                "first join",
+			condition:           available,
+			klusterletAvailable: notJoined,
+			wantObserved:        true,
+			wantAnnotated:       true,
+		},
+		{
+			name:                "joining",
+			condition:           unavailable,
+			klusterletAvailable: notJoined,
+		},
+		{
+			name:                "recovery after being offline",
+			observed:            true,
+			condition:           available,
+			klusterletAvailable: offline,
+			wantAnnotated:       true,
+		},
+		{
+			name:                "available before the annotation",
+			condition:           available,
+			klusterletAvailable: online,
+			wantAnnotated:       true,
+		},
+		{
+			name:      "imported before the condition",
+			condition: available,
+		},
+	}
+	importDuration.Reset()
+	want := 0
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedCluster := newPhaseManagedCluster("cluster1", tt.condition)
+			managedCluster.CreationTimestamp = metav1.NewTime(time.Now().Add(-10 * time.Minute))
+			managedCluster.Annotations = map[string]string{createdViaAnnotation: tt.name}
+			if tt.observed {
+				managedCluster.Annotations[importDurationObservedAnnotation] = "true"
+			}
+			c := fake.NewFakeClientWithScheme(testscheme, managedCluster)
+			if err := observeFirstJoin(c, managedCluster, nil, tt.klusterletAvailable); err != nil {
+				t.Errorf("observeFirstJoin() unexpected error %v", err)
+				return
+			}
+			if tt.wantObserved {
+				want++
+			}
+			if got := testutil.CollectAndCount(importDuration); got != want {
+				t.Errorf("observeFirstJoin() collected %d metrics, want %d", got, want)
+			}
+			updated := &clusterv1.ManagedCluster{}
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, updated); err != nil {
+				t.Errorf("unexpected error %v", err)
+				return
+			}
+			if _, ok := updated.Annotations[importDurationObservedAnnotation]; ok != tt.wantAnnotated {
+				t.Errorf("expected the annotation %s %v, got %v", importDurationObservedAnnotation, tt.wantAnnotated, updated.Annotations)
+			}
+		})
+	}
+}
+
+func Test_importPhaseCollector(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{}, &clusterv1.ManagedClusterList{})
+
+	c := fake.NewFakeClientWithScheme(testscheme, []runtime.Object{
+		newPhaseManagedCluster("available",
+			metav1.Condition{Type: clusterv1.ManagedClusterConditionAvailable, Status: metav1.ConditionTrue}),
+		newPhaseManagedCluster("secret1"),
+		newPhaseManagedCluster("secret2"),
+	}...)
+
+	expected := `
+# HELP managedcluster_import_phase_clusters Number of managed clusters in each import phase.
+# TYPE managedcluster_import_phase_clusters gauge
+managedcluster_import_phase_clusters{phase="AutoImport"} 0
+managedcluster_import_phase_clusters{phase="Available"} 1
+managedcluster_import_phase_clusters{phase="BootstrapToken"} 0
+managedcluster_import_phase_clusters{phase="Detaching"} 0
+managedcluster_import_phase_clusters{phase="ImportSecret"} 2
+managedcluster_import_phase_clusters{phase="Joining"} 0
+managedcluster_import_phase_clusters{phase="ManualImport"} 0
+`
+	if err := testutil.CollectAndCompare(newImportPhaseCollector(c), strings.NewReader(expected)); err != nil {
+		t.Errorf("importPhaseCollector unexpected metrics: %v", err)
+	}
+}
@@ -0,0 +1,218 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	"github.com/open-cluster-management/managedcluster-import-controller/pkg/helpers"
+	hivev1 "github.com/openshift/hive/apis/hive/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// selfHealingOfflineDurationEnvVarName is the environment variable enabling the self-healing of the
+	// imported clusters offline for longer than its duration, the self-healing is disabled if not set
+	selfHealingOfflineDurationEnvVarName = "SELF_HEALING_OFFLINE_DURATION"
+
+	// the annotations of the managed cluster recording the self-healing attempts since it went offline
+	selfHealingAttemptsAnnotation        = "import.open-cluster-management.io/self-healing-attempts"
+	selfHealingLastAttemptTimeAnnotation = "import.open-cluster-management.io/last-self-healing-time"
+)
+
+// getSelfHealingOfflineDuration returns the offline duration after which the klusterlet is imported again,
+// 0 if the self-healing is disabled
+func getSelfHealingOfflineDuration() (time.Duration, error) {
+	value := os.Getenv(selfHealingOfflineDurationEnvVarName)
+	if value == "" {
+		return 0, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration < 0 {
+		return 0, fmt.Errorf("invalid %s: %q", selfHealingOfflineDurationEnvVarName, value)
+	}
+	return duration, nil
+}
+
+// getSelfHealingOfflineSince returns the time the managedCluster went offline after having joined the hub,
+// nil if the managedCluster is available or never joined
+func getSelfHealingOfflineSince(managedCluster *clusterv1.ManagedCluster) *time.Time {
+	if !meta.IsStatusConditionTrue(managedCluster.Status.Conditions, clusterv1.ManagedClusterConditionJoined) {
+		return nil
+	}
+	available := meta.FindStatusCondition(managedCluster.Status.Conditions, clusterv1.ManagedClusterConditionAvailable)
+	if available == nil || available.Status == metav1.ConditionTrue {
+		return nil
+	}
+	return &available.LastTransitionTime.Time
+}
+
+// getSelfHealingLastAttemptTime returns the time of the last self-healing attempt of the managedCluster
+func getSelfHealingLastAttemptTime(managedCluster *clusterv1.ManagedCluster) *time.Time {
+	last, err := time.Parse(time.RFC3339, managedCluster.GetAnnotations()[selfHealingLastAttemptTimeAnnotation])
+	if err != nil {
+		return nil
+	}
+	return &last
+}
+
+// untilSelfHealing returns the duration before the next self-healing attempt of the offline managedCluster,
+// an attempt is made once the cluster is offline for offlineDuration, then every offlineDuration
+func untilSelfHealing(managedCluster *clusterv1.ManagedCluster, offlineSince time.Time,
+	offlineDuration time.Duration, now time.Time) time.Duration {
+	since := offlineSince
+	if last := getSelfHealingLastAttemptTime(managedCluster); last != nil && last.After(since) {
+		since = *last
+	}
+	return since.Add(offlineDuration).Sub(now)
+}
+
+// recordSelfHealingAttempt records a self-healing attempt at now in the annotations of the managedCluster
+// and returns the number of attempts since the cluster went offline
+func recordSelfHealingAttempt(managedCluster *clusterv1.ManagedCluster, offlineSince, now time.Time) int {
+	attempts := 0
+	if last := getSelfHealingLastAttemptTime(managedCluster); last != nil && last.After(offlineSince) {
+		attempts, _ = strconv.Atoi(managedCluster.GetAnnotations()[selfHealingAttemptsAnnotation])
+	}
+	attempts++
+	annotations := managedCluster.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[selfHealingAttemptsAnnotation] = strconv.Itoa(attempts)
+	annotations[selfHealingLastAttemptTimeAnnotation] = now.UTC().Format(time.RFC3339)
+	managedCluster.SetAnnotations(annotations)
+	return attempts
+}
+
+// inspectKlusterlet returns a description of the klusterlet namespace of the managed cluster
+func inspectKlusterlet(managedClusterClient client.Client, namespace string) string {
+	ns := &corev1.Namespace{}
+	if err := managedClusterClient.Get(context.TODO(), types.NamespacedName{Name: namespace}, ns); err != nil {
+		if errors.IsNotFound(err) {
+			return fmt.Sprintf("the namespace %s is not found", namespace)
+		}
+		return fmt.Sprintf("unable to get the namespace %s: %v", namespace, err)
+	}
+	if ns.DeletionTimestamp != nil {
+		return fmt.Sprintf("the namespace %s is terminating", namespace)
+	}
+	deployments := &appsv1.DeploymentList{}
+	if err := managedClusterClient.List(context.TODO(), deployments, client.InNamespace(namespace)); err != nil {
+		return fmt.Sprintf("unable to list the deployments of the namespace %s: %v", namespace, err)
+	}
+	if len(deployments.Items) == 0 {
+		return fmt.Sprintf("no deployment in the namespace %s", namespace)
+	}
+	statuses := make([]string, 0, len(deployments.Items))
+	for _, deployment := range deployments.Items {
+		replicas := int32(1)
+		if deployment.Spec.Replicas != nil {
+			replicas = *deployment.Spec.Replicas
+		}
+		statuses = append(statuses, fmt.Sprintf("%s %d/%d ready", deployment.Name, deployment.Status.ReadyReplicas, replicas))
+	}
+	return fmt.Sprintf("the deployments of the namespace %s are %s", namespace, strings.Join(statuses, ", "))
+}
+
+// selfHeal imports again the klusterlet of the managedCluster offline for longer than offlineDuration.
+// The klusterlet namespace is inspected and the import manifests are applied, each attempt is recorded
+// in the annotations of the managedCluster and with an event. An attempt waiting for a stage of the import
+// is checked again at importStageRequeueInterval and succeeds once all the stages are done.
+func (r *ReconcileManagedCluster) selfHeal(
+	managedCluster *clusterv1.ManagedCluster,
+	clusterDeployment *hivev1.ClusterDeployment,
+	autoImportSecret *corev1.Secret,
+	offlineSince time.Time,
+	offlineDuration time.Duration) (reconcile.Result, error) {
+	now := time.Now()
+	attempts, _ := strconv.Atoi(managedCluster.GetAnnotations()[selfHealingAttemptsAnnotation])
+	if getImportStageProgress(managedCluster) == nil {
+		if wait := untilSelfHealing(managedCluster, offlineSince, offlineDuration, now); wait > 0 {
+			klog.Infof("Next self-healing attempt of %s in %s", managedCluster.Name, wait)
+			return reconcile.Result{RequeueAfter: wait}, nil
+		}
+
+		patch := client.MergeFrom(managedCluster.DeepCopy())
+		attempts = recordSelfHealingAttempt(managedCluster, offlineSince, now)
+		if err := r.client.Patch(context.TODO(), managedCluster, patch); err != nil {
+			return reconcile.Result{}, err
+		}
+	}
+
+	result, err := r.selfHealWithClient(managedCluster, clusterDeployment, autoImportSecret, attempts, offlineSince)
+	if err != nil {
+		// a failed attempt is not continued, the next one starts again from the first stage
+		if errProgress := setImportStageProgress(r.client, managedCluster, nil); errProgress != nil {
+			return reconcile.Result{}, errProgress
+		}
+		selfHealingAttempts.WithLabelValues(getCreatedVia(managedCluster), "failed").Inc()
+		r.recorder.Eventf(managedCluster, corev1.EventTypeWarning, "SelfHealingFailed",
+			"Self-healing attempt %d failed, next attempt in %s: %v", attempts, offlineDuration, err)
+		// the next attempt is delayed by the offline duration, the error is reported with the condition only
+		return reconcile.Result{RequeueAfter: offlineDuration}, helpers.SetManagedClusterCondition(r.client, managedCluster, metav1.Condition{
+			Type:    ManagedClusterAutoImportAttempted,
+			Status:  metav1.ConditionFalse,
+			Reason:  "SelfHealingFailed",
+			Message: err.Error(),
+		})
+	}
+	if result.Requeue {
+		// a stage of the import is waiting for its objects, the attempt is checked again
+		klog.Infof("Self-healing attempt %d of %s is waiting for the import stage %s", attempts, managedCluster.Name,
+			getImportStageProgress(managedCluster).stage)
+		return reconcile.Result{Requeue: true, RequeueAfter: importStageRequeueInterval}, nil
+	}
+	selfHealingAttempts.WithLabelValues(getCreatedVia(managedCluster), "succeeded").Inc()
+	r.recorder.Eventf(managedCluster, corev1.EventTypeNormal, "SelfHealingSucceeded",
+		"Self-healing attempt %d succeeded, the klusterlet is applied on the managed cluster", attempts)
+	return reconcile.Result{RequeueAfter: offlineDuration}, helpers.SetManagedClusterCondition(r.client, managedCluster, metav1.Condition{
+		Type:    ManagedClusterAutoImportAttempted,
+		Status:  metav1.ConditionTrue,
+		Reason:  "SelfHealingSucceeded",
+		Message: fmt.Sprintf("The klusterlet is applied on the managed cluster by the self-healing attempt %d", attempts),
+	})
+}
+
+func (r *ReconcileManagedCluster) selfHealWithClient(
+	managedCluster *clusterv1.ManagedCluster,
+	clusterDeployment *hivev1.ClusterDeployment,
+	autoImportSecret *corev1.Secret,
+	attempts int,
+	offlineSince time.Time) (reconcile.Result, error) {
+	managedClusterClient, rConfig, err := r.getManagedClusterClient(managedCluster, clusterDeployment, autoImportSecret)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	klusterletConfig, err := getKlusterletConfig(r.client, managedCluster)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	//The klusterlet is inspected once per attempt, not each time a stage is checked again
+	if getImportStageProgress(managedCluster) == nil {
+		r.recorder.Eventf(managedCluster, corev1.EventTypeNormal, "SelfHealingAttempt",
+			"Self-healing attempt %d, the cluster is offline since %s and %s", attempts,
+			offlineSince.UTC().Format(time.RFC3339), inspectKlusterlet(managedClusterClient, getKlusterletNamespace(klusterletConfig)))
+	}
+
+	managedClusterKubeVersion, err := getManagedClusterKubeVersion(rConfig)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+	return r.importClusterWithClient(managedCluster, autoImportSecret, managedClusterClient, managedClusterKubeVersion)
+}
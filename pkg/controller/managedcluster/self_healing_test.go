@@ -0,0 +1,300 @@
+// Copyright Contributors to the Open Cluster Management project
+
+//Package managedcluster ...
+package managedcluster
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newOfflineManagedCluster(joined bool, available metav1.ConditionStatus, since time.Time,
+	lastAttempt *time.Time, attempts string) *clusterv1.ManagedCluster {
+	managedCluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "cluster1",
+			Annotations: map[string]string{},
+		},
+	}
+	if joined {
+		managedCluster.Status.Conditions = append(managedCluster.Status.Conditions, metav1.Condition{
+			Type:   clusterv1.ManagedClusterConditionJoined,
+			Status: metav1.ConditionTrue,
+		})
+	}
+	if available != "" {
+		managedCluster.Status.Conditions = append(managedCluster.Status.Conditions, metav1.Condition{
+			Type:               clusterv1.ManagedClusterConditionAvailable,
+			Status:             available,
+			LastTransitionTime: metav1.NewTime(since),
+		})
+	}
+	if lastAttempt != nil {
+		managedCluster.Annotations[selfHealingLastAttemptTimeAnnotation] = lastAttempt.UTC().Format(time.RFC3339)
+		managedCluster.Annotations[selfHealingAttemptsAnnotation] = attempts
+	}
+	return managedCluster
+}
+
+func Test_getSelfHealingOfflineDuration(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{value: "", want: 0},
+		{value: "10m", want: 10 * time.Minute},
+		{value: "-1m", wantErr: true},
+		{value: "ten", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			os.Setenv(selfHealingOfflineDurationEnvVarName, tt.value)
+			defer os.Unsetenv(selfHealingOfflineDurationEnvVarName)
+			got, err := getSelfHealingOfflineDuration()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getSelfHealingOfflineDuration() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("getSelfHealingOfflineDuration() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_getSelfHealingOfflineSince(t *testing.T) {
+	since := time.Now().Add(-time.Hour).Truncate(time.Second)
+	tests := []struct {
+		name           string
+		managedCluster *clusterv1.ManagedCluster
+		want           *time.Time
+	}{
+		{
+			name:           "never joined",
+			managedCluster: newOfflineManagedCluster(false, metav1.ConditionUnknown, since, nil, ""),
+		},
+		{
+			name:           "available",
+			managedCluster: newOfflineManagedCluster(true, metav1.ConditionTrue, since, nil, ""),
+		},
+		{
+			name:           "offline",
+			managedCluster: newOfflineManagedCluster(true, metav1.ConditionUnknown, since, nil, ""),
+			want:           &since,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := getSelfHealingOfflineSince(tt.managedCluster)
+			if (got == nil) != (tt.want == nil) || (got != nil && !got.Equal(*tt.want)) {
+				t.Errorf("getSelfHealingOfflineSince() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_untilSelfHealing(t *testing.T) {
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	since := now.Add(-30 * time.Minute)
+	lastAttempt := now.Add(-5 * time.Minute)
+	staleAttempt := now.Add(-time.Hour)
+	tests := []struct {
+		name           string
+		managedCluster *clusterv1.ManagedCluster
+		offlineSince   time.Time
+		want           time.Duration
+	}{
+		{
+			name:           "offline for less than the duration",
+			managedCluster: newOfflineManagedCluster(true, metav1.ConditionUnknown, since, nil, ""),
+			offlineSince:   since,
+			want:           10 * time.Minute,
+		},
+		{
+			name:           "offline for longer than the duration",
+			managedCluster: newOfflineManagedCluster(true, metav1.ConditionUnknown, now.Add(-time.Hour), nil, ""),
+			offlineSince:   now.Add(-time.Hour),
+			want:           -20 * time.Minute,
+		},
+		{
+			name:           "recent attempt",
+			managedCluster: newOfflineManagedCluster(true, metav1.ConditionUnknown, since, &lastAttempt, "1"),
+			offlineSince:   since,
+			want:           35 * time.Minute,
+		},
+		{
+			name:           "attempt before going offline",
+			managedCluster: newOfflineManagedCluster(true, metav1.ConditionUnknown, since, &staleAttempt, "3"),
+			offlineSince:   since,
+			want:           10 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := untilSelfHealing(tt.managedCluster, tt.offlineSince, 40*time.Minute, now); got != tt.want {
+				t.Errorf("untilSelfHealing() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_recordSelfHealingAttempt(t *testing.T) {
+	now := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	since := now.Add(-30 * time.Minute)
+	lastAttempt := now.Add(-5 * time.Minute)
+	staleAttempt := now.Add(-time.Hour)
+	tests := []struct {
+		name           string
+		managedCluster *clusterv1.ManagedCluster
+		want           int
+	}{
+		{
+			name:           "first attempt",
+			managedCluster: newOfflineManagedCluster(true, metav1.ConditionUnknown, since, nil, ""),
+			want:           1,
+		},
+		{
+			name:           "next attempt",
+			managedCluster: newOfflineManagedCluster(true, metav1.ConditionUnknown, since, &lastAttempt, "2"),
+			want:           3,
+		},
+		{
+			name:           "first attempt since going offline again",
+			managedCluster: newOfflineManagedCluster(true, metav1.ConditionUnknown, since, &staleAttempt, "2"),
+			want:           1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := recordSelfHealingAttempt(tt.managedCluster, since, now); got != tt.want {
+				t.Errorf("recordSelfHealingAttempt() = %d, want %d", got, tt.want)
+			}
+			if last := getSelfHealingLastAttemptTime(tt.managedCluster); last == nil || !last.Equal(now) {
+				t.Errorf("expected the last attempt time %s, got %v", now, last)
+			}
+		})
+	}
+}
+
+func Test_inspectKlusterlet(t *testing.T) {
+	replicas := int32(1)
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: klusterletNamespace}}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "klusterlet", Namespace: klusterletNamespace},
+		Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+	}
+	tests := []struct {
+		name    string
+		objects []runtime.Object
+		want    string
+	}{
+		{
+			name: "namespace not found",
+			want: "the namespace open-cluster-management-agent is not found",
+		},
+		{
+			name:    "no deployment",
+			objects: []runtime.Object{namespace},
+			want:    "no deployment in the namespace open-cluster-management-agent",
+		},
+		{
+			name:    "deployment not ready",
+			objects: []runtime.Object{namespace, deployment},
+			want:    "the deployments of the namespace open-cluster-management-agent are klusterlet 0/1 ready",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewFakeClientWithScheme(scheme.Scheme, tt.objects...)
+			if got := inspectKlusterlet(c, klusterletNamespace); got != tt.want {
+				t.Errorf("inspectKlusterlet() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileManagedCluster_selfHeal(t *testing.T) {
+	now := time.Now()
+	lastAttempt := now.Add(-5 * time.Minute)
+	managedCluster := newOfflineManagedCluster(true, metav1.ConditionUnknown, now.Add(-time.Hour), &lastAttempt, "1")
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{})
+	recorder := record.NewFakeRecorder(10)
+	r := &ReconcileManagedCluster{
+		client:   fake.NewFakeClientWithScheme(testscheme, managedCluster),
+		scheme:   testscheme,
+		recorder: recorder,
+	}
+
+	// an attempt was made 5 minutes ago, the next one is in 5 minutes
+	result, err := r.selfHeal(managedCluster, nil, nil, now.Add(-time.Hour), 10*time.Minute)
+	if err != nil {
+		t.Errorf("selfHeal() unexpected error %v", err)
+		return
+	}
+	if result.RequeueAfter <= 0 || result.RequeueAfter > 5*time.Minute {
+		t.Errorf("expected a requeue in less than 5m, got %s", result.RequeueAfter)
+	}
+
+	// the retained auto-import-secret has no credentials, the attempt fails
+	_, err = r.selfHeal(managedCluster, nil, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: autoImportSecretName, Namespace: "cluster1"},
+	}, now.Add(-time.Hour), time.Minute)
+	if err != nil {
+		t.Errorf("selfHeal() unexpected error %v", err)
+		return
+	}
+	updated := &clusterv1.ManagedCluster{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, updated); err != nil {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+	if updated.Annotations[selfHealingAttemptsAnnotation] != "2" {
+		t.Errorf("expected the attempt 2 to be recorded, got %v", updated.Annotations)
+	}
+	if event := <-recorder.Events; !strings.Contains(event, "SelfHealingFailed") {
+		t.Errorf("expected a SelfHealingFailed event, got %s", event)
+	}
+
+	// the attempt 2 is waiting for the operator stage, it is continued without waiting for the next attempt
+	updated.Annotations[importStageAnnotation] = importStageOperator + "/" + now.UTC().Format(time.RFC3339)
+	if err := r.client.Update(context.TODO(), updated); err != nil {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+	_, err = r.selfHeal(updated, nil, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: autoImportSecretName, Namespace: "cluster1"},
+	}, now.Add(-time.Hour), time.Hour)
+	if err != nil {
+		t.Errorf("selfHeal() unexpected error %v", err)
+		return
+	}
+	continued := &clusterv1.ManagedCluster{}
+	if err := r.client.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, continued); err != nil {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+	if continued.Annotations[selfHealingAttemptsAnnotation] != "2" {
+		t.Errorf("expected the attempt 2 to be continued, got %v", continued.Annotations)
+	}
+	if _, ok := continued.Annotations[importStageAnnotation]; ok {
+		t.Errorf("expected the stage of the failed attempt to be removed, got %v", continued.Annotations)
+	}
+	if event := <-recorder.Events; !strings.Contains(event, "Self-healing attempt 2 failed") {
+		t.Errorf("expected a SelfHealingFailed event of the attempt 2, got %s", event)
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright Contributors to the Open Cluster Management project
+
+// Package helpers contains the functions shared by the controllers
+package helpers
+
+import (
+	"context"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SetManagedClusterCondition sets the condition on the status of the managedCluster, the status is updated
+// only if the condition changes. The status is updated from the latest managedCluster with its resource version
+// and retried on conflict, to not overwrite the conditions set by the other controllers, such as the registration
+// controller. The managedCluster is refreshed with the updated status.
+func SetManagedClusterCondition(c client.Client, managedCluster *clusterv1.ManagedCluster, condition metav1.Condition) error {
+	if !conditionChanged(managedCluster, condition) {
+		return nil
+	}
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		latest := &clusterv1.ManagedCluster{}
+		if err := c.Get(context.TODO(), types.NamespacedName{Name: managedCluster.Name}, latest); err != nil {
+			return err
+		}
+		if conditionChanged(latest, condition) {
+			meta.SetStatusCondition(&latest.Status.Conditions, condition)
+			if err := c.Status().Update(context.TODO(), latest); err != nil {
+				return err
+			}
+		}
+		latest.DeepCopyInto(managedCluster)
+		return nil
+	})
+}
+
+// conditionChanged returns true if the condition differs from the one on the status of the managedCluster
+func conditionChanged(managedCluster *clusterv1.ManagedCluster, condition metav1.Condition) bool {
+	existing := meta.FindStatusCondition(managedCluster.Status.Conditions, condition.Type)
+	return existing == nil ||
+		existing.Status != condition.Status ||
+		existing.Reason != condition.Reason ||
+		existing.Message != condition.Message
+}
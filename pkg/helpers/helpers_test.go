@@ -0,0 +1,142 @@
+// Copyright Contributors to the Open Cluster Management project
+
+package helpers
+
+import (
+	"context"
+	"testing"
+
+	clusterv1 "github.com/open-cluster-management/api/cluster/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestSetManagedClusterCondition(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{})
+
+	pending := metav1.Condition{
+		Type:    "ManagedClusterBootstrapTokenReady",
+		Status:  metav1.ConditionFalse,
+		Reason:  "BootstrapTokenPending",
+		Message: "Waiting for the token controller to populate the secret",
+	}
+	ready := metav1.Condition{
+		Type:    "ManagedClusterBootstrapTokenReady",
+		Status:  metav1.ConditionTrue,
+		Reason:  "BootstrapTokenReady",
+		Message: "The bootstrap token is ready",
+	}
+
+	tests := []struct {
+		name     string
+		existing []metav1.Condition
+		set      metav1.Condition
+		want     metav1.Condition
+	}{
+		{
+			name: "add the condition",
+			set:  pending,
+			want: pending,
+		},
+		{
+			name:     "update the condition",
+			existing: []metav1.Condition{pending},
+			set:      ready,
+			want:     ready,
+		},
+		{
+			name:     "condition unchanged",
+			existing: []metav1.Condition{ready},
+			set:      ready,
+			want:     ready,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			managedCluster := &clusterv1.ManagedCluster{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cluster1",
+				},
+				Status: clusterv1.ManagedClusterStatus{
+					Conditions: tt.existing,
+				},
+			}
+			c := fake.NewFakeClientWithScheme(testscheme, managedCluster.DeepCopy())
+			if err := SetManagedClusterCondition(c, managedCluster, tt.set); err != nil {
+				t.Errorf("SetManagedClusterCondition() unexpected error %v", err)
+				return
+			}
+			got := &clusterv1.ManagedCluster{}
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, got); err != nil {
+				t.Errorf("unexpected error %v", err)
+				return
+			}
+			if len(got.Status.Conditions) != 1 {
+				t.Errorf("SetManagedClusterCondition() conditions = %v", got.Status.Conditions)
+				return
+			}
+			condition := got.Status.Conditions[0]
+			if condition.Type != tt.want.Type || condition.Status != tt.want.Status ||
+				condition.Reason != tt.want.Reason || condition.Message != tt.want.Message {
+				t.Errorf("SetManagedClusterCondition() = %v, want %v", condition, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetManagedClusterCondition_staleManagedCluster(t *testing.T) {
+	testscheme := scheme.Scheme
+	testscheme.AddKnownTypes(clusterv1.SchemeGroupVersion, &clusterv1.ManagedCluster{})
+
+	managedCluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cluster1",
+		},
+	}
+	c := fake.NewFakeClientWithScheme(testscheme, managedCluster.DeepCopy())
+
+	// the registration controller sets its condition after the managedCluster was read
+	latest := &clusterv1.ManagedCluster{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, latest); err != nil {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+	meta.SetStatusCondition(&latest.Status.Conditions, metav1.Condition{
+		Type:    clusterv1.ManagedClusterConditionJoined,
+		Status:  metav1.ConditionTrue,
+		Reason:  "ManagedClusterJoined",
+		Message: "Managed cluster joined",
+	})
+	if err := c.Status().Update(context.TODO(), latest); err != nil {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+
+	err := SetManagedClusterCondition(c, managedCluster, metav1.Condition{
+		Type:    "ManagedClusterImportSucceeded",
+		Status:  metav1.ConditionTrue,
+		Reason:  "ManagedClusterImported",
+		Message: "Import succeeded",
+	})
+	if err != nil {
+		t.Errorf("SetManagedClusterCondition() unexpected error %v", err)
+		return
+	}
+	got := &clusterv1.ManagedCluster{}
+	if err := c.Get(context.TODO(), types.NamespacedName{Name: "cluster1"}, got); err != nil {
+		t.Errorf("unexpected error %v", err)
+		return
+	}
+	if !meta.IsStatusConditionTrue(got.Status.Conditions, clusterv1.ManagedClusterConditionJoined) ||
+		!meta.IsStatusConditionTrue(got.Status.Conditions, "ManagedClusterImportSucceeded") {
+		t.Errorf("SetManagedClusterCondition() conditions = %v", got.Status.Conditions)
+	}
+	if !meta.IsStatusConditionTrue(managedCluster.Status.Conditions, clusterv1.ManagedClusterConditionJoined) {
+		t.Errorf("SetManagedClusterCondition() did not refresh the managedCluster, conditions = %v",
+			managedCluster.Status.Conditions)
+	}
+}